@@ -10,6 +10,9 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/cache"
+	"github.com/cosmos/cosmos-sdk/store/eventsink"
+	"github.com/cosmos/cosmos-sdk/store/streaming"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
@@ -116,6 +119,9 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 		panic(err)
 	}
 
+	if app.storeMetrics != nil {
+		app.storeMetrics.BeginBlock(req.Header.Height)
+	}
 	// Initialize the DeliverTx state. If this is the first block, it should
 	// already be initialized in InitChain. Otherwise app.deliverState will be
 	// nil, since it is reset on Commit.
@@ -139,10 +145,23 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 
 	app.deliverState.ctx = app.deliverState.ctx.WithBlockGasMeter(gasMeter)
 
+	// apply the transient store params in the state of the block
+	app.deliverState.ctx = app.withTransientGasConfig(app.deliverState.ctx)
+	app.transientLimiter.BeginBlock(req.Header.Height, app.getTransientStoreLimit(app.deliverState.ctx))
+
 	if app.beginBlocker != nil {
 		res = app.beginBlocker(app.deliverState.ctx, req)
 	}
 
+	if app.eventSink != nil {
+		app.eventSink.BeginBlock(req.Header.Height, res.Events)
+	}
+
+	if app.streamingService != nil {
+		app.streamingBlock.RequestBeginBlock = req
+		app.streamingBlock.ResponseBeginBlock = res
+	}
+
 	// set the signed validators for addition to context in deliverTx
 	app.voteInfos = req.LastCommitInfo.GetVotes()
 	return res
@@ -158,6 +177,15 @@ func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBloc
 		res = app.endBlocker(app.deliverState.ctx, req)
 	}
 
+	if app.eventSink != nil {
+		app.eventSink.EndBlock(res.Events)
+	}
+
+	if app.streamingService != nil {
+		app.streamingBlock.RequestEndBlock = req
+		app.streamingBlock.ResponseEndBlock = res
+	}
+
 	return
 }
 
@@ -205,7 +233,16 @@ func (app *BaseApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
 // Otherwise, the ResponseDeliverTx will contain releveant error information.
 // Regardless of tx execution outcome, the ResponseDeliverTx will contain relevant
 // gas execution context.
-func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
+func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDeliverTx) {
+	if app.eventSink != nil {
+		defer func() { app.eventSink.DeliverTx(req.Tx, res) }()
+	}
+	if app.streamingService != nil {
+		defer func() {
+			app.streamingBlock.DeliverTxs = append(app.streamingBlock.DeliverTxs, streaming.Tx{Request: req, Response: res})
+		}()
+	}
+
 	tx, err := app.txDecoder(req.Tx)
 	if err != nil {
 		return sdkerrors.ResponseDeliverTx(err, 0, 0, app.trace)
@@ -235,10 +272,25 @@ func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx
 func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	header := app.deliverState.ctx.BlockHeader()
 
+	if app.storeMetrics != nil {
+		app.storeMetrics.EndBlock()
+	}
+	app.transientLimiter.EndBlock()
+
 	// Write the DeliverTx state which is cache-wrapped and commit the MultiStore.
 	// The write to the DeliverTx state writes all state transitions to the root
 	// MultiStore (app.cms) so when Commit() is called is persists those values.
 	app.deliverState.ms.Write()
+
+	// The events are indexed before the state is committed, and the node halts
+	// if they can't be written, so that the block is executed again and its
+	// events indexed on restart rather than missing from the sink.
+	if app.eventSink != nil {
+		if err := app.eventSink.Commit(); err != nil {
+			panic(err)
+		}
+	}
+
 	commitID := app.cms.Commit()
 	app.logger.Debug("Commit synced", "commit", fmt.Sprintf("%X", commitID))
 
@@ -251,6 +303,14 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	// empty/reset the deliver state
 	app.deliverState = nil
 
+	res = abci.ResponseCommit{
+		Data: commitID.Hash,
+	}
+
+	if app.streamingService != nil {
+		app.streamBlock(res)
+	}
+
 	var halt bool
 
 	switch {
@@ -269,9 +329,7 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 		app.halt()
 	}
 
-	return abci.ResponseCommit{
-		Data: commitID.Hash,
-	}
+	return res
 }
 
 // halt attempts to gracefully shutdown the node via SIGINT and SIGTERM falling
@@ -356,6 +414,57 @@ func handleQueryApp(app *BaseApp, path []string, req abci.RequestQuery) abci.Res
 				Value:     []byte(app.appVersion),
 			}
 
+		case "events":
+			if app.eventSink == nil {
+				return sdkerrors.QueryResult(sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "event sink is not enabled"))
+			}
+
+			var query eventsink.Query
+			if err := codec.Cdc.UnmarshalJSON(req.Data, &query); err != nil {
+				return sdkerrors.QueryResult(sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error()))
+			}
+
+			result, err := app.eventSink.Search(query)
+			if err != nil {
+				return sdkerrors.QueryResult(sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error()))
+			}
+
+			return abci.ResponseQuery{
+				Codespace: sdkerrors.RootCodespace,
+				Height:    app.eventSink.LastHeight(),
+				Value:     codec.Cdc.MustMarshalJSON(result),
+			}
+
+		case "store_metrics":
+			if app.storeMetrics == nil {
+				return sdkerrors.QueryResult(sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "store metrics are not enabled"))
+			}
+
+			return abci.ResponseQuery{
+				Codespace: sdkerrors.RootCodespace,
+				Height:    req.Height,
+				Value:     codec.Cdc.MustMarshalJSON(app.storeMetrics.Stats()),
+			}
+
+		case "transient_usage":
+			return abci.ResponseQuery{
+				Codespace: sdkerrors.RootCodespace,
+				Height:    req.Height,
+				Value:     codec.Cdc.MustMarshalJSON(app.transientLimiter.Stats()),
+			}
+
+		case "inter_block_cache":
+			cmgr, ok := app.interBlockCache.(*cache.CommitKVStoreCacheManager)
+			if !ok {
+				return sdkerrors.QueryResult(sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "inter-block cache stats are not available"))
+			}
+
+			return abci.ResponseQuery{
+				Codespace: sdkerrors.RootCodespace,
+				Height:    req.Height,
+				Value:     codec.Cdc.MustMarshalJSON(cmgr.Stats()),
+			}
+
 		default:
 			return sdkerrors.QueryResult(sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown query: %s", path))
 		}
@@ -364,7 +473,7 @@ func handleQueryApp(app *BaseApp, path []string, req abci.RequestQuery) abci.Res
 	return sdkerrors.QueryResult(
 		sdkerrors.Wrap(
 			sdkerrors.ErrUnknownRequest,
-			"expected second parameter to be either 'simulate' or 'version', neither was present",
+			"expected second parameter to be either 'simulate', 'version', 'events', 'store_metrics', 'transient_usage' or 'inter_block_cache', none was present",
 		),
 	)
 }
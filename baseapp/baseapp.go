@@ -21,6 +21,12 @@ import (
 	dbm "github.com/tendermint/tm-db"
 
 	"github.com/cosmos/cosmos-sdk/store"
+	"github.com/cosmos/cosmos-sdk/store/cache"
+	"github.com/cosmos/cosmos-sdk/store/eventsink"
+	"github.com/cosmos/cosmos-sdk/store/listenkv"
+	"github.com/cosmos/cosmos-sdk/store/metrics"
+	"github.com/cosmos/cosmos-sdk/store/streaming"
+	"github.com/cosmos/cosmos-sdk/store/transient"
 	"github.com/cosmos/cosmos-sdk/store/rootmulti"
 	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -117,6 +123,28 @@ type BaseApp struct { // nolint: maligned
 	// an inter-block write-through cache provided to the context during deliverState
 	interBlockCache sdk.MultiStorePersistentCache
 
+	// an optional collector of the metrics of the stores accessed during deliverState
+	storeMetrics *metrics.Collector
+
+	// the limiter of the bytes written to the transient stores during
+	// deliverState, with the limit of the transient store limit param
+	transientLimiter *transient.Limiter
+
+	// an optional store of the params of BaseApp, which are part of the state
+	paramStore ParamStore
+
+	// an optional listener of the writes to the stores of deliverState
+	storeWriteListener sdk.WriteListener
+
+	// an optional sink indexing the events of the executed blocks
+	eventSink *eventsink.Sink
+
+	// an optional service streaming the committed blocks, and the block being
+	// executed and its writes to deliverState
+	streamingService streaming.Service
+	streamingBlock   streaming.Block
+	streamingWrites  *streamingWriteRecorder
+
 	// absent validators from begin block
 	voteInfos []abci.VoteInfo
 
@@ -164,6 +192,8 @@ func NewBaseApp(
 		txDecoder:      txDecoder,
 		fauxMerkleMode: false,
 		trace:          false,
+
+		transientLimiter: transient.NewLimiter(),
 	}
 	for _, option := range options {
 		option(app)
@@ -255,7 +285,14 @@ func (app *BaseApp) LoadLatestVersion(baseKey *sdk.KVStoreKey) error {
 	if err != nil {
 		return err
 	}
-	return app.initFromMainStore(baseKey)
+	if err := app.initFromMainStore(baseKey); err != nil {
+		return err
+	}
+
+	if cmgr, ok := app.interBlockCache.(*cache.CommitKVStoreCacheManager); ok {
+		app.logger.Info("warmed up the inter-block cache", "entries", cmgr.WarmUp())
+	}
+	return nil
 }
 
 // DefaultStoreLoader will be used by default and loads the latest version
@@ -271,41 +308,44 @@ func StoreLoaderWithUpgrade(upgrades *storetypes.StoreUpgrades) StoreLoader {
 	}
 }
 
-// UpgradeableStoreLoader can be configured by SetStoreLoader() to check for the
-// existence of a given upgrade file - json encoded StoreUpgrades data.
+// UpgradeableStoreLoader can be configured by SetStoreLoader() to apply the
+// store upgrades of the upgrade the node halted for. The upgrade is read from
+// the upgrade info file that x/upgrade writes to <home>/data/upgrade-info.json
+// right before halting, and which the upgrade supervisor reads to switch
+// binaries, so that both agree on the upgrade being applied.
 //
-// If not file is present, it will peform the default load (no upgrades to store).
+// If no file is present, or the upgrade it names has no entry in storeUpgrades,
+// it will perform the default load (no upgrades to store).
 //
-// If the file is present, it will parse the file and execute those upgrades
-// (rename or delete stores), while loading the data. It will also delete the
-// upgrade file upon successful load, so that the upgrade is only applied once,
-// and not re-applied on next restart
+// Otherwise, it will execute the store upgrades registered for the upgrade
+// (add, rename or delete stores) while loading the data. It will also delete the
+// upgrade info file upon successful load, so that the upgrade is only applied
+// once, and not re-applied on next restart.
 //
-// This is useful for in place migrations when a store key is renamed between
-// two versions of the software. (TODO: this code will move to x/upgrades
-// when PR #4233 is merged, here mainly to help test the design)
-func UpgradeableStoreLoader(upgradeInfoPath string) StoreLoader {
+// This is useful for in place migrations when a store key is added, renamed
+// or deleted between two versions of the software.
+func UpgradeableStoreLoader(upgradeInfoPath string, storeUpgrades map[string]*storetypes.StoreUpgrades) StoreLoader {
 	return func(ms sdk.CommitMultiStore) error {
-		_, err := os.Stat(upgradeInfoPath)
+		data, err := ioutil.ReadFile(upgradeInfoPath)
 		if os.IsNotExist(err) {
 			return DefaultStoreLoader(ms)
 		} else if err != nil {
-			return err
-		}
-
-		// there is a migration file, let's execute
-		data, err := ioutil.ReadFile(upgradeInfoPath)
-		if err != nil {
 			return fmt.Errorf("cannot read upgrade file %s: %v", upgradeInfoPath, err)
 		}
 
-		var upgrades storetypes.StoreUpgrades
-		err = json.Unmarshal(data, &upgrades)
+		var info upgradeInfo
+		err = json.Unmarshal(data, &info)
 		if err != nil {
 			return fmt.Errorf("cannot parse upgrade file: %v", err)
 		}
 
-		err = ms.LoadLatestVersionAndUpgrade(&upgrades)
+		upgrades, ok := storeUpgrades[info.Name]
+		if !ok {
+			return DefaultStoreLoader(ms)
+		}
+
+		// there are store upgrades for the upgrade, let's execute
+		err = ms.LoadLatestVersionAndUpgrade(upgrades)
 		if err != nil {
 			return fmt.Errorf("load and upgrade database: %v", err)
 		}
@@ -319,6 +359,12 @@ func UpgradeableStoreLoader(upgradeInfoPath string) StoreLoader {
 	}
 }
 
+// upgradeInfo is the part of the upgrade info file written by x/upgrade which
+// UpgradeableStoreLoader needs.
+type upgradeInfo struct {
+	Name string `json:"name"`
+}
+
 // LoadVersion loads the BaseApp application version. It will panic if called
 // more than once on a running baseapp.
 func (app *BaseApp) LoadVersion(version int64, baseKey *sdk.KVStoreKey) error {
@@ -391,6 +437,23 @@ func (app *BaseApp) setInterBlockCache(cache sdk.MultiStorePersistentCache) {
 	app.interBlockCache = cache
 }
 
+func (app *BaseApp) setStoreMetrics(collector *metrics.Collector) {
+	app.storeMetrics = collector
+}
+
+func (app *BaseApp) setTransientLimiter(limiter *transient.Limiter) {
+	app.transientLimiter = limiter
+}
+
+func (app *BaseApp) setEventSink(sink *eventsink.Sink) {
+	app.eventSink = sink
+}
+
+func (app *BaseApp) setStreamingService(service streaming.Service) {
+	app.streamingService = service
+	app.streamingWrites = &streamingWriteRecorder{skipped: make(map[sdk.StoreKey]bool)}
+}
+
 func (app *BaseApp) setTrace(trace bool) {
 	app.trace = trace
 }
@@ -424,6 +487,7 @@ func (app *BaseApp) setCheckState(header abci.Header) {
 		ms:  ms,
 		ctx: sdk.NewContext(ms, header, true, app.logger).WithMinGasPrices(app.minGasPrices),
 	}
+	app.checkState.ctx = app.withTransientGasConfig(app.checkState.ctx)
 }
 
 // setDeliverState sets the BaseApp's deliverState with a cache-wrapped multi-store
@@ -432,6 +496,27 @@ func (app *BaseApp) setCheckState(header abci.Header) {
 // Commit.
 func (app *BaseApp) setDeliverState(header abci.Header) {
 	ms := app.cms.CacheMultiStore()
+	if app.storeMetrics != nil {
+		if mms, ok := ms.(metrics.CacheMultiStore); ok {
+			ms = mms.SetMetrics(app.storeMetrics)
+		}
+	}
+	if tms, ok := ms.(transient.CacheMultiStore); ok {
+		ms = tms.SetLimiter(app.transientLimiter)
+	}
+	if app.storeWriteListener != nil {
+		if lms, ok := ms.(listenkv.CacheMultiStore); ok {
+			ms = lms.SetWriteListener(app.storeWriteListener)
+		}
+	}
+	if app.streamingService != nil {
+		lms, ok := ms.(listenkv.CacheMultiStore)
+		if !ok {
+			panic(fmt.Sprintf("cannot stream the writes to %T", ms))
+		}
+		app.streamingWrites.cms = app.cms
+		ms = lms.SetWriteListener(app.streamingWrites)
+	}
 	app.deliverState = &state{
 		ms:  ms,
 		ctx: sdk.NewContext(ms, header, false, app.logger),
@@ -658,6 +743,13 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx, height int6
 	var startingGas uint64
 	if mode == runTxModeDeliver {
 		startingGas = ctx.BlockGasMeter().GasConsumed()
+
+		if app.storeMetrics != nil {
+			app.storeMetrics.BeginTx()
+			defer app.storeMetrics.EndTx(tmhash.Sum(txBytes))
+		}
+		app.transientLimiter.BeginTx()
+		defer app.transientLimiter.EndTx()
 	}
 
 	defer func() {
@@ -673,6 +765,14 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx, height int6
 					),
 				)
 
+			case sdk.ErrorTransientStoreLimit:
+				err = sdkerrors.Wrap(
+					sdkerrors.ErrTransientStoreLimit, fmt.Sprintf(
+						"write to transient store %s exceeds the limit of %d bytes per block, %d already written",
+						rType.Store, rType.Limit, rType.Used,
+					),
+				)
+
 			default:
 				err = sdkerrors.Wrap(
 					sdkerrors.ErrPanic, fmt.Sprintf(
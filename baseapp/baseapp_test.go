@@ -16,11 +16,17 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/log"
 	dbm "github.com/tendermint/tm-db"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/cache"
+	"github.com/cosmos/cosmos-sdk/store/eventsink"
+	"github.com/cosmos/cosmos-sdk/store/metrics"
 	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	"github.com/cosmos/cosmos-sdk/store/streaming"
+	"github.com/cosmos/cosmos-sdk/store/transient"
 	store "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -146,9 +152,9 @@ func useUpgradeLoader(upgrades *store.StoreUpgrades) func(*BaseApp) {
 	}
 }
 
-func useFileUpgradeLoader(upgradeInfoPath string) func(*BaseApp) {
+func useFileUpgradeLoader(upgradeInfoPath string, upgrades map[string]*store.StoreUpgrades) func(*BaseApp) {
 	return func(app *BaseApp) {
-		app.SetStoreLoader(UpgradeableStoreLoader(upgradeInfoPath))
+		app.SetStoreLoader(UpgradeableStoreLoader(upgradeInfoPath, upgrades))
 	}
 }
 
@@ -187,10 +193,10 @@ func checkStore(t *testing.T, db dbm.DB, ver int64, storeKey string, k, v []byte
 // Test that we can make commits and then reload old versions.
 // Test that LoadLatestVersion actually does.
 func TestSetLoader(t *testing.T) {
-	// write a renamer to a file
+	// write the upgrade info of a halted node to a file
 	f, err := ioutil.TempFile("", "upgrade-*.json")
 	require.NoError(t, err)
-	data := []byte(`{"renamed":[{"old_key": "bnk", "new_key": "banker"}]}`)
+	data := []byte(`{"name":"v2","height":2}`)
 	_, err = f.Write(data)
 	require.NoError(t, err)
 	configName := f.Name()
@@ -200,6 +206,11 @@ func TestSetLoader(t *testing.T) {
 	_, err = os.Stat(configName)
 	require.NoError(t, err)
 
+	// the store upgrades registered by the new binary for the upgrade
+	renamer := map[string]*store.StoreUpgrades{
+		"v2": {Renamed: []store.StoreRename{{OldKey: "bnk", NewKey: "banker"}}},
+	}
+
 	cases := map[string]struct {
 		setLoader    func(*BaseApp)
 		origStoreKey string
@@ -225,12 +236,17 @@ func TestSetLoader(t *testing.T) {
 			loadStoreKey: "bar",
 		},
 		"file loader with missing file": {
-			setLoader:    useFileUpgradeLoader(configName + "randomchars"),
+			setLoader:    useFileUpgradeLoader(configName+"randomchars", renamer),
+			origStoreKey: "bnk",
+			loadStoreKey: "bnk",
+		},
+		"file loader for another upgrade": {
+			setLoader:    useFileUpgradeLoader(configName, map[string]*store.StoreUpgrades{"v3": renamer["v2"]}),
 			origStoreKey: "bnk",
 			loadStoreKey: "bnk",
 		},
 		"file loader with existing file": {
-			setLoader:    useFileUpgradeLoader(configName),
+			setLoader:    useFileUpgradeLoader(configName, renamer),
 			origStoreKey: "bnk",
 			loadStoreKey: "banker",
 		},
@@ -1486,6 +1502,290 @@ func TestQuery(t *testing.T) {
 	require.Equal(t, value, res.Value)
 }
 
+func TestStoreMetricsQuery(t *testing.T) {
+	key, value := []byte("hello"), []byte("goodbye")
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+			ctx.KVStore(capKey1).Set(key, value)
+			return &sdk.Result{}, nil
+		})
+	}
+	query := abci.RequestQuery{Path: "/app/store_metrics"}
+
+	// the query fails if store metrics are not enabled
+	app := setupBaseApp(t, routerOpt)
+	require.False(t, app.Query(query).IsOK())
+
+	collector := metrics.NewCollector(metrics.DefaultHotKeys, 1)
+	app = setupBaseApp(t, routerOpt, SetStoreMetrics(collector))
+	app.InitChain(abci.RequestInitChain{})
+
+	header := abci.Header{Height: app.LastBlockHeight() + 1}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	_, _, err := app.Deliver(newTxCounter(0, 0))
+	require.NoError(t, err)
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	res := app.Query(query)
+	require.True(t, res.IsOK(), res.Log)
+
+	var stats metrics.Stats
+	require.NoError(t, codec.Cdc.UnmarshalJSON(res.Value, &stats))
+	require.Equal(t, header.Height, stats.LastBlock.Height)
+	require.Len(t, stats.LastBlock.Txs, 1)
+
+	txStores := stats.LastBlock.Txs[0].Stores
+	require.Len(t, txStores, 1)
+	require.Equal(t, capKey1.Name(), txStores[0].Store)
+	require.Equal(t, uint64(1), txStores[0].Ops.Sets)
+	require.Equal(t, uint64(len(key)+len(value)), txStores[0].Ops.WriteBytes)
+}
+
+func TestInterBlockCache(t *testing.T) {
+	db := dbm.NewMemDB()
+	key, value := []byte("hello"), []byte("goodbye")
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+			ctx.KVStore(capKey1).Set(key, value)
+			return &sdk.Result{}, nil
+		})
+	}
+	newApp := func(config cache.Config) *BaseApp {
+		app := NewBaseApp(t.Name(), defaultLogger(), db, testTxDecoder(codec.New()), routerOpt,
+			SetInterBlockCache(cache.NewCommitKVStoreCacheManagerWithConfig(config)))
+		app.MountStores(capKey1, capKey2)
+		require.NoError(t, app.LoadLatestVersion(capKey1))
+		return app
+	}
+
+	app := newApp(cache.Config{DefaultBytes: 1000, StoreBytes: map[string]uint64{capKey2.Name(): 0}})
+	app.InitChain(abci.RequestInitChain{})
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: app.LastBlockHeight() + 1}})
+	_, _, err := app.Deliver(newTxCounter(0, 0))
+	require.NoError(t, err)
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	query := abci.RequestQuery{Path: "/app/inter_block_cache"}
+	var stats []cache.Stats
+
+	// the cache of capKey2 is disabled
+	res := app.Query(query)
+	require.True(t, res.IsOK(), res.Log)
+	require.NoError(t, codec.Cdc.UnmarshalJSON(res.Value, &stats))
+	require.Len(t, stats, 1)
+	require.Equal(t, capKey1.Name(), stats[0].Store)
+
+	// a restarted app preloads the warm-up prefixes into the cache, in addition
+	// to the consensus params read when loading
+	app = newApp(cache.Config{DefaultBytes: 1000, WarmUpPrefixes: map[string][][]byte{capKey1.Name(): {[]byte("hel")}}})
+	res = app.Query(query)
+	require.True(t, res.IsOK(), res.Log)
+	require.NoError(t, codec.Cdc.UnmarshalJSON(res.Value, &stats))
+	require.Len(t, stats, 2)
+	require.Equal(t, capKey1.Name(), stats[0].Store)
+	require.Equal(t, uint64(1000), stats[0].Capacity)
+	require.Equal(t, uint64(2), stats[0].Entries)
+	require.Equal(t, uint64(1), stats[0].Misses)
+}
+
+// paramStore is a ParamStore keeping the params of BaseApp in the store of
+// capKey1.
+type paramStore struct{}
+
+func (paramStore) Get(ctx sdk.Context, key []byte, ptr interface{}) {
+	codec.Cdc.MustUnmarshalJSON(ctx.KVStore(capKey1).Get(key), ptr)
+}
+
+func (paramStore) Has(ctx sdk.Context, key []byte) bool {
+	return ctx.KVStore(capKey1).Has(key)
+}
+
+func (paramStore) Set(ctx sdk.Context, key []byte, param interface{}) {
+	ctx.KVStore(capKey1).Set(key, codec.Cdc.MustMarshalJSON(param))
+}
+
+func TestTransientStoreLimit(t *testing.T) {
+	tKey := sdk.NewTransientStoreKey("transient_key1")
+	key, value := []byte("k"), make([]byte, 10)
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+			ctx.TransientStore(tKey).Set(key, value)
+			return &sdk.Result{}, nil
+		})
+	}
+
+	limiter := transient.NewLimiter()
+	limiter.SetModule(tKey.Name(), "counter")
+	app := newBaseApp(t.Name(), routerOpt, SetTransientLimiter(limiter))
+	app.SetParamStore(paramStore{})
+	app.MountStores(capKey1, tKey)
+	require.NoError(t, app.LoadLatestVersion(capKey1))
+	app.InitChain(abci.RequestInitChain{})
+	paramStore{}.Set(app.deliverState.ctx, ParamStoreKeyTransientStoreLimit, uint64(25))
+
+	// each tx writes 11 bytes, so the third one exceeds the limit
+	header := abci.Header{Height: app.LastBlockHeight() + 1}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	require.Equal(t, store.KVGasConfig(), app.deliverState.ctx.TransientGasConfig())
+	for i := 0; i < 2; i++ {
+		_, _, err := app.Deliver(newTxCounter(int64(i), 0))
+		require.NoError(t, err)
+	}
+	_, _, err := app.Deliver(newTxCounter(2, 0))
+	require.True(t, sdkerrors.ErrTransientStoreLimit.Is(err), err)
+	app.EndBlock(abci.RequestEndBlock{})
+	paramStore{}.Set(app.deliverState.ctx, ParamStoreKeyTransientStoreGas, true)
+	app.Commit()
+
+	// the transient gas costs apply from the state following the param change
+	require.Equal(t, store.TransientGasConfig(), app.checkState.ctx.TransientGasConfig())
+
+	res := app.Query(abci.RequestQuery{Path: "/app/transient_usage"})
+	require.True(t, res.IsOK(), res.Log)
+
+	var stats transient.Stats
+	require.NoError(t, codec.Cdc.UnmarshalJSON(res.Value, &stats))
+	require.Equal(t, uint64(25), stats.Limit)
+	require.Equal(t, uint64(1), stats.Rejected)
+	require.Equal(t, []transient.ModuleUsage{{Module: "counter", Writes: 2, Bytes: 22}}, stats.Modules)
+	require.Equal(t, transient.BlockUsage{
+		Height: header.Height,
+		Bytes:  22,
+		Stores: []transient.Usage{{Store: tKey.Name(), Module: "counter", Writes: 2, Bytes: 22}},
+	}, stats.LastBlock)
+
+	// the limit applies per block
+	header = abci.Header{Height: app.LastBlockHeight() + 1}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	require.Equal(t, store.TransientGasConfig(), app.deliverState.ctx.TransientGasConfig())
+	_, _, err = app.Deliver(newTxCounter(3, 0))
+	require.NoError(t, err)
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+	require.Equal(t, uint64(11), limiter.Stats().LastBlock.Bytes)
+	require.Equal(t, uint64(22), limiter.Stats().PeakBlock.Bytes)
+}
+
+func TestEventSinkQuery(t *testing.T) {
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+			event := sdk.NewEvent("counter", sdk.NewAttribute(sdk.AttributeKeyModule, "counter"))
+			return &sdk.Result{Events: sdk.Events{event}}, nil
+		})
+	}
+	query := eventsink.Query{Type: "counter", Attributes: []eventsink.Attribute{{Key: "module", Value: "counter"}}}
+	req := abci.RequestQuery{Path: "/app/events", Data: codec.Cdc.MustMarshalJSON(query)}
+
+	// the query fails if the event sink is not enabled
+	app := setupBaseApp(t, routerOpt)
+	require.False(t, app.Query(req).IsOK())
+
+	sink, err := eventsink.NewSink(dbm.NewMemDB())
+	require.NoError(t, err)
+	app = setupBaseApp(t, routerOpt, SetEventSink(sink))
+	app.InitChain(abci.RequestInitChain{})
+
+	header := abci.Header{Height: app.LastBlockHeight() + 1}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	cdc := codec.New()
+	registerTestCodec(cdc)
+	txBytes, err := cdc.MarshalBinaryLengthPrefixed(newTxCounter(0, 0))
+	require.NoError(t, err)
+	require.True(t, app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes}).IsOK())
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	res := app.Query(req)
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, header.Height, res.Height)
+
+	var result eventsink.SearchResult
+	require.NoError(t, codec.Cdc.UnmarshalJSON(res.Value, &result))
+	require.Len(t, result.Events, 1)
+	require.Equal(t, header.Height, result.Events[0].Height)
+	require.Equal(t, eventsink.SourceTx, result.Events[0].Source)
+	require.Equal(t, fmt.Sprintf("%X", tmhash.Sum(txBytes)), result.Events[0].TxHash)
+	require.Equal(t, "counter", result.Events[0].Module)
+}
+
+// streamingService records the streamed blocks, and the height committed by
+// the app when each was streamed.
+type streamingService struct {
+	app              *BaseApp
+	blocks           []streaming.Block
+	committedHeights []int64
+}
+
+func (s *streamingService) ListenCommit(block streaming.Block) error {
+	s.blocks = append(s.blocks, block)
+	s.committedHeights = append(s.committedHeights, s.app.LastBlockHeight())
+	return nil
+}
+
+func TestStreamingService(t *testing.T) {
+	tKey := sdk.NewTransientStoreKey("transient_key1")
+	dbKey := sdk.NewKVStoreKey("db_key1")
+	key, value := []byte("hello"), []byte("goodbye")
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+			ctx.KVStore(capKey1).Set(key, value)
+			ctx.KVStore(capKey1).Delete([]byte("missing"))
+			// the writes to the stores out of the app hash aren't streamed
+			ctx.TransientStore(tKey).Set(key, value)
+			ctx.KVStore(dbKey).Set(key, value)
+			if msg.(*msgCounter).Counter == 1 {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "failed")
+			}
+			return &sdk.Result{}, nil
+		})
+	}
+
+	service := &streamingService{}
+	app := newBaseApp(t.Name(), routerOpt, SetStreamingService(service))
+	app.MountStores(capKey1, tKey)
+	app.MountStore(dbKey, sdk.StoreTypeDB)
+	require.NoError(t, app.LoadLatestVersion(capKey1))
+	service.app = app
+	app.InitChain(abci.RequestInitChain{})
+
+	cdc := codec.New()
+	registerTestCodec(cdc)
+	for height := int64(1); height <= 2; height++ {
+		header := abci.Header{Height: height}
+		app.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+		txBytes, err := cdc.MarshalBinaryLengthPrefixed(newTxCounter(0, 0))
+		require.NoError(t, err)
+		require.True(t, app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes}).IsOK())
+
+		// a failed tx is streamed, but not its writes
+		failedTx, err := cdc.MarshalBinaryLengthPrefixed(newTxCounter(0, 1))
+		require.NoError(t, err)
+		require.False(t, app.DeliverTx(abci.RequestDeliverTx{Tx: failedTx}).IsOK())
+
+		app.EndBlock(abci.RequestEndBlock{Height: height})
+		require.Len(t, service.blocks, int(height-1), "a block is streamed before it's committed")
+		app.Commit()
+	}
+
+	require.Equal(t, []int64{1, 2}, service.committedHeights)
+	for i, block := range service.blocks {
+		height := int64(i + 1)
+		require.Equal(t, height, block.RequestBeginBlock.Header.Height)
+		require.Equal(t, height, block.RequestEndBlock.Height)
+		require.Len(t, block.DeliverTxs, 2)
+		require.True(t, block.DeliverTxs[0].Response.IsOK())
+		require.False(t, block.DeliverTxs[1].Response.IsOK())
+		require.NotEmpty(t, block.ResponseCommit.Data)
+		require.Equal(t, []sdk.StoreKVPair{
+			{StoreKey: capKey1.Name(), Key: key, Value: value},
+			{StoreKey: capKey1.Name(), Key: []byte("missing"), Delete: true},
+		}, block.Writes)
+	}
+}
+
 // Test p2p filter queries
 func TestP2PQuery(t *testing.T) {
 	addrPeerFilterOpt := func(bapp *BaseApp) {
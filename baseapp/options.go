@@ -7,6 +7,10 @@ import (
 	dbm "github.com/tendermint/tm-db"
 
 	"github.com/cosmos/cosmos-sdk/store"
+	"github.com/cosmos/cosmos-sdk/store/eventsink"
+	"github.com/cosmos/cosmos-sdk/store/metrics"
+	"github.com/cosmos/cosmos-sdk/store/streaming"
+	"github.com/cosmos/cosmos-sdk/store/transient"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -44,6 +48,31 @@ func SetInterBlockCache(cache sdk.MultiStorePersistentCache) func(*BaseApp) {
 	return func(app *BaseApp) { app.setInterBlockCache(cache) }
 }
 
+// SetStoreMetrics provides a BaseApp option function that sets the collector
+// of the metrics of the stores accessed by the blocks and their txs.
+func SetStoreMetrics(collector *metrics.Collector) func(*BaseApp) {
+	return func(app *BaseApp) { app.setStoreMetrics(collector) }
+}
+
+// SetTransientLimiter provides a BaseApp option function that replaces the
+// default limiter of the bytes written to the transient stores by the blocks
+// and their txs, e.g. with one setting the modules owning the stores.
+func SetTransientLimiter(limiter *transient.Limiter) func(*BaseApp) {
+	return func(app *BaseApp) { app.setTransientLimiter(limiter) }
+}
+
+// SetEventSink provides a BaseApp option function that sets the sink indexing
+// the events of BeginBlock, DeliverTx and EndBlock.
+func SetEventSink(sink *eventsink.Sink) func(*BaseApp) {
+	return func(app *BaseApp) { app.setEventSink(sink) }
+}
+
+// SetStreamingService provides a BaseApp option function that sets the service
+// streaming the committed blocks.
+func SetStreamingService(service streaming.Service) func(*BaseApp) {
+	return func(app *BaseApp) { app.setStreamingService(service) }
+}
+
 // SetTrace will turn on or off trace flag
 func SetTrace(trace bool) func(*BaseApp) {
 	return func(app *BaseApp) { app.setTrace(trace) }
@@ -99,6 +128,16 @@ func (app *BaseApp) SetEndBlocker(endBlocker sdk.EndBlocker) {
 	app.endBlocker = endBlocker
 }
 
+// SetParamStore sets the store of the params of BaseApp, such as a subspace of
+// the params module named Paramspace. The params default to their zero values
+// without a param store.
+func (app *BaseApp) SetParamStore(ps ParamStore) {
+	if app.sealed {
+		panic("SetParamStore() on sealed BaseApp")
+	}
+	app.paramStore = ps
+}
+
 func (app *BaseApp) SetAnteHandler(ah sdk.AnteHandler) {
 	if app.sealed {
 		panic("SetAnteHandler() on sealed BaseApp")
@@ -140,6 +179,13 @@ func (app *BaseApp) SetCommitMultiStoreTracer(w io.Writer) {
 	app.cms.SetTracer(w)
 }
 
+// SetStoreWriteListener sets a listener of the writes to the stores of the
+// DeliverTx state, from the next block on. The writes of a tx are notified as
+// they are written to the DeliverTx state, so the writes of failed msgs aren't.
+func (app *BaseApp) SetStoreWriteListener(listener sdk.WriteListener) {
+	app.storeWriteListener = listener
+}
+
 // SetStoreLoader allows us to customize the rootMultiStore initialization.
 func (app *BaseApp) SetStoreLoader(loader StoreLoader) {
 	if app.sealed {
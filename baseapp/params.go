@@ -0,0 +1,79 @@
+package baseapp
+
+import (
+	"fmt"
+
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Paramspace defines the parameter subspace to be used for the paramstore.
+const Paramspace = "baseapp"
+
+// Parameter store keys for all the parameters of BaseApp. They are part of the
+// state, so that all the nodes apply the same values, and they can be changed
+// by governance.
+var (
+	// ParamStoreKeyTransientStoreLimit is the key of the number of bytes the
+	// txs of a block may write to the transient stores, 0 meaning no limit.
+	ParamStoreKeyTransientStoreLimit = []byte("TransientStoreLimit")
+
+	// ParamStoreKeyTransientStoreGas is the key of whether the transient stores
+	// charge the gas costs of TransientGasConfig, rather than the ones of the
+	// KVStores charged until it is enabled.
+	ParamStoreKeyTransientStoreGas = []byte("TransientStoreGas")
+)
+
+// ParamStore defines the interface the parameter store used by the BaseApp must
+// fulfill.
+type ParamStore interface {
+	Get(ctx sdk.Context, key []byte, ptr interface{})
+	Has(ctx sdk.Context, key []byte) bool
+	Set(ctx sdk.Context, key []byte, param interface{})
+}
+
+// ValidateTransientStoreLimit defines a stateless validation on the transient
+// store limit param.
+func ValidateTransientStoreLimit(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+// ValidateTransientStoreGas defines a stateless validation on the transient
+// store gas param.
+func ValidateTransientStoreGas(i interface{}) error {
+	if _, ok := i.(bool); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+// getTransientStoreLimit returns the transient store limit param in the state
+// of ctx, or 0 if it isn't set.
+func (app *BaseApp) getTransientStoreLimit(ctx sdk.Context) uint64 {
+	var limit uint64
+	if app.paramStore != nil && app.paramStore.Has(ctx, ParamStoreKeyTransientStoreLimit) {
+		app.paramStore.Get(ctx, ParamStoreKeyTransientStoreLimit, &limit)
+	}
+
+	return limit
+}
+
+// withTransientGasConfig returns ctx charging the gas costs of
+// TransientGasConfig for the transient stores if the transient store gas param
+// is enabled in its state.
+func (app *BaseApp) withTransientGasConfig(ctx sdk.Context) sdk.Context {
+	var enabled bool
+	if app.paramStore != nil && app.paramStore.Has(ctx, ParamStoreKeyTransientStoreGas) {
+		app.paramStore.Get(ctx, ParamStoreKeyTransientStoreGas, &enabled)
+	}
+	if !enabled {
+		return ctx
+	}
+
+	return ctx.WithTransientGasConfig(storetypes.TransientGasConfig())
+}
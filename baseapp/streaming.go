@@ -0,0 +1,61 @@
+package baseapp
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/store/streaming"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// streamingWriteRecorder records the writes to the DeliverTx state of the
+// block being executed. The writes to the transient and DB stores are not
+// recorded, as they aren't part of the committed state.
+type streamingWriteRecorder struct {
+	cms     sdk.CommitMultiStore
+	skipped map[sdk.StoreKey]bool // whether the writes to a store are skipped, by store key
+	writes  []sdk.StoreKVPair
+}
+
+var _ sdk.WriteListener = (*streamingWriteRecorder)(nil)
+
+// OnWrite implements the WriteListener interface.
+func (r *streamingWriteRecorder) OnWrite(storeKey sdk.StoreKey, key, value []byte, delete bool) {
+	if r.isSkipped(storeKey) {
+		return
+	}
+
+	pair := sdk.StoreKVPair{StoreKey: storeKey.Name(), Delete: delete, Key: append([]byte{}, key...)}
+	if !delete {
+		pair.Value = append([]byte{}, value...)
+	}
+	r.writes = append(r.writes, pair)
+}
+
+func (r *streamingWriteRecorder) isSkipped(storeKey sdk.StoreKey) bool {
+	skipped, ok := r.skipped[storeKey]
+	if !ok {
+		switch r.cms.GetCommitKVStore(storeKey).GetStoreType() {
+		case sdk.StoreTypeTransient, sdk.StoreTypeDB:
+			skipped = true
+		}
+		r.skipped[storeKey] = skipped
+	}
+
+	return skipped
+}
+
+// streamBlock passes the block just committed to the streaming service, and
+// starts recording the next one. An error of the service is logged, see
+// streaming.Service.
+func (app *BaseApp) streamBlock(res abci.ResponseCommit) {
+	block := app.streamingBlock
+	block.ResponseCommit = res
+	block.Writes = app.streamingWrites.writes
+
+	app.streamingBlock = streaming.Block{}
+	app.streamingWrites.writes = nil
+
+	if err := app.streamingService.ListenCommit(block); err != nil {
+		app.logger.Error("failed to stream block", "height", block.RequestBeginBlock.Header.Height, "err", err)
+	}
+}
@@ -1,11 +1,18 @@
 package context
 
 import (
+	gocontext "context"
 	"fmt"
 	"strings"
+	"time"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/mempool"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -27,8 +34,11 @@ func (ctx CLIContext) BroadcastTx(txBytes []byte) (res sdk.TxResponse, err error
 	case flags.BroadcastBlock:
 		res, err = ctx.BroadcastTxCommit(txBytes)
 
+	case flags.BroadcastWait:
+		res, err = ctx.BroadcastTxWait(txBytes)
+
 	default:
-		return sdk.TxResponse{}, fmt.Errorf("unsupported return type %s; supported types: sync, async, block", ctx.BroadcastMode)
+		return sdk.TxResponse{}, fmt.Errorf("unsupported return type %s; supported types: sync, async, block, wait", ctx.BroadcastMode)
 	}
 
 	return res, err
@@ -53,20 +63,31 @@ func CheckTendermintError(err error, txBytes []byte) *sdk.TxResponse {
 	switch {
 	case strings.Contains(errStr, strings.ToLower(mempool.ErrTxInCache.Error())):
 		return &sdk.TxResponse{
-			Code:   sdkerrors.ErrTxInMempoolCache.ABCICode(),
-			TxHash: txHash,
+			Codespace: sdkerrors.RootCodespace,
+			Code:      sdkerrors.ErrTxInMempoolCache.ABCICode(),
+			TxHash:    txHash,
 		}
 
 	case strings.Contains(errStr, "mempool is full"):
 		return &sdk.TxResponse{
-			Code:   sdkerrors.ErrMempoolIsFull.ABCICode(),
-			TxHash: txHash,
+			Codespace: sdkerrors.RootCodespace,
+			Code:      sdkerrors.ErrMempoolIsFull.ABCICode(),
+			TxHash:    txHash,
 		}
 
 	case strings.Contains(errStr, "tx too large"):
 		return &sdk.TxResponse{
-			Code:   sdkerrors.ErrTxTooLarge.ABCICode(),
-			TxHash: txHash,
+			Codespace: sdkerrors.RootCodespace,
+			Code:      sdkerrors.ErrTxTooLarge.ABCICode(),
+			TxHash:    txHash,
+		}
+
+	case strings.Contains(errStr, "incorrect account sequence"), strings.Contains(errStr, "invalid sequence"):
+		return &sdk.TxResponse{
+			Codespace: sdkerrors.RootCodespace,
+			Code:      sdkerrors.ErrInvalidSequence.ABCICode(),
+			RawLog:    err.Error(),
+			TxHash:    txHash,
 		}
 
 	default:
@@ -74,6 +95,26 @@ func CheckTendermintError(err error, txBytes []byte) *sdk.TxResponse {
 	}
 }
 
+// IsSequenceError returns true if a tx was rejected because of its sequence,
+// either by the node (see CheckTendermintError) or with ErrInvalidSequence.
+//
+// NOTE: A tx signed with a wrong sequence may also fail the signature
+// verification with ErrUnauthorized, which can't be told apart from an invalid
+// signature and is therefore not a sequence error. Callers caching sequences
+// should still resync them on such errors, see utils.SequenceManager.
+func IsSequenceError(res sdk.TxResponse, err error) bool {
+	if err != nil {
+		if ErrTxReplaced.Is(err) || sdkerrors.ErrInvalidSequence.Is(err) {
+			return true
+		}
+
+		errRes := CheckTendermintError(err, nil)
+		return errRes != nil && IsSequenceError(*errRes, nil)
+	}
+
+	return res.Codespace == sdkerrors.RootCodespace && res.Code == sdkerrors.ErrInvalidSequence.ABCICode()
+}
+
 // BroadcastTxCommit broadcasts transaction bytes to a Tendermint node and
 // waits for a commit. An error is only returned if there is no RPC node
 // connection or if broadcasting fails.
@@ -138,3 +179,166 @@ func (ctx CLIContext) BroadcastTxAsync(txBytes []byte) (sdk.TxResponse, error) {
 
 	return sdk.NewResponseFormatBroadcastTx(res), err
 }
+
+// waitPollInterval is how often the wait broadcast mode queries the node for
+// the tx, in case no inclusion event is received.
+const waitPollInterval = time.Second
+
+// BroadcastTxWait broadcasts transaction bytes to a Tendermint node
+// synchronously and then waits until the tx is included in a block, listening
+// for its event over the websocket and polling the node for it. Unlike
+// BroadcastTxCommit, the response holds the events, gas used and block time of
+// the tx, and the wait is bounded by the broadcast timeout of the context.
+//
+// A tx rejected by CheckTx is returned like in sync mode, except that the
+// ErrMempoolIsFull and ErrTxReplaced errors are returned when the mempool is
+// full or the sequence of the tx has already been used, so that callers can
+// retry. ErrTxTimeout is returned if the tx is not included before the timeout;
+// it may still be included later.
+func (ctx CLIContext) BroadcastTxWait(txBytes []byte) (sdk.TxResponse, error) {
+	node, err := ctx.GetNode()
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	timeout := ctx.BroadcastTimeout
+	if timeout <= 0 {
+		timeout = flags.DefaultBroadcastTimeout
+	}
+
+	hash := tmhash.Sum(txBytes)
+	txHash := fmt.Sprintf("%X", hash)
+
+	// subscribe before broadcasting so that the inclusion event can't be missed
+	events, unsubscribe := ctx.subscribeTx(node, txHash)
+	defer unsubscribe()
+
+	res, err := node.BroadcastTxSync(txBytes)
+	if err != nil {
+		errRes := CheckTendermintError(err, txBytes)
+		if errRes == nil {
+			return sdk.TxResponse{}, err
+		}
+
+		// a tx already in the mempool cache has been broadcasted before, so it
+		// is waited for as well
+		if errRes.Code != sdkerrors.ErrTxInMempoolCache.ABCICode() {
+			return *errRes, broadcastWaitError(*errRes)
+		}
+	} else if res.Code != abci.CodeTypeOK {
+		txRes := sdk.NewResponseFormatBroadcastTx(res)
+		return txRes, broadcastWaitError(txRes)
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+
+	for {
+		var resTx *ctypes.ResultTx
+
+		select {
+		case event, ok := <-events:
+			if !ok {
+				// the websocket connection is gone, keep polling
+				events = nil
+				continue
+			}
+			if data, ok := event.Data.(tmtypes.EventDataTx); ok {
+				resTx = &ctypes.ResultTx{
+					Hash:     hash,
+					Height:   data.Height,
+					Index:    data.Index,
+					TxResult: data.Result,
+					Tx:       data.Tx,
+				}
+			}
+
+		case <-ticker.C:
+			resTx, err = node.Tx(hash, false)
+			if err != nil && events == nil && strings.Contains(err.Error(), "indexing is disabled") {
+				return sdk.TxResponse{TxHash: txHash}, err
+			}
+
+		case <-timer.C:
+			return sdk.TxResponse{TxHash: txHash}, sdkerrors.Wrapf(ErrTxTimeout, "tx %s after %s", txHash, timeout)
+		}
+
+		if resTx != nil {
+			return ctx.newWaitTxResponse(node, resTx)
+		}
+	}
+}
+
+// broadcastWaitError returns the typed error of a tx rejected before entering
+// the mempool, if any.
+func broadcastWaitError(res sdk.TxResponse) error {
+	switch {
+	case res.Code == sdkerrors.ErrMempoolIsFull.ABCICode():
+		return sdkerrors.Wrapf(sdkerrors.ErrMempoolIsFull, "tx %s", res.TxHash)
+
+	case IsSequenceError(res, nil):
+		return sdkerrors.Wrapf(ErrTxReplaced, "tx %s: %s", res.TxHash, res.RawLog)
+
+	default:
+		return nil
+	}
+}
+
+// subscribeTx subscribes to the inclusion event of a tx. A node client that is
+// already running is subscribed to as is. Otherwise a dedicated websocket
+// client is started for the subscription and stopped once done, so that
+// concurrent waits don't start and stop the client of the context. It returns a
+// nil channel if no subscription can be made, e.g. because the websocket
+// endpoint of the node can't be reached.
+func (ctx CLIContext) subscribeTx(node rpcclient.Client, txHash string) (<-chan ctypes.ResultEvent, func()) {
+	subscriber := "broadcast-wait-" + txHash
+	query := fmt.Sprintf("%s='%s' AND %s='%s'", tmtypes.EventTypeKey, tmtypes.EventTx, tmtypes.TxHashKey, txHash)
+
+	stop := func() {}
+	if !node.IsRunning() {
+		if ctx.NodeURI == "" {
+			return nil, stop
+		}
+
+		wsClient, err := rpchttp.New(ctx.NodeURI, "/websocket")
+		if err != nil {
+			return nil, stop
+		}
+		if err := wsClient.Start(); err != nil {
+			return nil, stop
+		}
+
+		node = wsClient
+		stop = func() { _ = wsClient.Stop() }
+	}
+
+	events, err := node.Subscribe(gocontext.Background(), subscriber, query)
+	if err != nil {
+		stop()
+		return nil, func() {}
+	}
+
+	return events, func() {
+		_ = node.Unsubscribe(gocontext.Background(), subscriber, query)
+		stop()
+	}
+}
+
+// newWaitTxResponse returns the response of an included tx, with its block
+// time and, if the context has a codec, the decoded tx.
+func (ctx CLIContext) newWaitTxResponse(node rpcclient.Client, resTx *ctypes.ResultTx) (sdk.TxResponse, error) {
+	block, err := node.Block(&resTx.Height)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	// the tx is left out of the response if it can't be decoded
+	var tx sdk.Tx
+	if ctx.Codec != nil && ctx.Codec.UnmarshalBinaryLengthPrefixed(resTx.Tx, &tx) != nil {
+		tx = nil
+	}
+
+	return sdk.NewResponseResultTx(resTx, tx, block.Block.Time.Format(time.RFC3339)), nil
+}
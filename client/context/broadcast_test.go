@@ -1,17 +1,23 @@
 package context
 
 import (
+	gocontext "context"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/libs/kv"
 	"github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/rpc/client/mock"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
@@ -67,3 +73,136 @@ func TestBroadcastError(t *testing.T) {
 	}
 
 }
+
+type waitClient struct {
+	mock.Client
+	checkTx  *ctypes.ResultBroadcastTx
+	err      error
+	events   chan ctypes.ResultEvent
+	resTx    *ctypes.ResultTx
+	blockNum int64
+}
+
+func (c *waitClient) IsRunning() bool { return true }
+
+func (c *waitClient) Subscribe(_ gocontext.Context, _, _ string, _ ...int) (<-chan ctypes.ResultEvent, error) {
+	if c.events == nil {
+		return nil, errors.New("subscriptions are not supported")
+	}
+	return c.events, nil
+}
+
+func (c *waitClient) Unsubscribe(gocontext.Context, string, string) error { return nil }
+
+func (c *waitClient) BroadcastTxSync(tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	return c.checkTx, c.err
+}
+
+func (c *waitClient) Tx(hash []byte, prove bool) (*ctypes.ResultTx, error) {
+	if c.resTx == nil {
+		return nil, fmt.Errorf("tx (%X) not found", hash)
+	}
+	return c.resTx, nil
+}
+
+func (c *waitClient) Block(height *int64) (*ctypes.ResultBlock, error) {
+	c.blockNum = *height
+	return &ctypes.ResultBlock{Block: &tmtypes.Block{
+		Header: tmtypes.Header{Height: *height, Time: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)},
+	}}, nil
+}
+
+func TestBroadcastTxWait(t *testing.T) {
+	txBytes := []byte{0xA, 0xB}
+	txHash := fmt.Sprintf("%X", tmhash.Sum(txBytes))
+	result := abci.ResponseDeliverTx{
+		GasWanted: 200000,
+		GasUsed:   51234,
+		Events: []abci.Event{{
+			Type:       "transfer",
+			Attributes: []kv.Pair{{Key: []byte("amount"), Value: []byte("10okt")}},
+		}},
+	}
+
+	// the tx is included as soon as its event is received
+	client := &waitClient{checkTx: &ctypes.ResultBroadcastTx{}, events: make(chan ctypes.ResultEvent, 1)}
+	client.events <- ctypes.ResultEvent{Data: tmtypes.EventDataTx{TxResult: tmtypes.TxResult{Height: 5, Tx: txBytes, Result: result}}}
+	ctx := CLIContext{Client: client, BroadcastMode: flags.BroadcastWait}
+
+	res, err := ctx.BroadcastTx(txBytes)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), client.blockNum)
+	require.Equal(t, int64(5), res.Height)
+	require.Equal(t, txHash, res.TxHash)
+	require.Equal(t, int64(51234), res.GasUsed)
+	require.Equal(t, "2020-01-02T03:04:05Z", res.Timestamp)
+	require.Equal(t, sdk.StringEvents{{
+		Type:       "transfer",
+		Attributes: []sdk.Attribute{{Key: "amount", Value: "10okt"}},
+	}}, res.Events)
+
+	// without subscriptions, the node is polled for the tx
+	client = &waitClient{
+		checkTx: &ctypes.ResultBroadcastTx{},
+		err:     mempool.ErrTxInCache,
+		resTx:   &ctypes.ResultTx{Hash: tmhash.Sum(txBytes), Height: 7, Tx: txBytes, TxResult: result},
+	}
+	res, err = ctx.WithClient(client).BroadcastTx(txBytes)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), res.Height)
+	require.Equal(t, txHash, res.TxHash)
+
+	client.resTx = nil
+	res, err = ctx.WithClient(client).WithBroadcastTimeout(10 * time.Millisecond).BroadcastTx(txBytes)
+	require.True(t, ErrTxTimeout.Is(err))
+	require.Equal(t, txHash, res.TxHash)
+
+	// txs rejected by CheckTx return typed errors when they may be retried
+	client = &waitClient{err: mempool.ErrMempoolIsFull{}}
+	_, err = ctx.WithClient(client).BroadcastTx(txBytes)
+	require.True(t, sdkerrors.ErrMempoolIsFull.Is(err))
+
+	client = &waitClient{checkTx: &ctypes.ResultBroadcastTx{
+		Codespace: sdkerrors.RootCodespace,
+		Code:      sdkerrors.ErrInvalidSequence.ABCICode(),
+	}}
+	res, err = ctx.WithClient(client).BroadcastTx(txBytes)
+	require.True(t, ErrTxReplaced.Is(err))
+	require.True(t, IsSequenceError(res, err))
+
+	client = &waitClient{err: errors.New("Incorrect account sequence")}
+	_, err = ctx.WithClient(client).BroadcastTx(txBytes)
+	require.True(t, ErrTxReplaced.Is(err))
+
+	// failed signature verifications are not sequence errors
+	client = &waitClient{checkTx: &ctypes.ResultBroadcastTx{
+		Codespace: sdkerrors.RootCodespace,
+		Code:      sdkerrors.ErrUnauthorized.ABCICode(),
+		Log:       "signature verification failed; verify correct account sequence and chain-id",
+	}}
+	res, err = ctx.WithClient(client).BroadcastTx(txBytes)
+	require.NoError(t, err)
+	require.Equal(t, sdkerrors.ErrUnauthorized.ABCICode(), res.Code)
+
+	client = &waitClient{checkTx: &ctypes.ResultBroadcastTx{Code: sdkerrors.ErrInsufficientFee.ABCICode()}}
+	res, err = ctx.WithClient(client).BroadcastTx(txBytes)
+	require.NoError(t, err)
+	require.Equal(t, sdkerrors.ErrInsufficientFee.ABCICode(), res.Code)
+}
+
+func TestIsSequenceError(t *testing.T) {
+	invalidSequence := sdk.TxResponse{Codespace: sdkerrors.RootCodespace, Code: sdkerrors.ErrInvalidSequence.ABCICode()}
+	require.True(t, IsSequenceError(invalidSequence, nil))
+	require.True(t, IsSequenceError(sdk.TxResponse{}, errors.New("incorrect account sequence")))
+	require.True(t, IsSequenceError(sdk.TxResponse{}, sdkerrors.Wrap(sdkerrors.ErrInvalidSequence, "expected 3")))
+	require.True(t, IsSequenceError(sdk.TxResponse{}, sdkerrors.Wrap(ErrTxReplaced, "tx")))
+
+	// the code of ErrInvalidSequence in another codespace
+	require.False(t, IsSequenceError(sdk.TxResponse{Codespace: "bank", Code: sdkerrors.ErrInvalidSequence.ABCICode()}, nil))
+	require.False(t, IsSequenceError(sdk.TxResponse{
+		Codespace: sdkerrors.RootCodespace,
+		Code:      sdkerrors.ErrUnauthorized.ABCICode(),
+		RawLog:    "signature verification failed; verify correct account sequence and chain-id",
+	}, nil))
+	require.False(t, IsSequenceError(sdk.TxResponse{}, errors.New("connection refused")))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -22,27 +23,28 @@ import (
 // CLIContext implements a typical CLI context created in SDK modules for
 // transaction handling and queries.
 type CLIContext struct {
-	FromAddress   sdk.AccAddress
-	Client        rpcclient.Client
-	ChainID       string
-	Keybase       keys.Keybase
-	Input         io.Reader
-	Output        io.Writer
-	OutputFormat  string
-	Height        int64
-	HomeDir       string
-	NodeURI       string
-	From          string
-	BroadcastMode string
-	Verifier      tmlite.Verifier
-	FromName      string
-	Codec         *codec.Codec
-	TrustNode     bool
-	UseLedger     bool
-	Simulate      bool
-	GenerateOnly  bool
-	Indent        bool
-	SkipConfirm   bool
+	FromAddress      sdk.AccAddress
+	Client           rpcclient.Client
+	ChainID          string
+	Keybase          keys.Keybase
+	Input            io.Reader
+	Output           io.Writer
+	OutputFormat     string
+	Height           int64
+	HomeDir          string
+	NodeURI          string
+	From             string
+	BroadcastMode    string
+	BroadcastTimeout time.Duration
+	Verifier         tmlite.Verifier
+	FromName         string
+	Codec            *codec.Codec
+	TrustNode        bool
+	UseLedger        bool
+	Simulate         bool
+	GenerateOnly     bool
+	Indent           bool
+	SkipConfirm      bool
 }
 
 // NewCLIContextWithInputAndFrom returns a new initialized CLIContext with parameters from the
@@ -74,24 +76,25 @@ func NewCLIContextWithInputAndFrom(input io.Reader, from string) CLIContext {
 	}
 
 	ctx := CLIContext{
-		Client:        rpc,
-		ChainID:       viper.GetString(flags.FlagChainID),
-		Input:         input,
-		Output:        os.Stdout,
-		NodeURI:       nodeURI,
-		From:          viper.GetString(flags.FlagFrom),
-		OutputFormat:  viper.GetString(cli.OutputFlag),
-		Height:        viper.GetInt64(flags.FlagHeight),
-		HomeDir:       viper.GetString(flags.FlagHome),
-		TrustNode:     viper.GetBool(flags.FlagTrustNode),
-		UseLedger:     viper.GetBool(flags.FlagUseLedger),
-		BroadcastMode: viper.GetString(flags.FlagBroadcastMode),
-		Simulate:      viper.GetBool(flags.FlagDryRun),
-		GenerateOnly:  genOnly,
-		FromAddress:   fromAddress,
-		FromName:      fromName,
-		Indent:        viper.GetBool(flags.FlagIndentResponse),
-		SkipConfirm:   viper.GetBool(flags.FlagSkipConfirmation),
+		Client:           rpc,
+		ChainID:          viper.GetString(flags.FlagChainID),
+		Input:            input,
+		Output:           os.Stdout,
+		NodeURI:          nodeURI,
+		From:             viper.GetString(flags.FlagFrom),
+		OutputFormat:     viper.GetString(cli.OutputFlag),
+		Height:           viper.GetInt64(flags.FlagHeight),
+		HomeDir:          viper.GetString(flags.FlagHome),
+		TrustNode:        viper.GetBool(flags.FlagTrustNode),
+		UseLedger:        viper.GetBool(flags.FlagUseLedger),
+		BroadcastMode:    viper.GetString(flags.FlagBroadcastMode),
+		BroadcastTimeout: viper.GetDuration(flags.FlagBroadcastTimeout),
+		Simulate:         viper.GetBool(flags.FlagDryRun),
+		GenerateOnly:     genOnly,
+		FromAddress:      fromAddress,
+		FromName:         fromName,
+		Indent:           viper.GetBool(flags.FlagIndentResponse),
+		SkipConfirm:      viper.GetBool(flags.FlagSkipConfirmation),
 	}
 
 	// create a verifier for the specific chain ID and RPC client
@@ -228,6 +231,13 @@ func (ctx CLIContext) WithBroadcastMode(mode string) CLIContext {
 	return ctx
 }
 
+// WithBroadcastTimeout returns a copy of the context with an updated timeout
+// of the wait broadcast mode.
+func (ctx CLIContext) WithBroadcastTimeout(timeout time.Duration) CLIContext {
+	ctx.BroadcastTimeout = timeout
+	return ctx
+}
+
 // PrintOutput prints output while respecting output and indent flags
 // NOTE: pass in marshalled structs that have been unmarshaled
 // because this function will panic on marshaling errors
@@ -4,6 +4,22 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// ClientCodespace is the codespace of the errors returned by the client when
+// broadcasting txs.
+const ClientCodespace = "client"
+
+var (
+	// ErrTxTimeout is returned by the wait broadcast mode when a tx accepted
+	// into the mempool has not been included in a block before the timeout.
+	ErrTxTimeout = sdkerrors.Register(ClientCodespace, 1, "timed out waiting for tx to be included in a block")
+
+	// ErrTxReplaced is returned by the wait broadcast mode when a tx is
+	// rejected because another tx of the same account and sequence has been
+	// accepted before it.
+	ErrTxReplaced = sdkerrors.Register(ClientCodespace, 2, "tx sequence already used by another tx")
 )
 
 // ErrInvalidAccount returns a standardized error reflecting that a given
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -24,6 +25,10 @@ const (
 
 	// DefaultKeyringBackend
 	DefaultKeyringBackend = keys.BackendOS
+
+	// DefaultBroadcastTimeout is how long the wait broadcast mode waits for a
+	// tx to be included in a block.
+	DefaultBroadcastTimeout = time.Minute
 )
 
 const (
@@ -36,6 +41,10 @@ const (
 	// BroadcastAsync defines a tx broadcasting mode where the client returns
 	// immediately.
 	BroadcastAsync = "async"
+	// BroadcastWait defines a tx broadcasting mode where the client waits for
+	// a CheckTx execution response and then polls the node until the tx is
+	// included in a block or the broadcast timeout expires.
+	BroadcastWait = "wait"
 )
 
 // List of CLI flags
@@ -55,6 +64,7 @@ const (
 	FlagFees               = "fees"
 	FlagGasPrices          = "gas-prices"
 	FlagBroadcastMode      = "broadcast-mode"
+	FlagBroadcastTimeout   = "broadcast-timeout"
 	FlagDryRun             = "dry-run"
 	FlagGenerateOnly       = "generate-only"
 	FlagIndentResponse     = "indent"
@@ -111,12 +121,13 @@ func PostCommands(cmds ...*cobra.Command) []*cobra.Command {
 		c.Flags().String(FlagNode, "tcp://localhost:26657", "<host>:<port> to tendermint rpc interface for this chain")
 		c.Flags().Bool(FlagUseLedger, false, "Use a connected Ledger device")
 		c.Flags().Float64(FlagGasAdjustment, DefaultGasAdjustment, "adjustment factor to be multiplied against the estimate returned by the tx simulation; if the gas limit is set manually this flag is ignored ")
-		c.Flags().StringP(FlagBroadcastMode, "b", BroadcastSync, "Transaction broadcasting mode (sync|async|block)")
+		c.Flags().StringP(FlagBroadcastMode, "b", BroadcastSync, "Transaction broadcasting mode (sync|async|block|wait)")
+		c.Flags().Duration(FlagBroadcastTimeout, DefaultBroadcastTimeout, "How long to wait for the tx to be included in a block (wait mode only)")
 		c.Flags().Bool(FlagTrustNode, true, "Trust connected full node (don't verify proofs for responses)")
 		c.Flags().Bool(FlagDryRun, false, "ignore the --gas flag and perform a simulation of a transaction, but don't broadcast it")
 		c.Flags().Bool(FlagGenerateOnly, false, "Build an unsigned transaction and write it to STDOUT (when enabled, the local Keybase is not accessible and the node operates offline)")
 		c.Flags().BoolP(FlagSkipConfirmation, "y", false, "Skip tx broadcasting prompt confirmation")
-		c.Flags().String(FlagKeyringBackend, DefaultKeyringBackend, "Select keyring's backend (os|file|test)")
+		c.Flags().String(FlagKeyringBackend, DefaultKeyringBackend, "Select keyring's backend (os|file|kwallet|pass|test|remote)")
 
 		// --gas can accept integers and "simulate"
 		c.Flags().Var(&GasFlagVar, "gas", fmt.Sprintf(
@@ -0,0 +1,143 @@
+package keys
+
+import (
+	"bufio"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/input"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const flagOnConflict = "on-conflict"
+
+// BackupCommand writes every key of the key store into an encrypted archive.
+func BackupCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up all keys into a single encrypted archive",
+		Long: `Write every key of the local keybase (local, ledger, offline and multisig keys)
+into a single versioned archive, ASCII armored and encrypted with a backup
+passphrase. The private keys of local keys are included, and HD wallets keep
+their members grouped. The archive is printed to standard output unless
+--output-file is given, and can be restored into any keyring backend with
+the restore command.`,
+		Args: cobra.NoArgs,
+		RunE: runBackupCmd,
+	}
+	cmd.Flags().String(flagOutputFile, "", "Write the archive to the given file instead of standard output")
+	return cmd
+}
+
+func runBackupCmd(cmd *cobra.Command, _ []string) error {
+	buf := bufio.NewReader(cmd.InOrStdin())
+	kb, err := keys.NewKeyring(sdk.KeyringServiceName(), viper.GetString(flags.FlagKeyringBackend), viper.GetString(flags.FlagHome), buf)
+	if err != nil {
+		return err
+	}
+
+	decryptPassword, err := input.GetPassword("Enter passphrase to decrypt your keys:", buf)
+	if err != nil {
+		return err
+	}
+	backupPassword, err := input.GetCheckPassword(
+		"Enter passphrase to encrypt the backup:", "Repeat the passphrase:", buf)
+	if err != nil {
+		return err
+	}
+
+	backup, err := keys.NewBackup(kb, decryptPassword)
+	if err != nil {
+		return err
+	}
+
+	armored, err := keys.ArmorBackup(backup, backupPassword)
+	if err != nil {
+		return err
+	}
+
+	if file := viper.GetString(flagOutputFile); file != "" {
+		if err := ioutil.WriteFile(file, []byte(armored), 0600); err != nil {
+			return err
+		}
+	} else {
+		cmd.Println(armored)
+	}
+
+	cmd.PrintErrf("backed up %d keys\n", len(backup.Entries))
+	return nil
+}
+
+// RestoreCommand imports the keys of an archive written by the backup command.
+func RestoreCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore <file>",
+		Short: "Restore keys from an encrypted backup archive",
+		Long: `Verify the integrity of an archive written by the backup command and import its
+keys into the local keybase. Keys which already exist with the same public key
+are left unchanged. Names taken by different keys are handled according to
+--on-conflict: abort fails before any key is written, skip keeps the existing
+keys and overwrite replaces them. The keys of an HD wallet are handled as a
+group, i.e. if any of them conflicts, the whole wallet is skipped or overwritten.
+
+It is recommended to run in 'dry-run' mode first to list what would be restored.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: runRestoreCmd,
+	}
+	cmd.Flags().String(flagOnConflict, string(keys.ConflictAbort), "How to handle keys whose name is already taken (abort|skip|overwrite)")
+	cmd.Flags().Bool(flags.FlagDryRun, false, "List the keys that would be restored without actually persisting any changes")
+	return cmd
+}
+
+func runRestoreCmd(cmd *cobra.Command, args []string) error {
+	policy, err := keys.ConflictPolicyFromString(viper.GetString(flagOnConflict))
+	if err != nil {
+		return err
+	}
+
+	bz, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	buf := bufio.NewReader(cmd.InOrStdin())
+	kb, err := keys.NewKeyring(sdk.KeyringServiceName(), viper.GetString(flags.FlagKeyringBackend), viper.GetString(flags.FlagHome), buf)
+	if err != nil {
+		return err
+	}
+
+	backupPassword, err := input.GetPassword("Enter passphrase to decrypt the backup:", buf)
+	if err != nil {
+		return err
+	}
+
+	backup, err := keys.UnarmorBackup(string(bz), backupPassword)
+	if err != nil {
+		return err
+	}
+
+	dryRun := viper.GetBool(flags.FlagDryRun)
+	results, err := kb.RestoreBackup(backup, DefaultKeyPass, policy, dryRun)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		cmd.PrintErrf("Backup of %s with %d keys (dry run, nothing was written):\n",
+			backup.CreatedAt.Format("2006-01-02 15:04:05 MST"), len(backup.Entries))
+	}
+	for _, result := range results {
+		line := result.Info.GetName() + " (" + result.Info.GetType().String() + ")"
+		if wallet := keys.InfoWallet(result.Info); wallet != "" {
+			line += " wallet " + wallet
+		}
+		cmd.Printf("%-12s %s %s\n", result.Status, line, result.Info.GetAddress())
+	}
+
+	return nil
+}
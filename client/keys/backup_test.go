@@ -0,0 +1,63 @@
+package keys
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/cosmos/cosmos-sdk/tests"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func Test_runBackupRestoreCmds(t *testing.T) {
+	backupCmd := BackupCommand()
+	restoreCmd := RestoreCommand()
+	mockIn, _, _ := tests.ApplyMockIO(backupCmd)
+	tests.ApplyMockIO(restoreCmd)
+	restoreCmd.SetIn(mockIn)
+
+	kbHome, cleanUp := tests.NewTestCaseDir(t)
+	defer cleanUp()
+	viper.Set(flags.FlagHome, kbHome)
+
+	kb, err := keys.NewKeyring(sdk.KeyringServiceName(), viper.GetString(flags.FlagKeyringBackend), viper.GetString(flags.FlagHome), mockIn)
+	require.NoError(t, err)
+	info, err := kb.CreateAccount("keyname1", tests.TestMnemonic, "", "123456789", "", keys.Secp256k1)
+	require.NoError(t, err)
+
+	archive := filepath.Join(kbHome, "backup.txt")
+	viper.Set(flagOutputFile, archive)
+	defer viper.Set(flagOutputFile, "")
+
+	mockIn.Reset("123456789\nbackuppass\n")
+	require.NoError(t, runBackupCmd(backupCmd, nil))
+
+	// restore into the same keybase after deleting the key
+	require.NoError(t, kb.Delete("keyname1", "", true))
+
+	viper.Set(flagOnConflict, "merge")
+	mockIn.Reset("backuppass\n")
+	require.Error(t, runRestoreCmd(restoreCmd, []string{archive}))
+	viper.Set(flagOnConflict, string(keys.ConflictAbort))
+
+	mockIn.Reset("wrongpass\n")
+	require.Error(t, runRestoreCmd(restoreCmd, []string{archive}))
+
+	viper.Set(flags.FlagDryRun, true)
+	mockIn.Reset("backuppass\n")
+	require.NoError(t, runRestoreCmd(restoreCmd, []string{archive}))
+	_, err = kb.Get("keyname1")
+	require.Error(t, err)
+
+	viper.Set(flags.FlagDryRun, false)
+	mockIn.Reset("backuppass\n")
+	require.NoError(t, runRestoreCmd(restoreCmd, []string{archive}))
+
+	restored, err := kb.Get("keyname1")
+	require.NoError(t, err)
+	require.Equal(t, info.GetPubKey(), restored.GetPubKey())
+}
@@ -0,0 +1,106 @@
+package keys
+
+import (
+	"bufio"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/input"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const flagOutputFile = "output-file"
+
+// ImportEthKeystoreCommand imports a private key from an Ethereum keystore file.
+func ImportEthKeystoreCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import-eth-keystore <name> <keyfile>",
+		Short: "Import a private key from an Ethereum keystore file",
+		Long: `Import a private key from a Web3 Secret Storage (V3) JSON keystore file, such as
+the ones written by geth or exported from MetaMask, into the local keybase.
+Keystores encrypted with either scrypt or pbkdf2 are supported.`,
+		Args: cobra.ExactArgs(2),
+		RunE: runImportEthKeystoreCmd,
+	}
+	cmd.Flags().String(flagKeyAlgo, string(keys.Secp256k1), "Key signing algorithm to import the key as (secp256k1|eth_secp256k1)")
+	return cmd
+}
+
+func runImportEthKeystoreCmd(cmd *cobra.Command, args []string) error {
+	buf := bufio.NewReader(cmd.InOrStdin())
+	kb, err := keys.NewKeyring(sdk.KeyringServiceName(), viper.GetString(flags.FlagKeyringBackend), viper.GetString(flags.FlagHome), buf)
+	if err != nil {
+		return err
+	}
+
+	bz, err := ioutil.ReadFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	keystorePassphrase, err := input.GetPassword("Enter passphrase to decrypt the keystore:", buf)
+	if err != nil {
+		return err
+	}
+
+	algo := keys.SigningAlgo(viper.GetString(flagKeyAlgo))
+	if algo == keys.SigningAlgo("") {
+		algo = keys.Secp256k1
+	}
+
+	info, err := kb.ImportEthKeystore(args[0], string(bz), keystorePassphrase, keystorePassphrase, algo)
+	if err != nil {
+		return err
+	}
+
+	cmd.PrintErrf("imported key %s with address %s\n", info.GetName(), info.GetAddress())
+	return nil
+}
+
+// ExportEthKeystoreCommand exports a private key as an Ethereum keystore file.
+func ExportEthKeystoreCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-eth-keystore <name>",
+		Short: "Export a private key as an Ethereum keystore file",
+		Long: `Export a private key from the local keybase as a Web3 Secret Storage (V3) JSON
+keystore encrypted with scrypt, which can be imported into geth or MetaMask.
+The keystore is printed to standard output unless --output-file is given.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runExportEthKeystoreCmd,
+	}
+	cmd.Flags().String(flagOutputFile, "", "Write the keystore to the given file instead of standard output")
+	return cmd
+}
+
+func runExportEthKeystoreCmd(cmd *cobra.Command, args []string) error {
+	buf := bufio.NewReader(cmd.InOrStdin())
+	kb, err := keys.NewKeyring(sdk.KeyringServiceName(), viper.GetString(flags.FlagKeyringBackend), viper.GetString(flags.FlagHome), buf)
+	if err != nil {
+		return err
+	}
+
+	decryptPassword, err := input.GetPassword("Enter passphrase to decrypt your key:", buf)
+	if err != nil {
+		return err
+	}
+	keystorePassword, err := input.GetPassword("Enter passphrase to encrypt the exported keystore:", buf)
+	if err != nil {
+		return err
+	}
+
+	keyJSON, err := kb.ExportEthKeystore(args[0], decryptPassword, keystorePassword)
+	if err != nil {
+		return err
+	}
+
+	if file := viper.GetString(flagOutputFile); file != "" {
+		return ioutil.WriteFile(file, []byte(keyJSON), 0600)
+	}
+
+	cmd.Println(keyJSON)
+	return nil
+}
@@ -0,0 +1,50 @@
+package keys
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/cosmos/cosmos-sdk/tests"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func Test_runEthKeystoreCmds(t *testing.T) {
+	exportCmd := ExportEthKeystoreCommand()
+	importCmd := ImportEthKeystoreCommand()
+	mockIn, _, _ := tests.ApplyMockIO(exportCmd)
+	tests.ApplyMockIO(importCmd)
+	importCmd.SetIn(mockIn)
+
+	// Now add a temporary keybase
+	kbHome, cleanUp := tests.NewTestCaseDir(t)
+	defer cleanUp()
+	viper.Set(flags.FlagHome, kbHome)
+
+	kb, err := keys.NewKeyring(sdk.KeyringServiceName(), viper.GetString(flags.FlagKeyringBackend), viper.GetString(flags.FlagHome), mockIn)
+	require.NoError(t, err)
+	info, err := kb.CreateAccount("keyname1", tests.TestMnemonic, "", "123456789", "", keys.Secp256k1)
+	require.NoError(t, err)
+
+	keyfile := filepath.Join(kbHome, "keystore.json")
+	viper.Set(flagOutputFile, keyfile)
+	defer viper.Set(flagOutputFile, "")
+
+	mockIn.Reset("123456789\nkeystorepass\n")
+	require.NoError(t, runExportEthKeystoreCmd(exportCmd, []string{"keyname1"}))
+
+	// wrong keystore passphrase
+	mockIn.Reset("wrongpass\n")
+	require.Error(t, runImportEthKeystoreCmd(importCmd, []string{"keyname2", keyfile}))
+
+	mockIn.Reset("keystorepass\n")
+	require.NoError(t, runImportEthKeystoreCmd(importCmd, []string{"keyname2", keyfile}))
+
+	imported, err := kb.Get("keyname2")
+	require.NoError(t, err)
+	require.Equal(t, info.GetPubKey(), imported.GetPubKey())
+}
@@ -2,6 +2,7 @@ package keys
 
 import (
 	"bufio"
+	"fmt"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -14,13 +15,17 @@ import (
 
 // ExportKeyCommand exports private keys from the key store.
 func ExportKeyCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "export <name>",
 		Short: "Export private keys",
-		Long:  `Export a private key from the local keybase in ASCII-armored encrypted format.`,
-		Args:  cobra.ExactArgs(1),
-		RunE:  runExportCmd,
+		Long: `Export a private key from the local keybase in ASCII-armored encrypted format.
+With --wallet, <name> is the name of an HD wallet and the private keys of all its
+keys are exported, one after the other.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runExportCmd,
 	}
+	cmd.Flags().Bool(flagWallet, false, "Export all the keys of the HD wallet <name>")
+	return cmd
 }
 
 func runExportCmd(cmd *cobra.Command, args []string) error {
@@ -39,11 +44,30 @@ func runExportCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	armored, err := kb.ExportPrivKey(args[0], decryptPassword, encryptPassword)
-	if err != nil {
-		return err
+	names := []string{args[0]}
+	if viper.GetBool(flagWallet) {
+		infos, err := kb.ListWallet(args[0])
+		if err != nil {
+			return err
+		}
+		if len(infos) == 0 {
+			return fmt.Errorf("no keys in wallet %s", args[0])
+		}
+
+		names = make([]string, len(infos))
+		for i, info := range infos {
+			names[i] = info.GetName()
+		}
+	}
+
+	for _, name := range names {
+		armored, err := kb.ExportPrivKey(name, decryptPassword, encryptPassword)
+		if err != nil {
+			return err
+		}
+
+		cmd.Println(armored)
 	}
 
-	cmd.Println(armored)
 	return nil
 }
@@ -17,11 +17,13 @@ func ListKeysCmd() *cobra.Command {
 		Use:   "list",
 		Short: "List all keys",
 		Long: `Return a list of all public keys stored by this key manager
-along with their associated name and address.`,
+along with their associated name and address. With --wallet, only the keys
+of the given HD wallet are listed.`,
 		RunE: runListCmd,
 	}
 	cmd.Flags().Bool(flags.FlagIndentResponse, false, "Add indent to JSON response")
 	cmd.Flags().BoolP(flagListNames, "n", false, "List names only")
+	cmd.Flags().String(flagWallet, "", "List the keys of the given HD wallet only")
 	return cmd
 }
 
@@ -31,7 +33,12 @@ func runListCmd(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	infos, err := kb.List()
+	var infos []keys.Info
+	if wallet := viper.GetString(flagWallet); wallet != "" {
+		infos, err = kb.ListWallet(wallet)
+	} else {
+		infos, err = kb.List()
+	}
 	if err != nil {
 		return err
 	}
@@ -5,11 +5,14 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
 )
 
 // Commands registers a sub-tree of commands to interact with
-// local private key storage.
-func Commands() *cobra.Command {
+// local private key storage. The keybase options of the app, e.g. its
+// supported algos and key generation function, apply to the commands deriving
+// HD wallets.
+func Commands(opts ...keys.KeybaseOption) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "keys",
 		Short: "Add or view local private keys",
@@ -22,8 +25,14 @@ func Commands() *cobra.Command {
 	cmd.AddCommand(
 		MnemonicKeyCommand(),
 		AddKeyCommand(),
+		AddWalletCommand(opts...),
+		DiscoverWalletCommand(opts...),
 		ExportKeyCommand(),
 		ImportKeyCommand(),
+		ImportEthKeystoreCommand(),
+		ExportEthKeystoreCommand(),
+		BackupCommand(),
+		RestoreCommand(),
 		ListKeysCmd(),
 		ShowKeysCmd(),
 		flags.LineBreak,
@@ -32,7 +41,7 @@ func Commands() *cobra.Command {
 		ParseKeyStringCommand(),
 		MigrateCommand(),
 	)
-	cmd.PersistentFlags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|test)")
+	cmd.PersistentFlags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|kwallet|pass|test|remote)")
 	viper.BindPFlag(flags.FlagKeyringBackend, cmd.Flags().Lookup(flags.FlagKeyringBackend))
 	return cmd
 }
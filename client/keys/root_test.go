@@ -16,7 +16,7 @@ func TestCommands(t *testing.T) {
 	assert.NotNil(t, rootCommands)
 
 	// Commands are registered
-	assert.Equal(t, 11, len(rootCommands.Commands()))
+	assert.Equal(t, 17, len(rootCommands.Commands()))
 }
 
 func TestMain(m *testing.M) {
@@ -0,0 +1,282 @@
+package keys
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+
+	bip39 "github.com/cosmos/go-bip39"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/input"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+const (
+	flagWallet   = "wallet"
+	flagCount    = "count"
+	flagGapLimit = "gap-limit"
+
+	defaultGapLimit = 20
+
+	// hardenedIndex is the first hardened BIP32 child index, address indexes
+	// are below it
+	hardenedIndex = 1 << 31
+)
+
+// AddWalletCommand derives and stores a range of keys of an HD wallet. The
+// keybase options apply to the keyring the keys are derived in.
+func AddWalletCommand(opts ...keys.KeybaseOption) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-wallet <wallet>",
+		Short: "Derive a range of keys from a mnemonic and store them as an HD wallet",
+		Long: `Derive the keys at address indexes --index to --index + --count - 1 of the
+BIP44 path m/44'/<coin-type>'/<account>'/0/<index> and store them under the given
+wallet name. Keys are named <wallet>-<index>, or <wallet>-<account>-<index> for
+accounts other than 0.
+
+A new mnemonic is generated unless --recover is set, in which case the mnemonic
+and the BIP39 passphrase are read from the input. Adding keys to an existing
+wallet requires the mnemonic it was created from.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAddWalletCmd(cmd, args, opts)
+		},
+	}
+	cmd.Flags().Bool(flagRecover, false, "Provide seed phrase to recover an existing wallet instead of creating one")
+	cmd.Flags().Bool(flagNoBackup, false, "Don't print out seed phrase (if others are watching the terminal)")
+	cmd.Flags().Uint32(flagAccount, 0, "Account number for HD derivation")
+	cmd.Flags().Uint32(flagIndex, 0, "First address index number for HD derivation")
+	cmd.Flags().Uint32(flagCount, 1, "Number of consecutive address indexes to derive")
+	cmd.Flags().Uint32(flagCointype, 60, "Coin type for HD derivation")
+	cmd.Flags().String(flagKeyAlgo, string(keys.Secp256k1), "Key signing algorithm to generate keys for")
+	cmd.Flags().Bool(flags.FlagIndentResponse, false, "Add indent to JSON response")
+	return cmd
+}
+
+func runAddWalletCmd(cmd *cobra.Command, args []string, opts []keys.KeybaseOption) error {
+	inBuf := bufio.NewReader(cmd.InOrStdin())
+	kb, err := keys.NewKeyring(
+		sdk.KeyringServiceName(), viper.GetString(flags.FlagKeyringBackend), viper.GetString(flags.FlagHome), inBuf, opts...,
+	)
+	if err != nil {
+		return err
+	}
+
+	count := uint32(viper.GetInt(flagCount))
+	if count == 0 {
+		return errors.New("count must be positive")
+	}
+
+	var mnemonic, bip39Passphrase string
+	recover := viper.GetBool(flagRecover)
+	if recover {
+		mnemonic, bip39Passphrase, err = readMnemonic(inBuf)
+		if err != nil {
+			return err
+		}
+	} else {
+		entropySeed, err := bip39.NewEntropy(mnemonicEntropySize)
+		if err != nil {
+			return err
+		}
+
+		mnemonic, err = bip39.NewMnemonic(entropySeed)
+		if err != nil {
+			return err
+		}
+	}
+
+	start := uint32(viper.GetInt(flagIndex))
+	indexes := make([]uint32, count)
+	for i := range indexes {
+		indexes[i] = start + uint32(i)
+	}
+
+	infos, err := kb.CreateWalletAccounts(
+		args[0], mnemonic, bip39Passphrase, DefaultKeyPass,
+		uint32(viper.GetInt(flagCointype)), uint32(viper.GetInt(flagAccount)), indexes, walletAlgo(),
+	)
+	if err != nil {
+		return err
+	}
+
+	printInfos(infos)
+	if !recover && !viper.GetBool(flagNoBackup) {
+		cmd.PrintErrln("\n**Important** write this mnemonic phrase in a safe place.")
+		cmd.PrintErrln("It is the only way to recover your wallet if you ever forget your password.")
+		cmd.PrintErrln("")
+		cmd.PrintErrln(mnemonic)
+	}
+
+	return nil
+}
+
+// DiscoverWalletCommand scans the address indexes of an HD wallet for accounts
+// existing on chain. The keybase options, e.g. the supported algos and key
+// generation function of the app, apply to the keyring and to the keybase the
+// addresses are derived in.
+func DiscoverWalletCommand(opts ...keys.KeybaseOption) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "discover <wallet>",
+		Short: "Discover the used addresses of an HD wallet by querying a node",
+		Long: `Derive the addresses of the BIP44 path m/44'/<coin-type>'/<account>'/0/<index>
+from a mnemonic, starting at --index, and query a node for their accounts. The
+scan stops once --gap-limit consecutive addresses have no account, and the keys
+of the addresses found are stored under the given wallet name, as add-wallet
+does. The mnemonic and the BIP39 passphrase are read from the input.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiscoverWalletCmd(cmd, args, opts)
+		},
+	}
+	cmd.Flags().Uint32(flagAccount, 0, "Account number for HD derivation")
+	cmd.Flags().Uint32(flagIndex, 0, "Address index number to start scanning at")
+	cmd.Flags().Uint32(flagCointype, 60, "Coin type for HD derivation")
+	cmd.Flags().Uint32(flagGapLimit, defaultGapLimit, "Number of consecutive unused addresses after which the scan stops")
+	cmd.Flags().String(flagKeyAlgo, string(keys.Secp256k1), "Key signing algorithm to generate keys for")
+	cmd.Flags().Bool(flagDryRun, false, "Print the addresses found, but don't add their keys to the local keystore")
+	return flags.GetCommands(cmd)[0]
+}
+
+func runDiscoverWalletCmd(cmd *cobra.Command, args []string, opts []keys.KeybaseOption) error {
+	inBuf := bufio.NewReader(cmd.InOrStdin())
+	kb, err := keys.NewKeyring(
+		sdk.KeyringServiceName(), viper.GetString(flags.FlagKeyringBackend), viper.GetString(flags.FlagHome), inBuf, opts...,
+	)
+	if err != nil {
+		return err
+	}
+
+	mnemonic, bip39Passphrase, err := readMnemonic(inBuf)
+	if err != nil {
+		return err
+	}
+
+	cliCtx := context.NewCLIContext()
+	accGetter := authtypes.NewAccountRetriever(cliCtx)
+
+	return discoverWallet(cmd, kb, opts, args[0], mnemonic, bip39Passphrase, func(addr sdk.AccAddress) (bool, error) {
+		err := accGetter.EnsureExists(addr)
+		switch {
+		case err == nil:
+			return true, nil
+		case strings.Contains(err.Error(), sdkerrors.ErrUnknownAddress.Error()):
+			return false, nil
+		default:
+			return false, err
+		}
+	})
+}
+
+func discoverWallet(
+	cmd *cobra.Command, kb keys.Keybase, opts []keys.KeybaseOption, wallet, mnemonic, bip39Passphrase string,
+	used func(sdk.AccAddress) (bool, error),
+) error {
+
+	coinType, account := uint32(viper.GetInt(flagCointype)), uint32(viper.GetInt(flagAccount))
+	algo := walletAlgo()
+
+	// derive the public keys in a transient keybase, set up as kb
+	scratch := keys.NewInMemory(opts...)
+	indexes, err := discoverIndexes(uint32(viper.GetInt(flagIndex)), uint32(viper.GetInt(flagGapLimit)), func(index uint32) (bool, error) {
+		info, err := scratch.CreateAccount(
+			fmt.Sprint(index), mnemonic, bip39Passphrase, "",
+			keys.CreateHDPathEx(coinType, account, index).String(), algo,
+		)
+		if err != nil {
+			return false, err
+		}
+
+		return used(info.GetAddress())
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(indexes) == 0 {
+		cmd.PrintErrln("No used address found.")
+		return nil
+	}
+
+	if viper.GetBool(flagDryRun) {
+		kb = scratch
+	}
+
+	infos, err := kb.CreateWalletAccounts(wallet, mnemonic, bip39Passphrase, DefaultKeyPass, coinType, account, indexes, algo)
+	if err != nil {
+		return err
+	}
+
+	printInfos(infos)
+	return nil
+}
+
+// discoverIndexes returns the used address indexes from start on, stopping
+// after gapLimit consecutive unused ones. The scan fails if it reaches the
+// hardened indexes.
+func discoverIndexes(start, gapLimit uint32, used func(index uint32) (bool, error)) ([]uint32, error) {
+	if gapLimit == 0 {
+		return nil, errors.New("gap limit must be positive")
+	}
+
+	var indexes []uint32
+	for index, gap := start, uint32(0); gap < gapLimit; index++ {
+		if index >= hardenedIndex {
+			return nil, fmt.Errorf("address index %d is out of the non-hardened range", index)
+		}
+
+		ok, err := used(index)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			indexes = append(indexes, index)
+			gap = 0
+		} else {
+			gap++
+		}
+	}
+
+	return indexes, nil
+}
+
+// readMnemonic reads a BIP39 mnemonic and passphrase from the input.
+func readMnemonic(inBuf *bufio.Reader) (mnemonic, bip39Passphrase string, err error) {
+	mnemonic, err = input.GetString("Enter your bip39 mnemonic", inBuf)
+	if err != nil {
+		return "", "", err
+	}
+
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return "", "", errors.New("invalid mnemonic")
+	}
+
+	bip39Passphrase, err = input.GetString(
+		"Enter your bip39 passphrase. This is combined with the mnemonic to derive the seed. "+
+			"Most users should just hit enter to use the default, \"\"", inBuf)
+	if err != nil {
+		return "", "", err
+	}
+
+	return mnemonic, bip39Passphrase, nil
+}
+
+func walletAlgo() keys.SigningAlgo {
+	algo := keys.SigningAlgo(viper.GetString(flagKeyAlgo))
+	if algo == keys.SigningAlgo("") {
+		algo = keys.Secp256k1
+	}
+
+	return algo
+}
@@ -0,0 +1,136 @@
+package keys
+
+import (
+	"testing"
+
+	bip39 "github.com/cosmos/go-bip39"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/cosmos/cosmos-sdk/tests"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func Test_discoverIndexes(t *testing.T) {
+	used := map[uint32]bool{0: true, 2: true, 5: true, 9: true}
+	scanned := 0
+	indexes, err := discoverIndexes(0, 3, func(index uint32) (bool, error) {
+		scanned++
+		return used[index], nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0, 2, 5}, indexes)
+	require.Equal(t, 9, scanned)
+
+	indexes, err = discoverIndexes(6, 4, func(index uint32) (bool, error) { return used[index], nil })
+	require.NoError(t, err)
+	require.Equal(t, []uint32{9}, indexes)
+
+	_, err = discoverIndexes(0, 0, func(uint32) (bool, error) { return true, nil })
+	require.Error(t, err)
+
+	// the scan stops at the hardened indexes
+	indexes, err = discoverIndexes(hardenedIndex-2, 2, func(uint32) (bool, error) { return false, nil })
+	require.NoError(t, err)
+	require.Empty(t, indexes)
+	_, err = discoverIndexes(hardenedIndex-2, 3, func(uint32) (bool, error) { return false, nil })
+	require.Error(t, err)
+	_, err = discoverIndexes(hardenedIndex-1, 1, func(uint32) (bool, error) { return true, nil })
+	require.Error(t, err)
+}
+
+func Test_runAddWalletCmd(t *testing.T) {
+	cmd := AddWalletCommand()
+	mockIn, _, _ := tests.ApplyMockIO(cmd)
+
+	kbHome, cleanUp := tests.NewTestCaseDir(t)
+	defer cleanUp()
+	viper.Set(flags.FlagHome, kbHome)
+	viper.Set(flagRecover, true)
+	viper.Set(flagCount, 3)
+	viper.Set(flagIndex, 1)
+	defer func() {
+		viper.Set(flagRecover, false)
+		viper.Set(flagCount, 1)
+		viper.Set(flagIndex, 0)
+	}()
+
+	mockIn.Reset(tests.TestMnemonic + "\n\n")
+	require.NoError(t, runAddWalletCmd(cmd, []string{"hot"}, nil))
+
+	kb, err := keys.NewKeyring(sdk.KeyringServiceName(), viper.GetString(flags.FlagKeyringBackend), kbHome, mockIn)
+	require.NoError(t, err)
+	infos, err := kb.ListWallet("hot")
+	require.NoError(t, err)
+	require.Len(t, infos, 3)
+	require.Equal(t, "hot-1", infos[0].GetName())
+	require.Equal(t, "hot-3", infos[2].GetName())
+
+	// a different mnemonic can't be added to the wallet
+	otherMnemonic, err := bip39.NewMnemonic(make([]byte, 32))
+	require.NoError(t, err)
+	require.NotEqual(t, tests.TestMnemonic, otherMnemonic)
+	viper.Set(flagIndex, 4)
+	mockIn.Reset(otherMnemonic + "\n\n")
+	err = runAddWalletCmd(cmd, []string{"hot"}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "mnemonic does not match")
+
+	// the keybase options of the app apply to the keyring
+	opts := []keys.KeybaseOption{keys.WithSupportedAlgos([]keys.SigningAlgo{keys.Sr25519})}
+	cmd = AddWalletCommand(opts...)
+	mockIn, _, _ = tests.ApplyMockIO(cmd)
+	mockIn.Reset(tests.TestMnemonic + "\n\n")
+	err = runAddWalletCmd(cmd, []string{"cold"}, opts)
+	require.Equal(t, keys.ErrUnsupportedSigningAlgo, err)
+}
+
+func Test_discoverWallet(t *testing.T) {
+	cmd := DiscoverWalletCommand()
+	tests.ApplyMockIO(cmd)
+	viper.Set(flagGapLimit, 5)
+	viper.Set(flagCointype, 60)
+	viper.Set(flagDryRun, false)
+	defer viper.Set(flagGapLimit, defaultGapLimit)
+
+	// mark the addresses of indexes 0 and 4 as used
+	scratch := keys.NewInMemory()
+	used := map[string]bool{}
+	for _, index := range []uint32{0, 4} {
+		info, err := scratch.CreateAccount("k", tests.TestMnemonic, "", "", keys.CreateHDPathEx(60, 0, index).String(), keys.Secp256k1)
+		require.NoError(t, err)
+		used[info.GetAddress().String()] = true
+		require.NoError(t, scratch.Delete("k", "", true))
+	}
+
+	kb := keys.NewInMemory()
+	require.NoError(t, discoverWallet(cmd, kb, nil, "found", tests.TestMnemonic, "", func(addr sdk.AccAddress) (bool, error) {
+		return used[addr.String()], nil
+	}))
+
+	infos, err := kb.ListWallet("found")
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	require.Equal(t, "found-0", infos[0].GetName())
+	require.Equal(t, "found-4", infos[1].GetName())
+
+	// the addresses are derived with the keybase options
+	viper.Set(flagKeyAlgo, string(keys.EthSecp256k1))
+	defer viper.Set(flagKeyAlgo, string(keys.Secp256k1))
+	opts := []keys.KeybaseOption{
+		keys.WithKeygenFunc(func(bz []byte, algo keys.SigningAlgo) (tmcrypto.PrivKey, error) {
+			return keys.SecpPrivKeyGen(bz), nil
+		}),
+		keys.WithDeriveFunc(func(mnemonic, bip39Passphrase, hdPath string, algo keys.SigningAlgo) ([]byte, error) {
+			return keys.SecpDeriveKey(mnemonic, bip39Passphrase, hdPath)
+		}),
+		keys.WithSupportedAlgos([]keys.SigningAlgo{keys.Secp256k1, keys.EthSecp256k1}),
+	}
+
+	noneUsed := func(sdk.AccAddress) (bool, error) { return false, nil }
+	require.Error(t, discoverWallet(cmd, keys.NewInMemory(opts...), nil, "eth", tests.TestMnemonic, "", noneUsed))
+	require.NoError(t, discoverWallet(cmd, keys.NewInMemory(opts...), opts, "eth", tests.TestMnemonic, "", noneUsed))
+}
@@ -106,6 +106,9 @@ func ServeCommand(cdc *codec.Codec, registerRoutesFn func(*RestServer)) *cobra.C
 
 			registerRoutesFn(rs)
 			rs.registerSwaggerUI()
+			if err := rs.registerSigningService(); err != nil {
+				return err
+			}
 
 			// Start the rest server and return error if one exists
 			err = rs.Start(
@@ -120,7 +123,7 @@ func ServeCommand(cdc *codec.Codec, registerRoutesFn func(*RestServer)) *cobra.C
 		},
 	}
 
-	return flags.RegisterRestServerFlags(cmd)
+	return RegisterSigningServiceFlags(flags.RegisterRestServerFlags(cmd))
 }
 
 func StartRestServer(cdc *codec.Codec, registerRoutesFn func(*RestServer), tmNode *node.Node, addr string) error {
@@ -128,6 +131,10 @@ func StartRestServer(cdc *codec.Codec, registerRoutesFn func(*RestServer), tmNod
 
 	registerRoutesFn(rs)
 	rs.registerSwaggerUI()
+	if err := rs.registerSigningService(); err != nil {
+		rs.log.Error("failed to start the tx signing service", "err", err)
+		return err
+	}
 	rs.log.Info("start rest server")
 	// Start the rest server and return error if one exists
 	err := rs.Start(
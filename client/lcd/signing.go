@@ -0,0 +1,531 @@
+package lcd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/bech32"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+// signing service flags
+const (
+	FlagUnlockKey       = "rest.unlock_key"
+	FlagUnlockKeyHome   = "rest.unlock_key_home"
+	FlagSigningPolicy   = "rest.signing_policy"
+	FlagSigningAuditLog = "rest.signing_audit_log"
+
+	defaultSigningAuditLog = "rest-signing-audit.log"
+)
+
+// Signing service audit decisions
+const (
+	SigningRejected    = "rejected"
+	SigningFailed      = "failed"
+	SigningBroadcasted = "broadcasted"
+)
+
+// SigningPolicy restricts the transactions the signing service signs with a
+// key. Message types are given as <route>/<type>, e.g. "bank/send", and must be
+// listed for the key to sign any message. An empty MaxFee, RateLimit or
+// AllowedRecipients puts no limit on fees, rate or recipients respectively.
+type SigningPolicy struct {
+	AllowedMsgTypes   []string `json:"allowed_msg_types"`
+	MaxFee            string   `json:"max_fee,omitempty"`
+	RateLimit         int      `json:"rate_limit,omitempty"`
+	RatePeriod        string   `json:"rate_period,omitempty"`
+	AllowedRecipients []string `json:"allowed_recipients,omitempty"`
+}
+
+// SigningAuditRecord is the audit log entry written for each signing request.
+type SigningAuditRecord struct {
+	Time       time.Time `json:"time"`
+	RemoteAddr string    `json:"remote_addr"`
+	Key        string    `json:"key"`
+	MsgTypes   []string  `json:"msg_types,omitempty"`
+	Fee        string    `json:"fee,omitempty"`
+	Decision   string    `json:"decision"`
+	Reason     string    `json:"reason,omitempty"`
+	TxHash     string    `json:"txhash,omitempty"`
+}
+
+// SignAndBroadcastReq defines a request to the signing service. If both the
+// account number and the sequence are zero, they are taken from the service's
+// SequenceManager, so that many txs can be signed with a key per block.
+type SignAndBroadcastReq struct {
+	Key           string          `json:"key" yaml:"key"`
+	Tx            authtypes.StdTx `json:"tx" yaml:"tx"`
+	Mode          string          `json:"mode" yaml:"mode"`
+	AccountNumber uint64          `json:"account_number" yaml:"account_number"`
+	Sequence      uint64          `json:"sequence" yaml:"sequence"`
+}
+
+// signingRule is a SigningPolicy parsed for a key.
+type signingRule struct {
+	address    sdk.AccAddress
+	msgTypes   map[string]bool
+	maxFee     sdk.Coins
+	rateLimit  int
+	ratePeriod time.Duration
+	recipients map[string]bool
+	signed     []time.Time
+}
+
+// SigningService signs unsigned transactions with the unlocked keys of a
+// keybase, provided that they comply with the keys' policies, and broadcasts
+// them. Every decision is written to the audit log.
+type SigningService struct {
+	cliCtx    context.CLIContext
+	keybase   keys.Keybase
+	rules     map[string]*signingRule
+	sequences *utils.SequenceManager
+
+	mtx       sync.Mutex
+	auditMtx  sync.Mutex
+	audit     io.Writer
+	now       func() time.Time
+	broadcast func(cliCtx context.CLIContext, txBytes []byte) (sdk.TxResponse, error)
+}
+
+// NewSigningService returns a signing service for the given unlocked keys,
+// each of which must have a policy.
+func NewSigningService(
+	cliCtx context.CLIContext, kb keys.Keybase, keyNames []string, policies map[string]SigningPolicy, audit io.Writer,
+) (*SigningService, error) {
+
+	if len(keyNames) == 0 {
+		return nil, fmt.Errorf("no key to unlock")
+	}
+
+	rules := make(map[string]*signingRule, len(keyNames))
+	for _, name := range keyNames {
+		info, err := kb.Get(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unlock key %s: %v", name, err)
+		}
+
+		policy, ok := policies[name]
+		if !ok {
+			return nil, fmt.Errorf("no signing policy for key %s", name)
+		}
+
+		rule, err := newSigningRule(info.GetAddress(), policy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signing policy for key %s: %v", name, err)
+		}
+		rules[name] = rule
+	}
+
+	return &SigningService{
+		cliCtx:    cliCtx,
+		keybase:   kb,
+		rules:     rules,
+		sequences: utils.NewSequenceManager(cliCtx, 0),
+		audit:     audit,
+		now:       time.Now,
+		broadcast: func(cliCtx context.CLIContext, txBytes []byte) (sdk.TxResponse, error) {
+			return cliCtx.BroadcastTx(txBytes)
+		},
+	}, nil
+}
+
+func newSigningRule(addr sdk.AccAddress, policy SigningPolicy) (*signingRule, error) {
+	if len(policy.AllowedMsgTypes) == 0 {
+		return nil, fmt.Errorf("no allowed message type")
+	}
+
+	rule := &signingRule{
+		address:   addr,
+		msgTypes:  make(map[string]bool, len(policy.AllowedMsgTypes)),
+		rateLimit: policy.RateLimit,
+	}
+	for _, msgType := range policy.AllowedMsgTypes {
+		rule.msgTypes[msgType] = true
+	}
+
+	if policy.MaxFee != "" {
+		maxFee, err := sdk.ParseCoins(policy.MaxFee)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max fee: %v", err)
+		}
+		rule.maxFee = maxFee
+	}
+
+	if policy.RateLimit < 0 {
+		return nil, fmt.Errorf("negative rate limit")
+	}
+	if policy.RateLimit > 0 {
+		period, err := time.ParseDuration(policy.RatePeriod)
+		if err != nil || period <= 0 {
+			return nil, fmt.Errorf("invalid rate period %q", policy.RatePeriod)
+		}
+		rule.ratePeriod = period
+	}
+
+	if len(policy.AllowedRecipients) > 0 {
+		rule.recipients = make(map[string]bool, len(policy.AllowedRecipients))
+		for _, recipient := range policy.AllowedRecipients {
+			addr, ok, err := recipientAddress(recipient)
+			if err != nil || !ok {
+				return nil, fmt.Errorf("invalid recipient %s: not an account or validator operator address", recipient)
+			}
+			rule.recipients[addr] = true
+		}
+	}
+
+	return rule, nil
+}
+
+// LoadSigningPolicies reads the signing policies of keys, indexed by key name,
+// from a JSON file.
+func LoadSigningPolicies(file string) (map[string]SigningPolicy, error) {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies map[string]SigningPolicy
+	if err := json.Unmarshal(bz, &policies); err != nil {
+		return nil, fmt.Errorf("failed to parse signing policies: %v", err)
+	}
+
+	return policies, nil
+}
+
+// RegisterRoutes registers the signing endpoint of the service.
+func (s *SigningService) RegisterRoutes(rs *RestServer) {
+	rs.Mux.HandleFunc("/signing/txs", s.SignAndBroadcastRequest).Methods("POST")
+}
+
+// SignAndBroadcastRequest implements a handler that checks an unsigned tx
+// against the policy of the requested key, signs it and broadcasts it.
+func (s *SigningService) SignAndBroadcastRequest(w http.ResponseWriter, r *http.Request) {
+	record := SigningAuditRecord{RemoteAddr: r.RemoteAddr}
+	status, err := s.signAndBroadcast(w, r, &record)
+	if err != nil {
+		record.Reason = err.Error()
+		s.writeAudit(record)
+		rest.WriteErrorResponse(w, status, err.Error())
+		return
+	}
+
+	s.writeAudit(record)
+}
+
+func (s *SigningService) signAndBroadcast(w http.ResponseWriter, r *http.Request, record *SigningAuditRecord) (int, error) {
+	record.Decision = SigningRejected
+
+	var req SignAndBroadcastReq
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	if err := s.cliCtx.Codec.UnmarshalJSON(body, &req); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	record.Key = req.Key
+	record.Fee = req.Tx.Fee.Amount.String()
+	for _, msg := range req.Tx.GetMsgs() {
+		record.MsgTypes = append(record.MsgTypes, msgType(msg))
+	}
+
+	if req.Mode == "" {
+		req.Mode = flags.BroadcastSync
+	}
+
+	// sign and broadcast one tx at a time to keep sequences in order
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	rule, ok := s.rules[req.Key]
+	if !ok {
+		return http.StatusForbidden, fmt.Errorf("key %s is not unlocked", req.Key)
+	}
+	if status, err := s.checkPolicy(rule, req.Tx); err != nil {
+		return status, err
+	}
+
+	record.Decision = SigningFailed
+
+	accNum, seq := req.AccountNumber, req.Sequence
+	reserved := accNum == 0 && seq == 0
+	if reserved {
+		accNum, seq, err = s.sequences.Next(rule.address)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+	}
+
+	txBldr := authtypes.NewTxBuilder(nil, accNum, seq, 0, 0, false, viper.GetString(flags.FlagChainID), "", nil, nil).
+		WithKeybase(s.keybase)
+	var txBytes []byte
+	signedTx, err := txBldr.SignStdTx(req.Key, "", req.Tx, false)
+	if err == nil {
+		txBytes, err = s.cliCtx.Codec.MarshalBinaryLengthPrefixed(signedTx)
+	}
+	if err != nil {
+		if reserved {
+			s.sequences.Release(rule.address, seq)
+		}
+		return http.StatusInternalServerError, err
+	}
+
+	cliCtx := s.cliCtx.WithBroadcastMode(req.Mode)
+	res, err := s.broadcast(cliCtx, txBytes)
+	if reserved {
+		s.sequences.HandleBroadcast(rule.address, seq, res, err)
+	}
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	rule.signed = append(rule.signed, s.now())
+	record.Decision = SigningBroadcasted
+	record.TxHash = res.TxHash
+	if res.Code != 0 {
+		record.Reason = res.RawLog
+	}
+
+	rest.PostProcessResponseBare(w, cliCtx, res)
+	return http.StatusOK, nil
+}
+
+// checkPolicy returns an error and the matching HTTP status if the tx does not
+// comply with the policy.
+func (s *SigningService) checkPolicy(rule *signingRule, tx authtypes.StdTx) (int, error) {
+	if len(tx.Signatures) > 0 {
+		return http.StatusBadRequest, fmt.Errorf("tx is already signed")
+	}
+	if len(tx.GetMsgs()) == 0 {
+		return http.StatusBadRequest, fmt.Errorf("tx has no message")
+	}
+	if err := tx.ValidateBasic(); err != nil && !sdkerrors.ErrNoSignatures.Is(err) {
+		return http.StatusBadRequest, err
+	}
+
+	for _, msg := range tx.GetMsgs() {
+		if err := msg.ValidateBasic(); err != nil {
+			return http.StatusBadRequest, err
+		}
+		if !rule.msgTypes[msgType(msg)] {
+			return http.StatusForbidden, fmt.Errorf("message type %s is not allowed", msgType(msg))
+		}
+
+		// the signer is a recipient of its own account and validator
+		signers := make(map[string]bool)
+		for _, signer := range msg.GetSigners() {
+			if !signer.Equals(rule.address) {
+				return http.StatusForbidden, fmt.Errorf("message must be signed by %s only", rule.address)
+			}
+			signers[signer.String()] = true
+			signers[sdk.ValAddress(signer).String()] = true
+		}
+
+		if rule.recipients == nil {
+			continue
+		}
+
+		recipients, err := s.msgAddresses(msg)
+		if err != nil {
+			return http.StatusBadRequest, err
+		}
+		for _, recipient := range recipients {
+			if !signers[recipient] && !rule.recipients[recipient] {
+				return http.StatusForbidden, fmt.Errorf("recipient %s is not allowed", recipient)
+			}
+		}
+	}
+
+	if rule.maxFee != nil {
+		if _, exceeds := rule.maxFee.SafeSub(tx.Fee.Amount); exceeds {
+			return http.StatusForbidden, fmt.Errorf("fee %s exceeds the maximum fee %s", tx.Fee.Amount, rule.maxFee)
+		}
+	}
+
+	if rule.rateLimit > 0 {
+		since := s.now().Add(-rule.ratePeriod)
+		recent := rule.signed[:0]
+		for _, t := range rule.signed {
+			if t.After(since) {
+				recent = append(recent, t)
+			}
+		}
+		rule.signed = recent
+
+		if len(recent) >= rule.rateLimit {
+			return http.StatusTooManyRequests, fmt.Errorf("rate limit of %d txs per %s exceeded", rule.rateLimit, rule.ratePeriod)
+		}
+	}
+
+	return http.StatusOK, nil
+}
+
+// msgAddresses returns the account and validator operator addresses found in
+// the JSON encoding of a message. It fails if the message holds a bech32 string
+// which can't be classified, so that no recipient escapes the policy.
+func (s *SigningService) msgAddresses(msg sdk.Msg) ([]string, error) {
+	bz, err := s.cliCtx.Codec.MarshalJSON(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(bz, &v); err != nil {
+		return nil, err
+	}
+
+	var addrs []string
+	var walk func(v interface{}) error
+	walk = func(v interface{}) error {
+		switch v := v.(type) {
+		case string:
+			addr, ok, err := recipientAddress(v)
+			if err != nil {
+				return err
+			}
+			if ok {
+				addrs = append(addrs, addr)
+			}
+		case []interface{}:
+			for _, e := range v {
+				if err := walk(e); err != nil {
+					return err
+				}
+			}
+		case map[string]interface{}:
+			for _, e := range v {
+				if err := walk(e); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := walk(v); err != nil {
+		return nil, err
+	}
+
+	return addrs, nil
+}
+
+// recipientAddress returns the given string re-encoded if it is a non-empty
+// account or validator operator bech32 address, which may receive funds. It
+// returns false for the strings which aren't bech32 encoded, and for the
+// consensus addresses and public keys, which receive nothing. Other bech32
+// strings can't be classified and are an error.
+func recipientAddress(s string) (string, bool, error) {
+	hrp, bz, err := bech32.DecodeAndConvert(s)
+	if err != nil {
+		return "", false, nil
+	}
+
+	config := sdk.GetConfig()
+	switch hrp {
+	case config.GetBech32AccountAddrPrefix():
+		return sdk.AccAddress(bz).String(), len(bz) > 0, nil
+
+	case config.GetBech32ValidatorAddrPrefix():
+		return sdk.ValAddress(bz).String(), len(bz) > 0, nil
+
+	case config.GetBech32ConsensusAddrPrefix(), config.GetBech32AccountPubPrefix(),
+		config.GetBech32ValidatorPubPrefix(), config.GetBech32ConsensusPubPrefix():
+		return "", false, nil
+
+	default:
+		return "", false, fmt.Errorf("unknown address %s of prefix %s", s, hrp)
+	}
+}
+
+func (s *SigningService) writeAudit(record SigningAuditRecord) {
+	record.Time = s.now().UTC()
+	bz, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+
+	s.auditMtx.Lock()
+	defer s.auditMtx.Unlock()
+	_, _ = s.audit.Write(append(bz, '\n'))
+}
+
+func msgType(msg sdk.Msg) string {
+	return msg.Route() + "/" + msg.Type()
+}
+
+// RegisterSigningServiceFlags registers the flags of the REST signing service.
+func RegisterSigningServiceFlags(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().String(FlagUnlockKey, "", "Select the keys to unlock on the RPC server")
+	cmd.Flags().String(FlagUnlockKeyHome, "", "The keybase home path")
+	cmd.Flags().String(FlagSigningPolicy, "", "Enable the tx signing service with the key policies of the given JSON file")
+	cmd.Flags().String(FlagSigningAuditLog, "", "The signing service audit log file (default <keybase home>/"+defaultSigningAuditLog+")")
+	return cmd
+}
+
+// registerSigningService enables the signing service if a policy file is set.
+func (rs *RestServer) registerSigningService() error {
+	policyFile := viper.GetString(FlagSigningPolicy)
+	if policyFile == "" {
+		return nil
+	}
+
+	policies, err := LoadSigningPolicies(policyFile)
+	if err != nil {
+		return err
+	}
+
+	home := viper.GetString(FlagUnlockKeyHome)
+	if home == "" {
+		home = viper.GetString(flags.FlagHome)
+	}
+
+	kb, err := keys.NewKeyring(sdk.KeyringServiceName(), viper.GetString(flags.FlagKeyringBackend), home, os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	auditFile := viper.GetString(FlagSigningAuditLog)
+	if auditFile == "" {
+		auditFile = filepath.Join(home, defaultSigningAuditLog)
+	}
+	audit, err := os.OpenFile(auditFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, name := range strings.Split(viper.GetString(FlagUnlockKey), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	svc, err := NewSigningService(rs.CliCtx, kb, names, policies, audit)
+	if err != nil {
+		audit.Close()
+		return err
+	}
+
+	rs.KeyBase = kb
+	svc.RegisterRoutes(rs)
+	rs.log.Info("tx signing service enabled", "keys", strings.Join(names, ","), "audit", auditFile)
+	return nil
+}
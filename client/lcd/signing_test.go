@@ -0,0 +1,174 @@
+package lcd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/bech32"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/cosmos/cosmos-sdk/tests"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+func TestSigningService(t *testing.T) {
+	cdc := codec.New()
+	sdk.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	authtypes.RegisterCodec(cdc)
+	bank.RegisterCodec(cdc)
+	viper.Set(flags.FlagChainID, "test-chain")
+
+	dir, cleanup := tests.NewTestCaseDir(t)
+	defer cleanup()
+	kb, err := keys.NewKeyring(sdk.KeyringServiceName(), keys.BackendTest, dir, nil)
+	require.NoError(t, err)
+	alice, err := kb.CreateAccount("alice", tests.TestMnemonic, "", "test", keys.CreateHDPath(0, 0).String(), keys.Secp256k1)
+	require.NoError(t, err)
+	bob, err := kb.CreateAccount("bob", tests.TestMnemonic, "", "test", keys.CreateHDPath(0, 1).String(), keys.Secp256k1)
+	require.NoError(t, err)
+	carol := sdk.AccAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+
+	policies := map[string]SigningPolicy{
+		"alice": {
+			AllowedMsgTypes:   []string{"bank/send"},
+			MaxFee:            "1okt",
+			RateLimit:         1,
+			RatePeriod:        "1m",
+			AllowedRecipients: []string{bob.GetAddress().String()},
+		},
+	}
+
+	// every unlocked key needs a policy
+	_, err = NewSigningService(context.CLIContext{Codec: cdc}, kb, []string{"alice", "bob"}, policies, nil)
+	require.Error(t, err)
+
+	audit := new(bytes.Buffer)
+	svc, err := NewSigningService(context.CLIContext{Codec: cdc}, kb, []string{"alice"}, policies, audit)
+	require.NoError(t, err)
+
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+	svc.broadcast = func(_ context.CLIContext, txBytes []byte) (sdk.TxResponse, error) {
+		var tx authtypes.StdTx
+		require.NoError(t, cdc.UnmarshalBinaryLengthPrefixed(txBytes, &tx))
+		require.Len(t, tx.Signatures, 1)
+		signBytes := authtypes.StdSignBytes("test-chain", 3, 7, tx.Fee, tx.Msgs, tx.Memo)
+		require.True(t, alice.GetPubKey().VerifyBytes(signBytes, tx.Signatures[0].Signature))
+		return sdk.TxResponse{TxHash: "ABCD"}, nil
+	}
+
+	send := func(key string, to sdk.AccAddress, fee string) int {
+		fees, err := sdk.ParseCoins(fee)
+		require.NoError(t, err)
+		amount, err := sdk.ParseCoins("10okt")
+		require.NoError(t, err)
+
+		tx := authtypes.NewStdTx(
+			[]sdk.Msg{bank.NewMsgSend(alice.GetAddress(), to, amount)},
+			authtypes.NewStdFee(200000, fees), nil, "",
+		)
+		body, err := cdc.MarshalJSON(SignAndBroadcastReq{Key: key, Tx: tx, AccountNumber: 3, Sequence: 7})
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		svc.SignAndBroadcastRequest(w, httptest.NewRequest("POST", "/signing/txs", bytes.NewReader(body)))
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusForbidden, send("bob", bob.GetAddress(), "0.1okt"))
+	require.Equal(t, http.StatusForbidden, send("alice", carol, "0.1okt"))
+	require.Equal(t, http.StatusForbidden, send("alice", bob.GetAddress(), "2okt"))
+	require.Equal(t, http.StatusOK, send("alice", bob.GetAddress(), "0.1okt"))
+	require.Equal(t, http.StatusTooManyRequests, send("alice", bob.GetAddress(), "0.1okt"))
+
+	now = now.Add(time.Minute)
+	require.Equal(t, http.StatusOK, send("alice", bob.GetAddress(), "0.1okt"))
+
+	// every decision is audited
+	lines := strings.Split(strings.TrimSpace(audit.String()), "\n")
+	require.Len(t, lines, 6)
+	decisions := make([]string, len(lines))
+	for i, line := range lines {
+		var record SigningAuditRecord
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		decisions[i] = record.Decision
+	}
+	require.Equal(t, []string{
+		SigningRejected, SigningRejected, SigningRejected, SigningBroadcasted, SigningRejected, SigningBroadcasted,
+	}, decisions)
+}
+
+// recipientsMsg is a message sending to an account, a validator operator and
+// a bech32 string of any prefix.
+type recipientsMsg struct {
+	From      sdk.AccAddress `json:"from"`
+	To        sdk.AccAddress `json:"to"`
+	Validator sdk.ValAddress `json:"validator"`
+	Other     string         `json:"other"`
+}
+
+func (msg recipientsMsg) Route() string        { return "test" }
+func (msg recipientsMsg) Type() string         { return "recipients" }
+func (msg recipientsMsg) ValidateBasic() error { return nil }
+
+func (msg recipientsMsg) GetSignBytes() []byte {
+	return sdk.MustSortJSON(codec.Cdc.MustMarshalJSON(msg))
+}
+
+func (msg recipientsMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+func TestSigningServiceRecipients(t *testing.T) {
+	alice := sdk.AccAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	bob := sdk.AccAddress(bytes.Repeat([]byte{2}, sdk.AddrLen))
+	validator := sdk.ValAddress(bytes.Repeat([]byte{3}, sdk.AddrLen))
+
+	// the recipients must be account or validator operator addresses
+	_, err := newSigningRule(alice, SigningPolicy{
+		AllowedMsgTypes: []string{"test/recipients"}, AllowedRecipients: []string{sdk.ConsAddress(bob).String()},
+	})
+	require.Error(t, err)
+	rule, err := newSigningRule(alice, SigningPolicy{
+		AllowedMsgTypes: []string{"test/recipients"}, AllowedRecipients: []string{bob.String(), validator.String()},
+	})
+	require.NoError(t, err)
+
+	svc := &SigningService{cliCtx: context.CLIContext{Codec: codec.New()}, now: time.Now}
+	check := func(msg recipientsMsg) int {
+		tx := authtypes.NewStdTx([]sdk.Msg{msg}, authtypes.NewStdFee(200000, nil), nil, "")
+		status, _ := svc.checkPolicy(rule, tx)
+		return status
+	}
+
+	msg := recipientsMsg{From: alice, To: bob, Validator: validator}
+	require.Equal(t, http.StatusOK, check(msg))
+
+	// the signer may send to its own validator
+	msg.Validator = sdk.ValAddress(alice)
+	require.Equal(t, http.StatusOK, check(msg))
+
+	msg.Validator = sdk.ValAddress(bob)
+	require.Equal(t, http.StatusForbidden, check(msg))
+
+	// consensus addresses receive nothing, but unknown addresses are rejected
+	msg.Validator = validator
+	msg.Other = sdk.ConsAddress(bob).String()
+	require.Equal(t, http.StatusOK, check(msg))
+	msg.Other, err = bech32.ConvertAndEncode("unknown", bob)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, check(msg))
+}
@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/eventsink"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+)
+
+// parseEventQuery parses the query of an event search from the URL parameters
+// type, module, attribute (as key=value, repeatable), min_height, max_height,
+// cursor and limit.
+func parseEventQuery(r *http.Request) (eventsink.Query, error) {
+	params := r.URL.Query()
+	query := eventsink.Query{
+		Type:   params.Get("type"),
+		Module: params.Get("module"),
+		Cursor: params.Get("cursor"),
+	}
+
+	for _, attr := range params["attribute"] {
+		kv := strings.SplitN(attr, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return eventsink.Query{}, fmt.Errorf("invalid attribute %q, expected key=value", attr)
+		}
+		query.Attributes = append(query.Attributes, eventsink.Attribute{Key: kv[0], Value: kv[1]})
+	}
+
+	var err error
+	if s := params.Get("min_height"); s != "" {
+		if query.MinHeight, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return eventsink.Query{}, fmt.Errorf("invalid min_height %q", s)
+		}
+	}
+	if s := params.Get("max_height"); s != "" {
+		if query.MaxHeight, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return eventsink.Query{}, fmt.Errorf("invalid max_height %q", s)
+		}
+	}
+	if s := params.Get("limit"); s != "" {
+		if query.Limit, err = strconv.Atoi(s); err != nil {
+			return eventsink.Query{}, fmt.Errorf("invalid limit %q", s)
+		}
+	}
+
+	return query, query.ValidateBasic()
+}
+
+// REST handler searching the events indexed by the event sink of the node.
+// The results are paginated with the cursor returned as next.
+func EventSearchRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query, err := parseEventQuery(r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData("/app/events", codec.Cdc.MustMarshalJSON(query))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		rest.PostProcessResponseBare(w, cliCtx, res)
+	}
+}
@@ -14,4 +14,9 @@ func RegisterRPCRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/blocks/{height}", BlockRequestHandlerFn(cliCtx)).Methods("GET")
 	r.HandleFunc("/validatorsets/latest", LatestValidatorSetRequestHandlerFn(cliCtx)).Methods("GET")
 	r.HandleFunc("/validatorsets/{height}", ValidatorSetRequestHandlerFn(cliCtx)).Methods("GET")
+	r.HandleFunc("/store/metrics", StoreMetricsRequestHandlerFn(cliCtx)).Methods("GET")
+	r.HandleFunc("/store/metrics/prometheus", StoreMetricsPrometheusHandlerFn(cliCtx)).Methods("GET")
+	r.HandleFunc("/store/cache", InterBlockCacheRequestHandlerFn(cliCtx)).Methods("GET")
+	r.HandleFunc("/store/transient", TransientUsageRequestHandlerFn(cliCtx)).Methods("GET")
+	r.HandleFunc("/events", EventSearchRequestHandlerFn(cliCtx)).Methods("GET")
 }
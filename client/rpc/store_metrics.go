@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/metrics"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+)
+
+// queryStoreMetrics returns the JSON encoded store metrics of the node.
+func queryStoreMetrics(cliCtx context.CLIContext) ([]byte, error) {
+	res, _, err := cliCtx.QueryWithData("/app/store_metrics", nil)
+	return res, err
+}
+
+// REST handler for the store metrics of the node
+func StoreMetricsRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := queryStoreMetrics(cliCtx)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		rest.PostProcessResponseBare(w, cliCtx, res)
+	}
+}
+
+// REST handler for the store metrics of the node, in the Prometheus text
+// exposition format
+func StoreMetricsPrometheusHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := queryStoreMetrics(cliCtx)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		var stats metrics.Stats
+		if err := codec.Cdc.UnmarshalJSON(res, &stats); err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := metrics.WritePrometheus(&buf, stats); err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		_, _ = w.Write(buf.Bytes())
+	}
+}
+
+// REST handler for the usage of the transient stores of the node
+func TransientUsageRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData("/app/transient_usage", nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		rest.PostProcessResponseBare(w, cliCtx, res)
+	}
+}
+
+// REST handler for the stats of the inter-block cache of the node
+func InterBlockCacheRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData("/app/inter_block_cache", nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		rest.PostProcessResponseBare(w, cliCtx, res)
+	}
+}
@@ -0,0 +1,296 @@
+package keys
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/mintkey"
+)
+
+// BackupVersion is the version of the keyring backup format written by
+// ArmorBackup.
+const BackupVersion = 1
+
+// ConflictPolicy defines how RestoreBackup handles keys of a backup whose name
+// is already taken by a different key.
+type ConflictPolicy string
+
+// Conflict policies
+const (
+	// ConflictAbort fails the restore before any key is written.
+	ConflictAbort ConflictPolicy = "abort"
+	// ConflictSkip leaves the existing keys untouched.
+	ConflictSkip ConflictPolicy = "skip"
+	// ConflictOverwrite replaces the existing keys.
+	ConflictOverwrite ConflictPolicy = "overwrite"
+)
+
+// RestoreStatus reports what RestoreBackup did with a key of a backup.
+type RestoreStatus string
+
+// Restore statuses
+const (
+	RestoreAdded       RestoreStatus = "added"
+	RestoreUnchanged   RestoreStatus = "unchanged"
+	RestoreSkipped     RestoreStatus = "skipped"
+	RestoreOverwritten RestoreStatus = "overwritten"
+)
+
+// Backup is the content of a keyring backup archive. Private keys are stored
+// in the clear, the archive being encrypted as a whole by ArmorBackup.
+type Backup struct {
+	Version   uint32        `json:"version"`
+	CreatedAt time.Time     `json:"created_at"`
+	Entries   []BackupEntry `json:"entries"`
+}
+
+// BackupEntry holds a key of a backup. PrivKey is only set for local keys,
+// whose Info carries no private key material.
+type BackupEntry struct {
+	Info    Info             `json:"info"`
+	PrivKey tmcrypto.PrivKey `json:"priv_key,omitempty"`
+}
+
+// RestoreResult reports the outcome of restoring a key of a backup.
+type RestoreResult struct {
+	Info   Info          `json:"info"`
+	Status RestoreStatus `json:"status"`
+}
+
+// restoreKeybase is a walletKeybase which can delete keys.
+type restoreKeybase interface {
+	walletKeybase
+	Delete(name, passphrase string, skipPass bool) error
+}
+
+// ConflictPolicyFromString returns the ConflictPolicy of the given name.
+func ConflictPolicyFromString(str string) (ConflictPolicy, error) {
+	switch policy := ConflictPolicy(str); policy {
+	case ConflictAbort, ConflictSkip, ConflictOverwrite:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown conflict policy %q, expected one of %s, %s or %s",
+			str, ConflictAbort, ConflictSkip, ConflictOverwrite)
+	}
+}
+
+// NewBackup collects every key of the keybase into a Backup. The private keys
+// of local keys are decrypted with decryptPassphrase. Keys held by a remote
+// signer are backed up as offline keys.
+func NewBackup(kb Keybase, decryptPassphrase string) (Backup, error) {
+	infos, err := kb.List()
+	if err != nil {
+		return Backup{}, err
+	}
+
+	backup := Backup{
+		Version:   BackupVersion,
+		CreatedAt: time.Now().UTC(),
+		Entries:   make([]BackupEntry, 0, len(infos)),
+	}
+	for _, info := range infos {
+		var entry BackupEntry
+		switch i := info.(type) {
+		case localInfo:
+			priv, err := kb.ExportPrivateKeyObject(i.Name, decryptPassphrase)
+			if err != nil {
+				return Backup{}, fmt.Errorf("failed to export key %s: %v", i.Name, err)
+			}
+			i.PrivKeyArmor = ""
+			entry = BackupEntry{Info: i, PrivKey: priv}
+		case remoteInfo:
+			entry = BackupEntry{Info: newOfflineInfo(i.Name, i.PubKey, i.Algo)}
+		default:
+			entry = BackupEntry{Info: info}
+		}
+		backup.Entries = append(backup.Entries, entry)
+	}
+
+	return backup, nil
+}
+
+// ArmorBackup encodes the backup and encrypts it with the passphrase into an
+// ASCII armored archive.
+func ArmorBackup(backup Backup, passphrase string) (string, error) {
+	if err := backup.Validate(); err != nil {
+		return "", err
+	}
+
+	bz, err := CryptoCdc.MarshalJSON(backup)
+	if err != nil {
+		return "", err
+	}
+
+	return mintkey.EncryptArmorBackup(bz, passphrase, strconv.FormatUint(uint64(backup.Version), 10)), nil
+}
+
+// UnarmorBackup decrypts an archive written by ArmorBackup and verifies its
+// integrity.
+func UnarmorBackup(armorStr, passphrase string) (Backup, error) {
+	bz, version, err := mintkey.UnarmorDecryptBackup(armorStr, passphrase)
+	if err != nil {
+		return Backup{}, err
+	}
+	if version != strconv.Itoa(BackupVersion) {
+		return Backup{}, fmt.Errorf("unsupported backup version: %s", version)
+	}
+
+	var backup Backup
+	if err := CryptoCdc.UnmarshalJSON(bz, &backup); err != nil {
+		return Backup{}, fmt.Errorf("failed to decode backup: %v", err)
+	}
+	if strconv.FormatUint(uint64(backup.Version), 10) != version {
+		return Backup{}, fmt.Errorf("backup version %d does not match archive version %s", backup.Version, version)
+	}
+
+	return backup, backup.Validate()
+}
+
+// Validate checks that key names are unique and that the private key of each
+// local key matches its public key.
+func (b Backup) Validate() error {
+	if b.Version != BackupVersion {
+		return fmt.Errorf("unsupported backup version: %d", b.Version)
+	}
+
+	names := make(map[string]bool, len(b.Entries))
+	for _, entry := range b.Entries {
+		if entry.Info == nil {
+			return fmt.Errorf("backup entry without key info")
+		}
+
+		name := entry.Info.GetName()
+		if name == "" {
+			return fmt.Errorf("backup entry without key name")
+		}
+		if names[name] {
+			return fmt.Errorf("duplicate key %s in backup", name)
+		}
+		names[name] = true
+
+		switch entry.Info.GetType() {
+		case TypeLocal:
+			if entry.PrivKey == nil {
+				return fmt.Errorf("missing private key of local key %s", name)
+			}
+			if !entry.PrivKey.PubKey().Equals(entry.Info.GetPubKey()) {
+				return fmt.Errorf("private key of %s does not match its public key", name)
+			}
+		case TypeRemote:
+			return fmt.Errorf("remote key %s cannot be restored", name)
+		default:
+			if entry.PrivKey != nil {
+				return fmt.Errorf("unexpected private key for %s key %s", entry.Info.GetType(), name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// RestoreBackup imports the keys of a backup. Keys which already exist with
+// the same public key are left unchanged, whereas other name conflicts are
+// handled according to the policy. The keys of an HD wallet are handled as a
+// group: if any of them conflicts, the policy applies to the whole wallet. With
+// dryRun set, the outcome is reported but nothing is written.
+func (kb baseKeybase) RestoreBackup(
+	w restoreKeybase, backup Backup, encryptPassphrase string, policy ConflictPolicy, dryRun bool,
+) ([]RestoreResult, error) {
+
+	if err := backup.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := ConflictPolicyFromString(string(policy)); err != nil {
+		return nil, err
+	}
+
+	results := make([]RestoreResult, len(backup.Entries))
+	conflicts := make(map[string]bool)
+	for i, entry := range backup.Entries {
+		info := entry.Info
+		results[i] = RestoreResult{Info: info, Status: RestoreAdded}
+
+		existing, err := w.Get(info.GetName())
+		if err != nil {
+			continue
+		}
+		if existing.GetType() == info.GetType() &&
+			existing.GetPubKey().Equals(info.GetPubKey()) &&
+			InfoWallet(existing) == InfoWallet(info) {
+			results[i].Status = RestoreUnchanged
+			continue
+		}
+
+		if policy == ConflictAbort {
+			return nil, fmt.Errorf("cannot overwrite key: %s", info.GetName())
+		}
+		conflicts[restoreGroup(info)] = true
+	}
+
+	for i := range results {
+		if results[i].Status == RestoreUnchanged || !conflicts[restoreGroup(results[i].Info)] {
+			continue
+		}
+
+		if _, err := w.Get(results[i].Info.GetName()); err != nil && policy == ConflictOverwrite {
+			continue
+		}
+
+		if policy == ConflictSkip {
+			results[i].Status = RestoreSkipped
+		} else {
+			results[i].Status = RestoreOverwritten
+		}
+	}
+
+	if dryRun {
+		return results, nil
+	}
+
+	for i, entry := range backup.Entries {
+		switch results[i].Status {
+		case RestoreOverwritten:
+			if err := w.Delete(entry.Info.GetName(), "", true); err != nil {
+				return nil, err
+			}
+		case RestoreAdded:
+		default:
+			continue
+		}
+
+		results[i].Info = kb.writeBackupEntry(w, entry, encryptPassphrase)
+	}
+
+	return results, nil
+}
+
+// writeBackupEntry stores a key of a backup, preserving its HD wallet
+// membership.
+func (kb baseKeybase) writeBackupEntry(w keyWriter, entry BackupEntry, encryptPassphrase string) Info {
+	linfo, ok := entry.Info.(localInfo)
+	if !ok {
+		w.writeInfo(entry.Info.GetName(), entry.Info)
+		return entry.Info
+	}
+
+	stored := *(w.writeLocalKey(linfo.Name, entry.PrivKey, encryptPassphrase, linfo.Algo).(*localInfo))
+	if linfo.Wallet != "" {
+		stored.Wallet = linfo.Wallet
+		stored.Path = linfo.Path
+		w.writeInfo(stored.Name, stored)
+	}
+
+	return stored
+}
+
+// restoreGroup returns the group a key is restored with, i.e. its HD wallet
+// if it belongs to one, or the key itself.
+func restoreGroup(info Info) string {
+	if wallet := InfoWallet(info); wallet != "" {
+		return "wallet/" + wallet
+	}
+	return "key/" + info.GetName()
+}
@@ -0,0 +1,117 @@
+package keys
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/mintkey"
+	"github.com/cosmos/cosmos-sdk/tests"
+)
+
+func TestBackupRestore(t *testing.T) {
+	mintkey.BcryptSecurityParameter = 4
+
+	dir, cleanup := tests.NewTestCaseDir(t)
+	defer cleanup()
+	kb, err := NewKeyring("keybasename", "test", dir, nil)
+	require.NoError(t, err)
+
+	_, err = kb.CreateAccount("local", tests.TestMnemonic, "", "passphrase", CreateHDPathEx(118, 0, 0).String(), Secp256k1)
+	require.NoError(t, err)
+	_, err = kb.CreateOffline("offline", secp256k1.GenPrivKey().PubKey(), Secp256k1)
+	require.NoError(t, err)
+	_, err = kb.CreateWalletAccounts("hot", tests.TestMnemonic, "", "", 60, 0, []uint32{0, 1}, Secp256k1)
+	require.NoError(t, err)
+
+	backup, err := NewBackup(kb, "")
+	require.NoError(t, err)
+	require.Len(t, backup.Entries, 4)
+
+	armored, err := ArmorBackup(backup, "backup passphrase")
+	require.NoError(t, err)
+	require.False(t, strings.Contains(armored, tests.TestMnemonic))
+
+	// the archive is authenticated
+	_, err = UnarmorBackup(armored, "wrong passphrase")
+	require.Error(t, err)
+	lines := strings.Split(armored, "\n")
+	for i, line := range lines {
+		if i > 0 && len(line) == 64 {
+			lines[i] = strings.Repeat("A", 64)
+			break
+		}
+	}
+	_, err = UnarmorBackup(strings.Join(lines, "\n"), "backup passphrase")
+	require.Error(t, err)
+
+	restored, err := UnarmorBackup(armored, "backup passphrase")
+	require.NoError(t, err)
+
+	// restore into another backend
+	target := NewInMemory()
+	results, err := target.RestoreBackup(restored, "passphrase", ConflictAbort, true)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	list, err := target.List()
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	results, err = target.RestoreBackup(restored, "passphrase", ConflictAbort, false)
+	require.NoError(t, err)
+	for _, result := range results {
+		require.Equal(t, RestoreAdded, result.Status)
+	}
+
+	_, _, err = target.Sign("local", "passphrase", []byte("msg"))
+	require.NoError(t, err)
+	members, err := target.ListWallet("hot")
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	offline, err := target.Get("offline")
+	require.NoError(t, err)
+	require.Equal(t, TypeOffline, offline.GetType())
+
+	// restoring again leaves identical keys unchanged
+	results, err = target.RestoreBackup(restored, "passphrase", ConflictAbort, false)
+	require.NoError(t, err)
+	for _, result := range results {
+		require.Equal(t, RestoreUnchanged, result.Status)
+	}
+
+	// a conflicting wallet member makes the whole wallet conflict
+	require.NoError(t, target.Delete("hot-0", "", true))
+	_, err = target.CreateAccount("hot-1", tests.TestMnemonic, "", "passphrase", CreateHDPathEx(118, 0, 5).String(), Secp256k1)
+	require.NoError(t, err)
+
+	_, err = target.RestoreBackup(restored, "passphrase", ConflictAbort, false)
+	require.Error(t, err)
+
+	results, err = target.RestoreBackup(restored, "passphrase", ConflictSkip, false)
+	require.NoError(t, err)
+	statuses := make(map[string]RestoreStatus)
+	for _, result := range results {
+		statuses[result.Info.GetName()] = result.Status
+	}
+	require.Equal(t, RestoreSkipped, statuses["hot-0"])
+	require.Equal(t, RestoreSkipped, statuses["hot-1"])
+	require.Equal(t, RestoreUnchanged, statuses["local"])
+	_, err = target.Get("hot-0")
+	require.Error(t, err)
+
+	results, err = target.RestoreBackup(restored, "passphrase", ConflictOverwrite, false)
+	require.NoError(t, err)
+	for _, result := range results {
+		statuses[result.Info.GetName()] = result.Status
+	}
+	require.Equal(t, RestoreAdded, statuses["hot-0"])
+	require.Equal(t, RestoreOverwritten, statuses["hot-1"])
+	members, err = target.ListWallet("hot")
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+
+	_, err = target.RestoreBackup(restored, "passphrase", ConflictPolicy("merge"), false)
+	require.Error(t, err)
+}
@@ -25,4 +25,5 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(ledgerInfo{}, "crypto/keys/ledgerInfo", nil)
 	cdc.RegisterConcrete(offlineInfo{}, "crypto/keys/offlineInfo", nil)
 	cdc.RegisterConcrete(multiInfo{}, "crypto/keys/multiInfo", nil)
+	cdc.RegisterConcrete(remoteInfo{}, "crypto/keys/remoteInfo", nil)
 }
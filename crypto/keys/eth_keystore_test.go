@@ -0,0 +1,129 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/require"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// pbkdf2 test vector from the Web3 Secret Storage definition
+const (
+	pbkdf2KeystoreJSON = `{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"6087dab2f9fdbbfaddc31a909735c1e6"},` +
+		`"ciphertext":"5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46","kdf":"pbkdf2",` +
+		`"kdfparams":{"c":262144,"dklen":32,"prf":"hmac-sha256","salt":"ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},` +
+		`"mac":"517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`
+	pbkdf2KeystorePassphrase = "testpassword"
+	pbkdf2KeystorePrivKey    = "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d"
+)
+
+func newEthTestKeybase() Keybase {
+	keygen := func(bz []byte, algo SigningAlgo) (tmcrypto.PrivKey, error) {
+		if algo != Secp256k1 && algo != EthSecp256k1 {
+			return nil, ErrUnsupportedSigningAlgo
+		}
+		return SecpPrivKeyGen(bz), nil
+	}
+
+	return NewInMemory(WithKeygenFunc(keygen), WithSupportedAlgos([]SigningAlgo{Secp256k1, EthSecp256k1}))
+}
+
+func TestImportEthKeystore(t *testing.T) {
+	kb := newEthTestKeybase()
+
+	info, err := kb.ImportEthKeystore("pbkdf2", pbkdf2KeystoreJSON, pbkdf2KeystorePassphrase, "passphrase", EthSecp256k1)
+	require.NoError(t, err)
+	require.Equal(t, EthSecp256k1, info.GetAlgo())
+
+	priv, err := kb.ExportPrivateKeyObject("pbkdf2", "passphrase")
+	require.NoError(t, err)
+	privBz := priv.(secp256k1.PrivKeySecp256k1)
+	require.Equal(t, pbkdf2KeystorePrivKey, hex.EncodeToString(privBz[:]))
+
+	// scrypt keystore as written by geth
+	privKeyECDSA, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	key := &keystore.Key{
+		Id:         uuid.NewRandom(),
+		Address:    ethcrypto.PubkeyToAddress(privKeyECDSA.PublicKey),
+		PrivateKey: privKeyECDSA,
+	}
+	keyJSON, err := keystore.EncryptKey(key, "gethpass", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+
+	_, err = kb.ImportEthKeystore("scrypt", string(keyJSON), "wrongpass", "passphrase", Secp256k1)
+	require.Error(t, err)
+	_, err = kb.ImportEthKeystore("scrypt", string(keyJSON), "gethpass", "passphrase", Ed25519)
+	require.Equal(t, ErrUnsupportedSigningAlgo, err)
+
+	info, err = kb.ImportEthKeystore("scrypt", string(keyJSON), "gethpass", "passphrase", Secp256k1)
+	require.NoError(t, err)
+	require.Equal(t, SecpPrivKeyGen(ethcrypto.FromECDSA(privKeyECDSA)).PubKey(), info.GetPubKey())
+
+	_, err = kb.ImportEthKeystore("scrypt", string(keyJSON), "gethpass", "passphrase", Secp256k1)
+	require.Error(t, err)
+}
+
+func TestExportEthKeystore(t *testing.T) {
+	kb := newEthTestKeybase()
+
+	_, err := kb.ImportEthKeystore("pbkdf2", pbkdf2KeystoreJSON, pbkdf2KeystorePassphrase, "passphrase", EthSecp256k1)
+	require.NoError(t, err)
+
+	_, err = kb.ExportEthKeystore("pbkdf2", "wrongpass", "exportpass")
+	require.Error(t, err)
+	_, err = kb.ExportEthKeystore("unknown", "passphrase", "exportpass")
+	require.Error(t, err)
+
+	keyJSON, err := kb.ExportEthKeystore("pbkdf2", "passphrase", "exportpass")
+	require.NoError(t, err)
+
+	// the exported keystore is readable by geth
+	key, err := keystore.DecryptKey([]byte(keyJSON), "exportpass")
+	require.NoError(t, err)
+	require.Equal(t, pbkdf2KeystorePrivKey, hex.EncodeToString(ethcrypto.FromECDSA(key.PrivateKey)))
+	require.Equal(t, ethcrypto.PubkeyToAddress(key.PrivateKey.PublicKey), key.Address)
+
+	// and can be imported back
+	info, err := kb.ImportEthKeystore("roundtrip", keyJSON, "exportpass", "passphrase", EthSecp256k1)
+	require.NoError(t, err)
+	orig, err := kb.Get("pbkdf2")
+	require.NoError(t, err)
+	require.Equal(t, orig.GetPubKey(), info.GetPubKey())
+}
+
+// ethPrivKey stands for the eth_secp256k1 private keys of the app
+type ethPrivKey struct {
+	secp256k1.PrivKeySecp256k1
+}
+
+func (privKey ethPrivKey) ToECDSA() *ecdsa.PrivateKey {
+	privKeyECDSA, err := ethcrypto.ToECDSA(privKey.PrivKeySecp256k1[:])
+	if err != nil {
+		panic(err)
+	}
+	return privKeyECDSA
+}
+
+func TestEncryptEthKeystore(t *testing.T) {
+	priv := secp256k1.GenPrivKey()
+
+	for _, privKey := range []tmcrypto.PrivKey{priv, ethPrivKey{priv}} {
+		keyJSON, err := EncryptEthKeystore(privKey, "exportpass")
+		require.NoError(t, err)
+
+		key, err := keystore.DecryptKey([]byte(keyJSON), "exportpass")
+		require.NoError(t, err)
+		require.Equal(t, priv[:], ethcrypto.FromECDSA(key.PrivateKey))
+	}
+
+	_, err := EncryptEthKeystore(ed25519.GenPrivKey(), "exportpass")
+	require.Error(t, err)
+}
@@ -106,6 +106,31 @@ func (kb dbKeybase) CreateAccount(
 	return kb.base.CreateAccount(kb, name, mnemonic, bip39Passwd, encryptPasswd, hdPath, algo)
 }
 
+// CreateWalletAccounts derives the keys at the given indexes of a mnemonic,
+// persists them encrypted with the given password and records them as members
+// of the named HD wallet.
+func (kb dbKeybase) CreateWalletAccounts(
+	wallet, mnemonic, bip39Passwd, encryptPasswd string, coinType, account uint32, indexes []uint32, algo SigningAlgo,
+) ([]Info, error) {
+
+	return kb.base.CreateWalletAccounts(kb, wallet, mnemonic, bip39Passwd, encryptPasswd, coinType, account, indexes, algo)
+}
+
+// ListWallet returns the keys of the named HD wallet.
+func (kb dbKeybase) ListWallet(wallet string) ([]Info, error) {
+	wallets, err := listWallet(kb.List())
+	return wallets[wallet], err
+}
+
+// RestoreBackup imports the keys of a backup, handling name conflicts
+// according to the given policy.
+func (kb dbKeybase) RestoreBackup(
+	backup Backup, encryptPassphrase string, policy ConflictPolicy, dryRun bool,
+) ([]RestoreResult, error) {
+
+	return kb.base.RestoreBackup(kb, backup, encryptPassphrase, policy, dryRun)
+}
+
 // CreateLedger creates a new locally-stored reference to a Ledger keypair.
 // It returns the created key info and an error if the Ledger could not be queried.
 func (kb dbKeybase) CreateLedger(
@@ -325,6 +350,32 @@ func (kb dbKeybase) ImportPrivKey(name string, armor string, passphrase string)
 	return nil
 }
 
+// ImportEthKeystore imports a private key from a Web3 Secret Storage (V3) JSON
+// keystore. It returns an error if a key with the same name exists or a wrong
+// keystore passphrase is supplied.
+func (kb dbKeybase) ImportEthKeystore(
+	name, keyJSON, keystorePassphrase, encryptPassphrase string, algo SigningAlgo,
+) (Info, error) {
+
+	if _, err := kb.Get(name); err == nil {
+		return nil, errors.New("Cannot overwrite key " + name)
+	}
+
+	return kb.base.ImportEthKeystore(kb, name, keyJSON, keystorePassphrase, encryptPassphrase, algo)
+}
+
+// ExportEthKeystore returns a private key as a Web3 Secret Storage (V3) JSON
+// keystore. It returns an error if the key does not exist or a wrong decryption
+// passphrase is supplied.
+func (kb dbKeybase) ExportEthKeystore(name, decryptPassphrase, keystorePassphrase string) (string, error) {
+	priv, err := kb.ExportPrivateKeyObject(name, decryptPassphrase)
+	if err != nil {
+		return "", err
+	}
+
+	return EncryptEthKeystore(priv, keystorePassphrase)
+}
+
 func (kb dbKeybase) Import(name string, armor string) (err error) {
 	bz, err := kb.db.Get(infoKey(name))
 	if err != nil {
@@ -22,6 +22,7 @@ type (
 		deriveFunc           DeriveKeyFunc
 		supportedAlgos       []SigningAlgo
 		supportedAlgosLedger []SigningAlgo
+		remoteSignerAddr     string
 	}
 
 	// baseKeybase is an auxiliary type that groups Keybase storage agnostic features
@@ -72,6 +73,14 @@ func WithSupportedAlgosLedger(algos []SigningAlgo) KeybaseOption {
 	}
 }
 
+// WithRemoteSigner sets the address of the signer used by the remote keyring
+// backend, see NewRemoteKeybase.
+func WithRemoteSigner(addr string) KeybaseOption {
+	return func(o *kbOptions) {
+		o.remoteSignerAddr = addr
+	}
+}
+
 // newBaseKeybase generates the base keybase with defaulting to tendermint SECP256K1 key type
 func newBaseKeybase(optionsFns ...KeybaseOption) baseKeybase {
 	// Default options for keybase
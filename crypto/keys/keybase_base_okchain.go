@@ -1,13 +1,18 @@
 package keys
 
 import (
+	"crypto/ecdsa"
 	"encoding/hex"
 	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/pborman/uuid"
 	"github.com/pkg/errors"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 )
 
-
 func encode(derivedPriv [32]byte) string {
 	src := make([]byte, len(derivedPriv))
 	for idx, m := range derivedPriv {
@@ -47,7 +52,7 @@ func deriveKeyByPrivKey(privKey string, algo SigningAlgo) ([]byte, error) {
 			return nil, fmt.Errorf("invalid private key '%s', algo '%s'", privKey, algo)
 		}
 		return decodePriv[:], nil
-	case SigningAlgo("eth_secp256k1"):
+	case EthSecp256k1:
 		privKeyECDSA, err := ethcrypto.HexToECDSA(privKey)
 		if err != nil {
 			return nil, fmt.Errorf("invalid private key '%s', algo '%s', error: %s", privKey, algo, err)
@@ -58,3 +63,74 @@ func deriveKeyByPrivKey(privKey string, algo SigningAlgo) ([]byte, error) {
 		return nil, errors.Wrap(ErrUnsupportedSigningAlgo, string(algo))
 	}
 }
+
+// ImportEthKeystore decrypts a Web3 Secret Storage (V3) JSON keystore, as written
+// by geth or MetaMask, and stores the private key under the given name using the
+// given algo.
+func (kb baseKeybase) ImportEthKeystore(
+	w writeLocalKeyer, name, keyJSON, keystorePassphrase, encryptPassphrase string, algo SigningAlgo,
+) (Info, error) {
+
+	if !IsSupportedAlgorithm(kb.SupportedAlgos(), algo) {
+		return nil, ErrUnsupportedSigningAlgo
+	}
+
+	key, err := keystore.DecryptKey([]byte(keyJSON), keystorePassphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt keystore")
+	}
+
+	privKey, err := kb.options.keygenFunc(ethcrypto.FromECDSA(key.PrivateKey), algo)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.writeLocalKey(name, privKey, encryptPassphrase, algo), nil
+}
+
+// EncryptEthKeystore encrypts a secp256k1 private key into a Web3 Secret Storage
+// (V3) JSON keystore using scrypt with geth's standard parameters.
+func EncryptEthKeystore(priv tmcrypto.PrivKey, passphrase string) (string, error) {
+	privKeyECDSA, err := privKeyToECDSA(priv)
+	if err != nil {
+		return "", err
+	}
+
+	key := &keystore.Key{
+		Id:         uuid.NewRandom(),
+		Address:    ethcrypto.PubkeyToAddress(privKeyECDSA.PublicKey),
+		PrivateKey: privKeyECDSA,
+	}
+
+	keyJSON, err := keystore.EncryptKey(key, passphrase, keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return "", err
+	}
+
+	return string(keyJSON), nil
+}
+
+// ecdsaPrivKey is implemented by the eth_secp256k1 private keys created by the
+// keygenFunc of the app.
+type ecdsaPrivKey interface {
+	ToECDSA() *ecdsa.PrivateKey
+}
+
+// privKeyToECDSA converts a secp256k1 or eth_secp256k1 private key to its ECDSA
+// form.
+func privKeyToECDSA(priv tmcrypto.PrivKey) (*ecdsa.PrivateKey, error) {
+	switch priv := priv.(type) {
+	case secp256k1.PrivKeySecp256k1:
+		privKeyECDSA, err := ethcrypto.ToECDSA(priv[:])
+		if err != nil {
+			return nil, errors.Wrap(err, "not a secp256k1 private key")
+		}
+		return privKeyECDSA, nil
+
+	case ecdsaPrivKey:
+		return priv.ToECDSA(), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", priv)
+	}
+}
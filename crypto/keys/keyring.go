@@ -30,6 +30,7 @@ const (
 	BackendKWallet = "kwallet"
 	BackendPass    = "pass"
 	BackendTest    = "test"
+	BackendRemote  = "remote"
 )
 
 const (
@@ -57,7 +58,9 @@ func newKeyringKeybase(db keyring.Keyring, opts ...KeybaseOption) Keybase {
 
 // NewKeyring creates a new instance of a keyring. Keybase
 // options can be applied when generating this new Keybase.
-// Available backends are "os", "file", "test".
+// Available backends are "os", "file", "kwallet", "pass", "test" and "remote".
+// The "remote" backend forwards to the signer set with WithRemoteSigner, which
+// defaults to the keyring-remote-<appName>.sock Unix socket in rootDir.
 func NewKeyring(
 	appName, backend, rootDir string, userInput io.Reader, opts ...KeybaseOption,
 ) (Keybase, error) {
@@ -66,6 +69,12 @@ func NewKeyring(
 	var err error
 
 	switch backend {
+	case BackendRemote:
+		addr := newBaseKeybase(opts...).options.remoteSignerAddr
+		if addr == "" {
+			addr = filepath.Join(rootDir, fmt.Sprintf(remoteSignerSocketFmt, appName))
+		}
+		return NewRemoteKeybase(addr, opts...)
 	case BackendTest:
 		db, err = keyring.Open(lkbToKeyringConfig(appName, rootDir, nil, true))
 	case BackendFile:
@@ -106,6 +115,30 @@ func (kb keyringKeybase) CreateAccount(
 	return kb.base.CreateAccount(kb, name, mnemonic, bip39Passwd, encryptPasswd, hdPath, algo)
 }
 
+// CreateWalletAccounts derives the keys at the given indexes of a mnemonic,
+// persists them in the keyring and records them as members of the named HD wallet.
+func (kb keyringKeybase) CreateWalletAccounts(
+	wallet, mnemonic, bip39Passwd, encryptPasswd string, coinType, account uint32, indexes []uint32, algo SigningAlgo,
+) ([]Info, error) {
+
+	return kb.base.CreateWalletAccounts(kb, wallet, mnemonic, bip39Passwd, encryptPasswd, coinType, account, indexes, algo)
+}
+
+// ListWallet returns the keys of the named HD wallet.
+func (kb keyringKeybase) ListWallet(wallet string) ([]Info, error) {
+	wallets, err := listWallet(kb.List())
+	return wallets[wallet], err
+}
+
+// RestoreBackup imports the keys of a backup, handling name conflicts
+// according to the given policy.
+func (kb keyringKeybase) RestoreBackup(
+	backup Backup, encryptPassphrase string, policy ConflictPolicy, dryRun bool,
+) ([]RestoreResult, error) {
+
+	return kb.base.RestoreBackup(kb, backup, encryptPassphrase, policy, dryRun)
+}
+
 // CreateLedger creates a new locally-stored reference to a Ledger keypair.
 // It returns the created key info and an error if the Ledger could not be queried.
 func (kb keyringKeybase) CreateLedger(
@@ -357,6 +390,32 @@ func (kb keyringKeybase) ImportPrivKey(name, armor, passphrase string) error {
 	return nil
 }
 
+// ImportEthKeystore imports a private key from a Web3 Secret Storage (V3) JSON
+// keystore. An error is returned if a key with the same name exists or a wrong
+// keystore passphrase is supplied.
+func (kb keyringKeybase) ImportEthKeystore(
+	name, keyJSON, keystorePassphrase, _ string, algo SigningAlgo,
+) (Info, error) {
+
+	if kb.HasKey(name) {
+		return nil, fmt.Errorf("cannot overwrite key: %s", name)
+	}
+
+	// NOTE: The keyring keystore has no need for a passphrase.
+	return kb.base.ImportEthKeystore(kb, name, keyJSON, keystorePassphrase, "", algo)
+}
+
+// ExportEthKeystore returns a private key as a Web3 Secret Storage (V3) JSON
+// keystore encrypted with the given keystore passphrase.
+func (kb keyringKeybase) ExportEthKeystore(name, decryptPassphrase, keystorePassphrase string) (string, error) {
+	priv, err := kb.ExportPrivateKeyObject(name, decryptPassphrase)
+	if err != nil {
+		return "", err
+	}
+
+	return EncryptEthKeystore(priv, keystorePassphrase)
+}
+
 // HasKey returns whether the key exists in the keyring.
 func (kb keyringKeybase) HasKey(name string) bool {
 	bz, _ := kb.Get(name)
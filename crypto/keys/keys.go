@@ -13,4 +13,7 @@ const (
 	Ed25519 = SigningAlgo("ed25519")
 	// Sr25519 represents the Sr25519 signature system.
 	Sr25519 = SigningAlgo("sr25519")
+	// EthSecp256k1 uses the secp256k1 ECDSA parameters with Ethereum style
+	// (keccak256) addresses and signatures.
+	EthSecp256k1 = SigningAlgo("eth_secp256k1")
 )
@@ -210,6 +210,65 @@ func (lkb lazyKeybase) ExportPrivKey(name string, decryptPassphrase string,
 	return newDBKeybase(db, lkb.options...).ExportPrivKey(name, decryptPassphrase, encryptPassphrase)
 }
 
+func (lkb lazyKeybase) ImportEthKeystore(
+	name, keyJSON, keystorePassphrase, encryptPassphrase string, algo SigningAlgo,
+) (Info, error) {
+
+	db, err := sdk.NewLevelDB(lkb.name, lkb.dir)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	return newDBKeybase(db, lkb.options...).ImportEthKeystore(name, keyJSON, keystorePassphrase, encryptPassphrase, algo)
+}
+
+func (lkb lazyKeybase) ExportEthKeystore(name, decryptPassphrase, keystorePassphrase string) (string, error) {
+	db, err := sdk.NewLevelDB(lkb.name, lkb.dir)
+	if err != nil {
+		return "", err
+	}
+	defer db.Close()
+
+	return newDBKeybase(db, lkb.options...).ExportEthKeystore(name, decryptPassphrase, keystorePassphrase)
+}
+
+func (lkb lazyKeybase) CreateWalletAccounts(
+	wallet, mnemonic, bip39Passwd, encryptPasswd string, coinType, account uint32, indexes []uint32, algo SigningAlgo,
+) ([]Info, error) {
+
+	db, err := sdk.NewLevelDB(lkb.name, lkb.dir)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	return newDBKeybase(db, lkb.options...).CreateWalletAccounts(wallet, mnemonic, bip39Passwd, encryptPasswd, coinType, account, indexes, algo)
+}
+
+func (lkb lazyKeybase) ListWallet(wallet string) ([]Info, error) {
+	db, err := sdk.NewLevelDB(lkb.name, lkb.dir)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	return newDBKeybase(db, lkb.options...).ListWallet(wallet)
+}
+
+func (lkb lazyKeybase) RestoreBackup(
+	backup Backup, encryptPassphrase string, policy ConflictPolicy, dryRun bool,
+) ([]RestoreResult, error) {
+
+	db, err := sdk.NewLevelDB(lkb.name, lkb.dir)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	return newDBKeybase(db, lkb.options...).RestoreBackup(backup, encryptPassphrase, policy, dryRun)
+}
+
 // SupportedAlgos returns a list of supported signing algorithms.
 func (lkb lazyKeybase) SupportedAlgos() []SigningAlgo {
 	return newBaseKeybase(lkb.options...).SupportedAlgos()
@@ -20,6 +20,7 @@ const (
 	blockTypePrivKey = "TENDERMINT PRIVATE KEY"
 	blockTypeKeyInfo = "TENDERMINT KEY INFO"
 	blockTypePubKey  = "TENDERMINT PUBLIC KEY"
+	blockTypeBackup  = "TENDERMINT KEYRING BACKUP"
 
 	defaultAlgo = "secp256k1"
 
@@ -189,3 +190,55 @@ func decryptPrivKey(saltBytes []byte, encBytes []byte, passphrase string) (privK
 	privKey, err = cryptoAmino.PrivKeyFromBytes(privKeyBytes)
 	return privKey, err
 }
+
+//-----------------------------------------------------------------
+// encrypt/decrypt keyring backups with armor
+
+// EncryptArmorBackup encrypts a keyring backup with the passphrase and armors
+// it, recording the backup format version in the armor header.
+func EncryptArmorBackup(bz []byte, passphrase string, version string) string {
+	saltBytes := crypto.CRandBytes(16)
+	header := map[string]string{
+		"kdf":         "bcrypt",
+		"salt":        fmt.Sprintf("%X", saltBytes),
+		headerVersion: version,
+	}
+	encBytes := xsalsa20symmetric.EncryptSymmetric(bz, backupKey(saltBytes, passphrase))
+	return armor.EncodeArmor(blockTypeBackup, header, encBytes)
+}
+
+// UnarmorDecryptBackup returns the decrypted bytes of a keyring backup and its
+// format version. The armor checksum and the authenticated encryption ensure
+// that a backup which has been tampered with is rejected.
+func UnarmorDecryptBackup(armorStr string, passphrase string) (bz []byte, version string, err error) {
+	encBytes, header, err := unarmorBytes(armorStr, blockTypeBackup)
+	if err != nil {
+		return nil, "", err
+	}
+	if header["kdf"] != "bcrypt" {
+		return nil, "", fmt.Errorf("unrecognized KDF type: %v", header["kdf"])
+	}
+	if header[headerVersion] == "" {
+		return nil, "", fmt.Errorf("header's version field is empty")
+	}
+	saltBytes, err := hex.DecodeString(header["salt"])
+	if err != nil || len(saltBytes) == 0 {
+		return nil, "", fmt.Errorf("missing or invalid salt bytes")
+	}
+
+	bz, err = xsalsa20symmetric.DecryptSymmetric(encBytes, backupKey(saltBytes, passphrase))
+	if err != nil && err.Error() == "Ciphertext decryption failed" {
+		return nil, "", keyerror.NewErrWrongPassword()
+	} else if err != nil {
+		return nil, "", err
+	}
+	return bz, header[headerVersion], nil
+}
+
+func backupKey(saltBytes []byte, passphrase string) []byte {
+	key, err := bcrypt.GenerateFromPassword(saltBytes, []byte(passphrase), BcryptSecurityParameter)
+	if err != nil {
+		tmos.Exit("error generating bcrypt key from passphrase: " + err.Error())
+	}
+	return crypto.Sha256(key) // get 32 bytes
+}
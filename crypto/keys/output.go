@@ -16,6 +16,7 @@ type KeyOutput struct {
 	Mnemonic   string                 `json:"mnemonic,omitempty" yaml:"mnemonic"`
 	Threshold  uint                   `json:"threshold,omitempty" yaml:"threshold"`
 	PubKeys    []multisigPubKeyOutput `json:"pubkeys,omitempty" yaml:"pubkeys"`
+	Wallet     string                 `json:"wallet,omitempty" yaml:"wallet,omitempty"`
 }
 
 // NewKeyOutput creates a default KeyOutput instance without Mnemonic, Threshold and PubKeys
@@ -104,6 +105,7 @@ func Bech32KeyOutput(keyInfo Info) (KeyOutput, error) {
 		ko.PubKeys = pubKeys
 	}
 	ko.EthAddress = ethcmn.BytesToAddress(accAddr.Bytes()).String()
+	ko.Wallet = InfoWallet(keyInfo)
 
 	return ko, nil
 }
@@ -0,0 +1,365 @@
+package keys
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/keyerror"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/mintkey"
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// Remote signer protocol methods
+const (
+	RemoteMethodList         = "list"
+	RemoteMethodGet          = "get"
+	RemoteMethodGetByAddress = "get_by_address"
+	RemoteMethodSign         = "sign"
+)
+
+const (
+	remoteSignerSocketFmt      = "keyring-remote-%s.sock"
+	defaultRemoteSignerTimeout = 30 * time.Second
+	maxRemoteMessageSize       = 4 << 20
+)
+
+// RemoteSignerRequest is a request sent to a remote signer. Each request is
+// sent on its own connection as a single line of amino JSON, to which the
+// signer replies with a single line holding a RemoteSignerResponse:
+//
+//	{"method":"list"}
+//	{"method":"get","name":"validator"}
+//	{"method":"get_by_address","address":"cosmos1..."}
+//	{"method":"sign","name":"validator","msg":"<base64 bytes>"}
+type RemoteSignerRequest struct {
+	Method  string `json:"method"`
+	Name    string `json:"name,omitempty"`
+	Address string `json:"address,omitempty"`
+	Msg     []byte `json:"msg,omitempty"`
+}
+
+// RemoteKey is the public information a remote signer returns about a key.
+// The public key is amino JSON encoded, e.g.
+//
+//	{"type":"tendermint/PubKeySecp256k1","value":"<base64 bytes>"}
+type RemoteKey struct {
+	Name   string          `json:"name"`
+	PubKey tmcrypto.PubKey `json:"pubkey"`
+	Algo   SigningAlgo     `json:"algo"`
+}
+
+// RemoteSignerResponse is the reply of a remote signer. On failure only Error
+// is set. Otherwise "list" sets Keys, "get" and "get_by_address" set Key, and
+// "sign" sets Signature along with the PubKey of the signing key.
+type RemoteSignerResponse struct {
+	Keys      []RemoteKey     `json:"keys,omitempty"`
+	Key       *RemoteKey      `json:"key,omitempty"`
+	Signature []byte          `json:"signature,omitempty"`
+	PubKey    tmcrypto.PubKey `json:"pubkey,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+var _ Keybase = remoteKeybase{}
+
+// remoteKeybase implements the Keybase interface by forwarding key lookups and
+// signing to an external signer, e.g. a daemon fronting an HSM. Keys can't be
+// created, imported, exported or deleted through it.
+type remoteKeybase struct {
+	base    baseKeybase
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewRemoteKeybase creates a Keybase backed by the remote signer listening on
+// addr, which is either a Unix socket ("unix:///path/to/signer.sock" or a
+// plain path) or a TCP address ("tcp://host:port").
+func NewRemoteKeybase(addr string, opts ...KeybaseOption) (Keybase, error) {
+	network, address, err := parseRemoteSignerAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return remoteKeybase{
+		base:    newBaseKeybase(opts...),
+		network: network,
+		address: address,
+		timeout: defaultRemoteSignerTimeout,
+	}, nil
+}
+
+func parseRemoteSignerAddr(addr string) (network, address string, err error) {
+	switch {
+	case strings.HasPrefix(addr, "unix://"):
+		network, address = "unix", strings.TrimPrefix(addr, "unix://")
+	case strings.HasPrefix(addr, "tcp://"):
+		network, address = "tcp", strings.TrimPrefix(addr, "tcp://")
+	case strings.Contains(addr, "://"):
+		return "", "", fmt.Errorf("unsupported remote signer address %q, expected unix:// or tcp://", addr)
+	default:
+		network, address = "unix", addr
+	}
+
+	if address == "" {
+		return "", "", fmt.Errorf("empty remote signer address")
+	}
+
+	return network, address, nil
+}
+
+// List returns the keys held by the remote signer.
+func (kb remoteKeybase) List() ([]Info, error) {
+	res, err := kb.call(RemoteSignerRequest{Method: RemoteMethodList})
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]Info, 0, len(res.Keys))
+	for _, key := range res.Keys {
+		if key.PubKey == nil {
+			return nil, fmt.Errorf("remote signer returned key %s without public key", key.Name)
+		}
+		infos = append(infos, newRemoteInfo(key.Name, key.PubKey, key.Algo))
+	}
+
+	return infos, nil
+}
+
+// Get returns the public information about the named key of the remote signer.
+func (kb remoteKeybase) Get(name string) (Info, error) {
+	res, err := kb.call(RemoteSignerRequest{Method: RemoteMethodGet, Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Key == nil || res.Key.PubKey == nil {
+		return nil, keyerror.NewErrKeyNotFound(name)
+	}
+
+	return newRemoteInfo(res.Key.Name, res.Key.PubKey, res.Key.Algo), nil
+}
+
+// GetByAddress returns the public information about the key of the remote
+// signer with the given address.
+func (kb remoteKeybase) GetByAddress(address types.AccAddress) (Info, error) {
+	res, err := kb.call(RemoteSignerRequest{Method: RemoteMethodGetByAddress, Address: address.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Key == nil || res.Key.PubKey == nil || !types.AccAddress(res.Key.PubKey.Address()).Equals(address) {
+		return nil, fmt.Errorf("key with address %s not found", address)
+	}
+
+	return newRemoteInfo(res.Key.Name, res.Key.PubKey, res.Key.Algo), nil
+}
+
+// Sign has the remote signer sign msg with the named key. The passphrase is
+// ignored, authorization is up to the signer. The returned signature is
+// verified against the public key of the key.
+func (kb remoteKeybase) Sign(name, _ string, msg []byte) ([]byte, tmcrypto.PubKey, error) {
+	info, err := kb.Get(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := kb.call(RemoteSignerRequest{Method: RemoteMethodSign, Name: name, Msg: msg})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if res.PubKey == nil || !res.PubKey.Equals(info.GetPubKey()) {
+		return nil, nil, fmt.Errorf("remote signer signed with a key other than %s", name)
+	}
+	if !res.PubKey.VerifyBytes(msg, res.Signature) {
+		return nil, nil, fmt.Errorf("remote signer returned an invalid signature for %s", name)
+	}
+
+	return res.Signature, res.PubKey, nil
+}
+
+// call sends the request to the remote signer and waits for its response.
+func (kb remoteKeybase) call(req RemoteSignerRequest) (res RemoteSignerResponse, err error) {
+	conn, err := net.DialTimeout(kb.network, kb.address, kb.timeout)
+	if err != nil {
+		return res, errors.Wrap(err, "failed to connect to remote signer")
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(kb.timeout)); err != nil {
+		return res, err
+	}
+
+	if err := writeRemoteMessage(conn, req); err != nil {
+		return res, errors.Wrap(err, "failed to send request to remote signer")
+	}
+
+	if err := readRemoteMessage(bufio.NewReader(conn), &res); err != nil {
+		return res, errors.Wrap(err, "failed to read response of remote signer")
+	}
+
+	if res.Error != "" {
+		return res, fmt.Errorf("remote signer: %s", res.Error)
+	}
+
+	return res, nil
+}
+
+func writeRemoteMessage(conn net.Conn, msg interface{}) error {
+	bz, err := CryptoCdc.MarshalJSON(msg)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Write(append(bz, '\n'))
+	return err
+}
+
+func readRemoteMessage(r *bufio.Reader, ptr interface{}) error {
+	var line []byte
+	for {
+		chunk, isPrefix, err := r.ReadLine()
+		if err != nil {
+			return err
+		}
+
+		line = append(line, chunk...)
+		if len(line) > maxRemoteMessageSize {
+			return fmt.Errorf("message exceeds %d bytes", maxRemoteMessageSize)
+		}
+		if !isPrefix {
+			break
+		}
+	}
+
+	return CryptoCdc.UnmarshalJSON(line, ptr)
+}
+
+var errRemoteNotSupported = errors.New("operation not supported by remote signer keybase")
+
+// Delete implements Keybase, keys are managed by the remote signer.
+func (kb remoteKeybase) Delete(string, string, bool) error {
+	return errRemoteNotSupported
+}
+
+// CreateMnemonic implements Keybase, keys are managed by the remote signer.
+func (kb remoteKeybase) CreateMnemonic(string, Language, string, SigningAlgo, string) (Info, string, error) {
+	return nil, "", errRemoteNotSupported
+}
+
+// CreateAccount implements Keybase, keys are managed by the remote signer.
+func (kb remoteKeybase) CreateAccount(string, string, string, string, string, SigningAlgo) (Info, error) {
+	return nil, errRemoteNotSupported
+}
+
+// CreateLedger implements Keybase, keys are managed by the remote signer.
+func (kb remoteKeybase) CreateLedger(string, SigningAlgo, string, uint32, uint32) (Info, error) {
+	return nil, errRemoteNotSupported
+}
+
+// CreateOffline implements Keybase, keys are managed by the remote signer.
+func (kb remoteKeybase) CreateOffline(string, tmcrypto.PubKey, SigningAlgo) (Info, error) {
+	return nil, errRemoteNotSupported
+}
+
+// CreateMulti implements Keybase, keys are managed by the remote signer.
+func (kb remoteKeybase) CreateMulti(string, tmcrypto.PubKey) (Info, error) {
+	return nil, errRemoteNotSupported
+}
+
+// Update implements Keybase, keys are managed by the remote signer.
+func (kb remoteKeybase) Update(string, string, func() (string, error)) error {
+	return errRemoteNotSupported
+}
+
+// Import implements Keybase, keys are managed by the remote signer.
+func (kb remoteKeybase) Import(string, string) error {
+	return errRemoteNotSupported
+}
+
+// ImportPrivKey implements Keybase, keys are managed by the remote signer.
+func (kb remoteKeybase) ImportPrivKey(string, string, string) error {
+	return errRemoteNotSupported
+}
+
+// CreateWalletAccounts implements Keybase, keys are managed by the remote signer.
+func (kb remoteKeybase) CreateWalletAccounts(string, string, string, string, uint32, uint32, []uint32, SigningAlgo) ([]Info, error) {
+	return nil, errRemoteNotSupported
+}
+
+// ListWallet implements Keybase, keys of the remote signer are not grouped in wallets.
+func (kb remoteKeybase) ListWallet(string) ([]Info, error) {
+	return nil, nil
+}
+
+// RestoreBackup implements Keybase, keys are managed by the remote signer.
+func (kb remoteKeybase) RestoreBackup(Backup, string, ConflictPolicy, bool) ([]RestoreResult, error) {
+	return nil, errRemoteNotSupported
+}
+
+// ImportEthKeystore implements Keybase, keys are managed by the remote signer.
+func (kb remoteKeybase) ImportEthKeystore(string, string, string, string, SigningAlgo) (Info, error) {
+	return nil, errRemoteNotSupported
+}
+
+// ExportEthKeystore implements Keybase, private keys never leave the remote signer.
+func (kb remoteKeybase) ExportEthKeystore(string, string, string) (string, error) {
+	return "", errRemoteNotSupported
+}
+
+// ImportPubKey implements Keybase, keys are managed by the remote signer.
+func (kb remoteKeybase) ImportPubKey(string, string) error {
+	return errRemoteNotSupported
+}
+
+// Export implements Keybase, returning the armored public information of the
+// remote key.
+func (kb remoteKeybase) Export(name string) (string, error) {
+	info, err := kb.Get(name)
+	if err != nil {
+		return "", err
+	}
+
+	return mintkey.ArmorInfoBytes(marshalInfo(info)), nil
+}
+
+// ExportPubKey implements Keybase, returning the armored public key of the
+// remote key.
+func (kb remoteKeybase) ExportPubKey(name string) (string, error) {
+	info, err := kb.Get(name)
+	if err != nil {
+		return "", err
+	}
+
+	return mintkey.ArmorPubKeyBytes(info.GetPubKey().Bytes(), string(info.GetAlgo())), nil
+}
+
+// ExportPrivKey implements Keybase, private keys never leave the remote signer.
+func (kb remoteKeybase) ExportPrivKey(string, string, string) (string, error) {
+	return "", errRemoteNotSupported
+}
+
+// ExportPrivateKeyObject implements Keybase, private keys never leave the
+// remote signer.
+func (kb remoteKeybase) ExportPrivateKeyObject(string, string) (tmcrypto.PrivKey, error) {
+	return nil, errRemoteNotSupported
+}
+
+// SupportedAlgos returns a list of supported signing algorithms.
+func (kb remoteKeybase) SupportedAlgos() []SigningAlgo {
+	return kb.base.SupportedAlgos()
+}
+
+// SupportedAlgosLedger returns a list of supported ledger signing algorithms.
+func (kb remoteKeybase) SupportedAlgosLedger() []SigningAlgo {
+	return kb.base.SupportedAlgosLedger()
+}
+
+// CloseDB implements Keybase, connections are closed after each request.
+func (kb remoteKeybase) CloseDB() {}
@@ -0,0 +1,108 @@
+package keys
+
+import (
+	"bufio"
+	"net"
+
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// RemoteSignerServer implements the remote signer protocol on top of a local
+// Keybase. It is meant as an in-process stand-in for an external signer, e.g.
+// in tests, and signs with all keys of the Keybase using a single passphrase.
+type RemoteSignerServer struct {
+	kb         Keybase
+	passphrase string
+}
+
+// NewRemoteSignerServer creates a RemoteSignerServer serving the keys of kb.
+func NewRemoteSignerServer(kb Keybase, passphrase string) *RemoteSignerServer {
+	return &RemoteSignerServer{
+		kb:         kb,
+		passphrase: passphrase,
+	}
+}
+
+// Serve accepts connections on the listener and serves one request per
+// connection. It returns once the listener is closed.
+func (s *RemoteSignerServer) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+
+		go s.serveConn(conn)
+	}
+}
+
+func (s *RemoteSignerServer) serveConn(conn net.Conn) {
+	defer conn.Close()
+
+	var req RemoteSignerRequest
+	if err := readRemoteMessage(bufio.NewReader(conn), &req); err != nil {
+		_ = writeRemoteMessage(conn, RemoteSignerResponse{Error: err.Error()})
+		return
+	}
+
+	_ = writeRemoteMessage(conn, s.Handle(req))
+}
+
+// Handle processes a single remote signer request.
+func (s *RemoteSignerServer) Handle(req RemoteSignerRequest) (res RemoteSignerResponse) {
+	switch req.Method {
+	case RemoteMethodList:
+		infos, err := s.kb.List()
+		if err != nil {
+			return RemoteSignerResponse{Error: err.Error()}
+		}
+
+		for _, info := range infos {
+			res.Keys = append(res.Keys, remoteKeyFromInfo(info))
+		}
+
+	case RemoteMethodGet:
+		info, err := s.kb.Get(req.Name)
+		if err != nil {
+			return RemoteSignerResponse{Error: err.Error()}
+		}
+
+		key := remoteKeyFromInfo(info)
+		res.Key = &key
+
+	case RemoteMethodGetByAddress:
+		addr, err := types.AccAddressFromBech32(req.Address)
+		if err != nil {
+			return RemoteSignerResponse{Error: err.Error()}
+		}
+
+		info, err := s.kb.GetByAddress(addr)
+		if err != nil {
+			return RemoteSignerResponse{Error: err.Error()}
+		}
+
+		key := remoteKeyFromInfo(info)
+		res.Key = &key
+
+	case RemoteMethodSign:
+		sig, pub, err := s.kb.Sign(req.Name, s.passphrase, req.Msg)
+		if err != nil {
+			return RemoteSignerResponse{Error: err.Error()}
+		}
+
+		res.Signature, res.PubKey = sig, pub
+
+	default:
+		return RemoteSignerResponse{Error: "unknown method: " + req.Method}
+	}
+
+	return res
+}
+
+func remoteKeyFromInfo(info Info) RemoteKey {
+	return RemoteKey{
+		Name:   info.GetName(),
+		PubKey: info.GetPubKey(),
+		Algo:   info.GetAlgo(),
+	}
+}
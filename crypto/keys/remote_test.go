@@ -0,0 +1,157 @@
+package keys
+
+import (
+	"bufio"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/cosmos/cosmos-sdk/tests"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// startRemoteSigner serves the given keybase on a Unix socket in dir and
+// returns a remote keybase connected to it.
+func startRemoteSigner(t *testing.T, dir string, local Keybase) (Keybase, func()) {
+	l, err := net.Listen("unix", filepath.Join(dir, "keyring-remote-keybasename.sock"))
+	require.NoError(t, err)
+	go NewRemoteSignerServer(local, "passphrase").Serve(l) // nolint: errcheck
+
+	kb, err := NewKeyring("keybasename", BackendRemote, dir, nil)
+	require.NoError(t, err)
+
+	return kb, func() { l.Close() }
+}
+
+func TestRemoteKeybase(t *testing.T) {
+	dir, cleanup := tests.NewTestCaseDir(t)
+	defer cleanup()
+
+	local := NewInMemory()
+	i1, _, err := local.CreateMnemonic("validator", English, "passphrase", Secp256k1, "")
+	require.NoError(t, err)
+	_, _, err = local.CreateMnemonic("operator", English, "passphrase", Secp256k1, "")
+	require.NoError(t, err)
+
+	kb, stop := startRemoteSigner(t, dir, local)
+	defer stop()
+
+	infos, err := kb.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	for _, info := range infos {
+		require.Equal(t, TypeRemote, info.GetType())
+		require.Equal(t, "remote", info.GetType().String())
+	}
+
+	info, err := kb.Get("validator")
+	require.NoError(t, err)
+	require.Equal(t, i1.GetPubKey(), info.GetPubKey())
+	require.Equal(t, i1.GetAddress(), info.GetAddress())
+	require.Equal(t, Secp256k1, info.GetAlgo())
+
+	_, err = kb.Get("unknown")
+	require.Error(t, err)
+
+	info, err = kb.GetByAddress(i1.GetAddress())
+	require.NoError(t, err)
+	require.Equal(t, "validator", info.GetName())
+
+	_, err = kb.GetByAddress(sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()))
+	require.Error(t, err)
+
+	msg := []byte("sign me")
+	sig, pub, err := kb.Sign("validator", "", msg)
+	require.NoError(t, err)
+	require.Equal(t, i1.GetPubKey(), pub)
+	require.True(t, pub.VerifyBytes(msg, sig))
+
+	_, _, err = kb.Sign("unknown", "", msg)
+	require.Error(t, err)
+
+	// remote infos survive an amino round trip
+	restored, err := unmarshalInfo(marshalInfo(info))
+	require.NoError(t, err)
+	require.Equal(t, info.GetPubKey(), restored.GetPubKey())
+	require.Equal(t, TypeRemote, restored.GetType())
+
+	// keys are managed by the remote signer
+	require.Error(t, kb.Delete("validator", "", true))
+	_, _, err = kb.CreateMnemonic("new", English, "passphrase", Secp256k1, "")
+	require.Error(t, err)
+	_, err = kb.ExportPrivKey("validator", "", "")
+	require.Error(t, err)
+
+	armor, err := kb.ExportPubKey("validator")
+	require.NoError(t, err)
+	require.NoError(t, local.ImportPubKey("imported", armor))
+}
+
+func TestRemoteKeybaseRejectsInvalidSignature(t *testing.T) {
+	local := NewInMemory()
+	_, _, err := local.CreateMnemonic("validator", English, "passphrase", Secp256k1, "")
+	require.NoError(t, err)
+	server := NewRemoteSignerServer(local, "passphrase")
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	// the signer tampers with the signature
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			var req RemoteSignerRequest
+			require.NoError(t, readRemoteMessage(bufio.NewReader(conn), &req))
+			res := server.Handle(req)
+			if len(res.Signature) > 0 {
+				res.Signature[0] ^= 0xff
+			}
+			require.NoError(t, writeRemoteMessage(conn, res))
+			conn.Close()
+		}
+	}()
+
+	kb, err := NewRemoteKeybase("tcp://" + l.Addr().String())
+	require.NoError(t, err)
+
+	_, err = kb.Get("validator")
+	require.NoError(t, err)
+	_, _, err = kb.Sign("validator", "", []byte("sign me"))
+	require.Error(t, err)
+}
+
+func TestParseRemoteSignerAddr(t *testing.T) {
+	cases := []struct {
+		addr    string
+		network string
+		address string
+		expErr  bool
+	}{
+		{"unix:///tmp/signer.sock", "unix", "/tmp/signer.sock", false},
+		{"/tmp/signer.sock", "unix", "/tmp/signer.sock", false},
+		{"tcp://127.0.0.1:26659", "tcp", "127.0.0.1:26659", false},
+		{"http://127.0.0.1:26659", "", "", true},
+		{"tcp://", "", "", true},
+		{"", "", "", true},
+	}
+
+	for _, tc := range cases {
+		network, address, err := parseRemoteSignerAddr(tc.addr)
+		if tc.expErr {
+			require.Error(t, err, tc.addr)
+			continue
+		}
+
+		require.NoError(t, err, tc.addr)
+		require.Equal(t, tc.network, network)
+		require.Equal(t, tc.address, address)
+	}
+}
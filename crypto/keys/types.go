@@ -72,6 +72,29 @@ type Keybase interface {
 	// It returns an error if the key does not exist or a wrong encryption passphrase is supplied.
 	ExportPrivKey(name, decryptPassphrase, encryptPassphrase string) (armor string, err error)
 
+	// ImportEthKeystore imports a private key from a Web3 Secret Storage (V3) JSON
+	// keystore, encrypted with either scrypt or pbkdf2, and stores it under the given name.
+	ImportEthKeystore(name, keyJSON, keystorePassphrase, encryptPassphrase string, algo SigningAlgo) (Info, error)
+
+	// ExportEthKeystore returns a private key as a Web3 Secret Storage (V3) JSON
+	// keystore encrypted with the given keystore passphrase.
+	ExportEthKeystore(name, decryptPassphrase, keystorePassphrase string) (keyJSON string, err error)
+
+	// CreateWalletAccounts derives the keys at the given address indexes of a
+	// mnemonic and stores them as members of the named HD wallet. Keys are named
+	// after the wallet, see WalletKeyName. Adding keys to an existing wallet
+	// requires the mnemonic the wallet was created from.
+	CreateWalletAccounts(wallet, mnemonic, bip39Passwd, encryptPasswd string, coinType, account uint32, indexes []uint32, algo SigningAlgo) ([]Info, error)
+
+	// ListWallet returns the keys of the named HD wallet, ordered by derivation path.
+	ListWallet(wallet string) ([]Info, error)
+
+	// RestoreBackup imports the keys of a backup, see NewBackup. Keys whose name is
+	// taken by a different key are handled according to the conflict policy, the
+	// keys of an HD wallet being handled as a group. With dryRun set, nothing is
+	// written and the returned results describe what the restore would do.
+	RestoreBackup(backup Backup, encryptPassphrase string, policy ConflictPolicy, dryRun bool) ([]RestoreResult, error)
+
 	// ExportPrivateKeyObject *only* works on locally-stored keys. Temporary method until we redo the exporting API
 	ExportPrivateKeyObject(name string, passphrase string) (crypto.PrivKey, error)
 
@@ -94,6 +117,7 @@ const (
 	TypeLedger  KeyType = 1
 	TypeOffline KeyType = 2
 	TypeMulti   KeyType = 3
+	TypeRemote  KeyType = 4
 )
 
 var keyTypes = map[KeyType]string{
@@ -101,6 +125,7 @@ var keyTypes = map[KeyType]string{
 	TypeLedger:  "ledger",
 	TypeOffline: "offline",
 	TypeMulti:   "multi",
+	TypeRemote:  "remote",
 }
 
 // String implements the stringer interface for KeyType.
@@ -129,6 +154,7 @@ var (
 	_ Info = &ledgerInfo{}
 	_ Info = &offlineInfo{}
 	_ Info = &multiInfo{}
+	_ Info = &remoteInfo{}
 )
 
 // localInfo is the public information about a locally stored key
@@ -138,6 +164,10 @@ type localInfo struct {
 	PubKey       crypto.PubKey `json:"pubkey"`
 	PrivKeyArmor string        `json:"privkey.armor"`
 	Algo         SigningAlgo   `json:"algo"`
+	// Wallet and Path are only set for keys derived as part of an HD wallet,
+	// see CreateWalletAccounts.
+	Wallet string          `json:"wallet,omitempty"`
+	Path   *hd.BIP44Params `json:"path,omitempty"`
 }
 
 func newLocalInfo(name string, pub crypto.PubKey, privArmor string, algo SigningAlgo) Info {
@@ -176,6 +206,9 @@ func (i localInfo) GetAlgo() SigningAlgo {
 
 // GetType implements Info interface
 func (i localInfo) GetPath() (*hd.BIP44Params, error) {
+	if i.Path != nil {
+		return i.Path, nil
+	}
 	return nil, fmt.Errorf("BIP44 Paths are not available for this type")
 }
 
@@ -335,6 +368,52 @@ func (i multiInfo) GetPath() (*hd.BIP44Params, error) {
 	return nil, fmt.Errorf("BIP44 Paths are not available for this type")
 }
 
+// remoteInfo is the public information about a key held by a remote signer
+// Note: Algo must be last field in struct for backwards amino compatibility
+type remoteInfo struct {
+	Name   string        `json:"name"`
+	PubKey crypto.PubKey `json:"pubkey"`
+	Algo   SigningAlgo   `json:"algo"`
+}
+
+func newRemoteInfo(name string, pub crypto.PubKey, algo SigningAlgo) Info {
+	return &remoteInfo{
+		Name:   name,
+		PubKey: pub,
+		Algo:   algo,
+	}
+}
+
+// GetType implements Info interface
+func (i remoteInfo) GetType() KeyType {
+	return TypeRemote
+}
+
+// GetName implements Info interface
+func (i remoteInfo) GetName() string {
+	return i.Name
+}
+
+// GetPubKey implements Info interface
+func (i remoteInfo) GetPubKey() crypto.PubKey {
+	return i.PubKey
+}
+
+// GetAddress implements Info interface
+func (i remoteInfo) GetAddress() types.AccAddress {
+	return i.PubKey.Address().Bytes()
+}
+
+// GetAlgo implements Info interface
+func (i remoteInfo) GetAlgo() SigningAlgo {
+	return i.Algo
+}
+
+// GetPath implements Info interface
+func (i remoteInfo) GetPath() (*hd.BIP44Params, error) {
+	return nil, fmt.Errorf("BIP44 Paths are not available for this type")
+}
+
 // encoding info
 func marshalInfo(i Info) []byte {
 	return CryptoCdc.MustMarshalBinaryLengthPrefixed(i)
@@ -0,0 +1,153 @@
+package keys
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
+)
+
+// walletKeybase is a keyWriter which can look up existing keys.
+type walletKeybase interface {
+	keyWriter
+	Get(name string) (Info, error)
+	List() ([]Info, error)
+}
+
+// WalletKeyName returns the name a key of an HD wallet is stored under, i.e.
+// <wallet>-<index>, or <wallet>-<account>-<index> for accounts other than 0.
+func WalletKeyName(wallet string, account, index uint32) string {
+	if account == 0 {
+		return fmt.Sprintf("%s-%d", wallet, index)
+	}
+	return fmt.Sprintf("%s-%d-%d", wallet, account, index)
+}
+
+// InfoWallet returns the name of the HD wallet a key belongs to, or an empty
+// string if it was not derived as part of a wallet.
+func InfoWallet(info Info) string {
+	switch i := info.(type) {
+	case localInfo:
+		return i.Wallet
+	case *localInfo:
+		return i.Wallet
+	default:
+		return ""
+	}
+}
+
+// CreateWalletAccounts derives the keys at the given indexes of a mnemonic and
+// stores them as members of the wallet.
+func (kb baseKeybase) CreateWalletAccounts(
+	w walletKeybase, wallet, mnemonic, bip39Passphrase, encryptPasswd string,
+	coinType, account uint32, indexes []uint32, algo SigningAlgo,
+) ([]Info, error) {
+
+	if wallet == "" {
+		return nil, fmt.Errorf("wallet name required")
+	}
+	if !IsSupportedAlgorithm(kb.SupportedAlgos(), algo) {
+		return nil, ErrUnsupportedSigningAlgo
+	}
+
+	members, err := listWallet(w.List())
+	if err != nil {
+		return nil, err
+	}
+	if err := kb.checkWalletSeed(members[wallet], mnemonic, bip39Passphrase); err != nil {
+		return nil, err
+	}
+
+	infos := make([]Info, 0, len(indexes))
+	for _, index := range indexes {
+		path := hd.NewFundraiserParams(account, coinType, index)
+		name := WalletKeyName(wallet, account, index)
+
+		derivedPriv, err := kb.options.deriveFunc(mnemonic, bip39Passphrase, path.String(), algo)
+		if err != nil {
+			return nil, err
+		}
+
+		privKey, err := kb.options.keygenFunc(derivedPriv, algo)
+		if err != nil {
+			return nil, err
+		}
+
+		if existing, err := w.Get(name); err == nil {
+			// re-deriving a key of the wallet is a no-op
+			if InfoWallet(existing) == wallet && existing.GetPubKey().Equals(privKey.PubKey()) {
+				infos = append(infos, existing)
+				continue
+			}
+			return nil, fmt.Errorf("cannot overwrite key: %s", name)
+		}
+
+		info := w.writeLocalKey(name, privKey, encryptPasswd, algo)
+		linfo := *(info.(*localInfo))
+		linfo.Wallet = wallet
+		linfo.Path = path
+		w.writeInfo(name, linfo)
+
+		infos = append(infos, linfo)
+	}
+
+	return infos, nil
+}
+
+// checkWalletSeed returns an error if the mnemonic does not derive the
+// existing members of a wallet.
+func (kb baseKeybase) checkWalletSeed(members []Info, mnemonic, bip39Passphrase string) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	info := members[0]
+	path, err := info.GetPath()
+	if err != nil {
+		return err
+	}
+
+	derivedPriv, err := kb.options.deriveFunc(mnemonic, bip39Passphrase, path.String(), info.GetAlgo())
+	if err != nil {
+		return err
+	}
+
+	privKey, err := kb.options.keygenFunc(derivedPriv, info.GetAlgo())
+	if err != nil {
+		return err
+	}
+
+	if !privKey.PubKey().Equals(info.GetPubKey()) {
+		return fmt.Errorf("mnemonic does not match the existing keys of wallet %s", InfoWallet(info))
+	}
+
+	return nil
+}
+
+// listWallet groups keys by wallet, each wallet's keys being ordered by
+// derivation path.
+func listWallet(infos []Info, err error) (map[string][]Info, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	wallets := make(map[string][]Info)
+	for _, info := range infos {
+		if wallet := InfoWallet(info); wallet != "" {
+			wallets[wallet] = append(wallets[wallet], info)
+		}
+	}
+
+	for _, members := range wallets {
+		sort.Slice(members, func(i, j int) bool {
+			pi, _ := members[i].GetPath()
+			pj, _ := members[j].GetPath()
+			if pi.Account != pj.Account {
+				return pi.Account < pj.Account
+			}
+			return pi.AddressIndex < pj.AddressIndex
+		})
+	}
+
+	return wallets, nil
+}
@@ -0,0 +1,72 @@
+package keys
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/tests"
+)
+
+func TestCreateWalletAccounts(t *testing.T) {
+	kb := NewInMemory()
+
+	infos, err := kb.CreateWalletAccounts("hot", tests.TestMnemonic, "", "passphrase", 60, 0, []uint32{2, 0, 1}, Secp256k1)
+	require.NoError(t, err)
+	require.Len(t, infos, 3)
+	require.Equal(t, "hot-2", infos[0].GetName())
+
+	// keys match the ones derived one by one
+	single, err := NewInMemory().CreateAccount("single", tests.TestMnemonic, "", "passphrase", CreateHDPathEx(60, 0, 2).String(), Secp256k1)
+	require.NoError(t, err)
+	require.Equal(t, single.GetPubKey(), infos[0].GetPubKey())
+
+	info, err := kb.Get("hot-1")
+	require.NoError(t, err)
+	require.Equal(t, "hot", InfoWallet(info))
+	path, err := info.GetPath()
+	require.NoError(t, err)
+	require.Equal(t, CreateHDPathEx(60, 0, 1), path)
+
+	// other accounts get their own names
+	infos, err = kb.CreateWalletAccounts("hot", tests.TestMnemonic, "", "passphrase", 60, 1, []uint32{0}, Secp256k1)
+	require.NoError(t, err)
+	require.Equal(t, "hot-1-0", infos[0].GetName())
+
+	// members are listed by path
+	members, err := kb.ListWallet("hot")
+	require.NoError(t, err)
+	names := make([]string, len(members))
+	for i, member := range members {
+		names[i] = member.GetName()
+	}
+	require.Equal(t, []string{"hot-0", "hot-1", "hot-2", "hot-1-0"}, names)
+
+	// re-deriving existing keys is a no-op
+	_, err = kb.CreateWalletAccounts("hot", tests.TestMnemonic, "", "passphrase", 60, 0, []uint32{2, 3}, Secp256k1)
+	require.NoError(t, err)
+	members, err = kb.ListWallet("hot")
+	require.NoError(t, err)
+	require.Len(t, members, 5)
+
+	// a wallet only holds keys of one seed
+	_, err = kb.CreateWalletAccounts("hot", tests.TestMnemonic, "other passphrase", "passphrase", 60, 0, []uint32{4}, Secp256k1)
+	require.Error(t, err)
+
+	// and keys outside of wallets are never overwritten
+	_, err = kb.CreateAccount("cold-0", tests.TestMnemonic, "", "passphrase", CreateHDPathEx(60, 0, 0).String(), Secp256k1)
+	require.NoError(t, err)
+	_, err = kb.CreateWalletAccounts("cold", tests.TestMnemonic, "", "passphrase", 60, 0, []uint32{0}, Secp256k1)
+	require.Error(t, err)
+
+	members, err = kb.ListWallet("unknown")
+	require.NoError(t, err)
+	require.Empty(t, members)
+
+	_, err = kb.CreateWalletAccounts("", tests.TestMnemonic, "", "passphrase", 60, 0, []uint32{0}, Secp256k1)
+	require.Error(t, err)
+
+	// wallet keys can sign
+	_, _, err = kb.Sign("hot-2", "passphrase", []byte("msg"))
+	require.NoError(t, err)
+}
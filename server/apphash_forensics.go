@@ -0,0 +1,553 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	flagReference          = "reference"
+	flagReferenceWriteSets = "reference-write-sets"
+	flagWriteSets          = "write-sets"
+)
+
+// storeHash is the commit hash of a store, hex encoded.
+type storeHash struct {
+	Name string `json:"name"`
+	Hash string `json:"hash"`
+}
+
+// heightCommitInfo are the hashes committed at a height, as printed by
+// CommitInfoCmd.
+type heightCommitInfo struct {
+	Height  int64       `json:"height"`
+	AppHash string      `json:"app_hash"`
+	Stores  []storeHash `json:"stores"`
+}
+
+// storeWrite is the last write to a key in a step of a block, hex encoded.
+type storeWrite struct {
+	Store  string `json:"store"`
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Delete bool   `json:"delete,omitempty"`
+}
+
+// writeSet holds the writes of a step of a block: its begin blocker, one of its
+// txs or its end blocker.
+type writeSet struct {
+	Height  int64        `json:"height"`
+	Step    string       `json:"step"`
+	TxIndex int          `json:"tx_index"`
+	TxHash  string       `json:"tx_hash,omitempty"`
+	Writes  []storeWrite `json:"writes"`
+}
+
+// keyDivergence is a key written differently by the local and reference nodes
+// in a step. A nil write means the key wasn't written.
+type keyDivergence struct {
+	Store     string      `json:"store"`
+	Key       string      `json:"key"`
+	Local     *storeWrite `json:"local"`
+	Reference *storeWrite `json:"reference"`
+}
+
+// forensicsReport is the result of AppHashForensicsCmd.
+type forensicsReport struct {
+	Diverged        bool     `json:"diverged"`
+	Height          int64    `json:"height"`
+	AppHash         string   `json:"app_hash,omitempty"`
+	ExpectedAppHash string   `json:"expected_app_hash,omitempty"`
+	DivergingStores []string `json:"diverging_stores,omitempty"`
+
+	// set if the write sets of the reference node are given
+	FirstDivergingStep *writeSet       `json:"first_diverging_step,omitempty"`
+	DivergingKeys      []keyDivergence `json:"diverging_keys,omitempty"`
+
+	// set otherwise, the steps writing to the diverging stores
+	CandidateSteps []writeSet `json:"candidate_steps,omitempty"`
+}
+
+// writeSetRecorder records the writes to the stores of an app, by step.
+type writeSetRecorder struct {
+	writes map[string]storeWrite
+}
+
+var _ sdk.WriteListener = (*writeSetRecorder)(nil)
+
+func newWriteSetRecorder() *writeSetRecorder {
+	return &writeSetRecorder{writes: make(map[string]storeWrite)}
+}
+
+// OnWrite implements the WriteListener interface.
+func (r *writeSetRecorder) OnWrite(storeKey sdk.StoreKey, key, value []byte, delete bool) {
+	w := storeWrite{
+		Store:  storeKey.Name(),
+		Key:    hex.EncodeToString(key),
+		Value:  hex.EncodeToString(value),
+		Delete: delete,
+	}
+	r.writes[w.Store+"/"+w.Key] = w
+}
+
+// take returns the writes recorded since the last call, sorted by store and
+// key.
+func (r *writeSetRecorder) take() []storeWrite {
+	writes := make([]storeWrite, 0, len(r.writes))
+	for _, w := range r.writes {
+		writes = append(writes, w)
+	}
+	r.writes = make(map[string]storeWrite)
+
+	sort.Slice(writes, func(i, j int) bool {
+		if writes[i].Store != writes[j].Store {
+			return writes[i].Store < writes[j].Store
+		}
+		return writes[i].Key < writes[j].Key
+	})
+	return writes
+}
+
+// CommitInfoCmd prints the app hash and the store hashes committed at a range
+// of heights, to be compared by AppHashForensicsCmd on another node.
+func CommitInfoCmd(ctx *Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "commit-info [from-height] [to-height]",
+		Short: "Print the app hash and the store hashes committed at a range of heights",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, to, err := parseHeightRange(args)
+			if err != nil {
+				return err
+			}
+
+			config := ctx.Config
+			config.SetRoot(viper.GetString(flags.FlagHome))
+
+			db, err := openDB(config.RootDir)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			infos := make([]heightCommitInfo, 0, to-from+1)
+			for height := from; height <= to; height++ {
+				info, err := loadHeightCommitInfo(db, height)
+				if err != nil {
+					return err
+				}
+				infos = append(infos, info)
+			}
+
+			encoded, err := json.MarshalIndent(infos, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+}
+
+// AppHashForensicsCmd replays a range of blocks from the block store against a
+// copy of the state rolled back to the height preceding them, to find where it
+// diverges from the state of another node.
+func AppHashForensicsCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apphash-forensics [from-height] [to-height]",
+		Short: "Replay blocks to find the first tx and the keys diverging from another node",
+		Long: `Replay a range of blocks from the Tendermint block store against a copy of the
+application state rolled back to the height preceding the range. The state is copied to a new
+database, in --scratch-dir if given or else in a temporary directory removed afterwards, leaving
+the node's state untouched. With --in-place, the node's state itself is rolled back and replayed
+on instead, deleting the later heights. The node must not be running.
+
+After each block, the store hashes are compared with the ones of the reference node, exported
+with commit-info to the file given with --reference, or the app hash with the one stored in the
+next block header otherwise. At the first divergence, the command reports the diverging stores,
+and, given the write sets recorded by the same command on the reference node with --write-sets,
+the first diverging tx and the keys it wrote differently. Without them, it reports the steps of
+the block writing to the diverging stores.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, to, err := parseHeightRange(args)
+			if err != nil {
+				return err
+			}
+
+			reference, err := loadReferenceCommitInfos(viper.GetString(flagReference))
+			if err != nil {
+				return err
+			}
+
+			var writeSetsOut io.Writer
+			if path := viper.GetString(flagWriteSets); path != "" {
+				file, err := os.Create(path)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+
+				buffered := bufio.NewWriter(file)
+				defer buffered.Flush()
+				writeSetsOut = buffered
+			}
+
+			config := ctx.Config
+			config.SetRoot(viper.GetString(flags.FlagHome))
+
+			scratchDir, cleanup, err := replayScratchDir()
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			db, err := openReplayDB(config.RootDir, scratchDir, from-1)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			blocks := openBlockSource(config)
+			defer blocks.close()
+
+			app := appCreator(ctx.Logger, db, nil)
+			listened, ok := app.(interface{ SetStoreWriteListener(sdk.WriteListener) })
+			if !ok {
+				return fmt.Errorf("app %T doesn't support store write listeners", app)
+			}
+			recorder := newWriteSetRecorder()
+			listened.SetStoreWriteListener(recorder)
+
+			if err := prepareReplay(config.GenesisFile(), app, from); err != nil {
+				return err
+			}
+			recorder.take()
+
+			for height := from; height <= to; height++ {
+				block, err := blocks.loadBlock(height)
+				if err != nil {
+					return err
+				}
+
+				var writeSets []writeSet
+				afterStep := func(step string, txIndex int) {
+					ws := writeSet{Height: height, Step: step, TxIndex: txIndex, Writes: recorder.take()}
+					if step == replayDeliverTx {
+						ws.TxHash = fmt.Sprintf("%X", block.Txs[txIndex].Hash())
+					}
+					writeSets = append(writeSets, ws)
+				}
+
+				_, appHash, err := blocks.replayBlock(app, block, afterStep)
+				if err != nil {
+					return err
+				}
+
+				if writeSetsOut != nil {
+					if err := writeWriteSets(writeSetsOut, writeSets); err != nil {
+						return err
+					}
+				}
+
+				report, err := checkDivergence(db, blocks, reference, height, appHash)
+				if err != nil {
+					return err
+				}
+				if report.Diverged {
+					if err := explainDivergence(&report, writeSets, viper.GetString(flagReferenceWriteSets)); err != nil {
+						return err
+					}
+					return printForensicsReport(report)
+				}
+			}
+
+			return printForensicsReport(forensicsReport{Height: to})
+		},
+	}
+
+	cmd.Flags().String(flagReference, "", "File holding the commit-info output of the reference node")
+	cmd.Flags().String(flagReferenceWriteSets, "", "File holding the write sets recorded by the reference node")
+	cmd.Flags().String(flagWriteSets, "", "File to record the write sets of the replayed blocks to, as JSON lines")
+	cmd.Flags().String(flagScratchDir, "", "Directory of the new database to replay on, instead of a temporary one")
+	cmd.Flags().Bool(flagInPlace, false, "Roll the node's state back and replay on it, instead of on a copy")
+	return cmd
+}
+
+func parseHeightRange(args []string) (from, to int64, err error) {
+	if from, err = strconv.ParseInt(args[0], 10, 64); err != nil || from < 1 {
+		return 0, 0, fmt.Errorf("invalid from height %s", args[0])
+	}
+	if to, err = strconv.ParseInt(args[1], 10, 64); err != nil || to < from {
+		return 0, 0, fmt.Errorf("invalid to height %s", args[1])
+	}
+	return from, to, nil
+}
+
+// prepareReplay checks that the app is at the height preceding from, and
+// initializes its chain if the replay starts from genesis.
+func prepareReplay(genesisFile string, app abci.Application, from int64) error {
+	info := app.Info(abci.RequestInfo{})
+	if info.LastBlockHeight != from-1 {
+		return fmt.Errorf("the state is at height %d, so the replay must start at height %d",
+			info.LastBlockHeight, info.LastBlockHeight+1)
+	}
+
+	if info.LastBlockHeight == 0 {
+		genDoc, err := tmtypes.GenesisDocFromFile(genesisFile)
+		if err != nil {
+			return err
+		}
+		initChain(app, genDoc)
+	}
+
+	return nil
+}
+
+func loadHeightCommitInfo(db dbm.DB, height int64) (heightCommitInfo, error) {
+	appHash, hashes, err := rootmulti.StoreHashes(db, height)
+	if err != nil {
+		return heightCommitInfo{}, err
+	}
+
+	info := heightCommitInfo{Height: height, AppHash: fmt.Sprintf("%X", appHash)}
+	for name, hash := range hashes {
+		info.Stores = append(info.Stores, storeHash{Name: name, Hash: fmt.Sprintf("%X", hash)})
+	}
+	sort.Slice(info.Stores, func(i, j int) bool { return info.Stores[i].Name < info.Stores[j].Name })
+
+	return info, nil
+}
+
+// loadReferenceCommitInfos reads the output of CommitInfoCmd, by height. It
+// returns no commit infos if path is empty.
+func loadReferenceCommitInfos(path string) (map[int64]heightCommitInfo, error) {
+	infos := make(map[int64]heightCommitInfo)
+	if path == "" {
+		return infos, nil
+	}
+
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []heightCommitInfo
+	if err := json.Unmarshal(bz, &list); err != nil {
+		return nil, fmt.Errorf("invalid reference commit infos: %w", err)
+	}
+	for _, info := range list {
+		infos[info.Height] = info
+	}
+
+	return infos, nil
+}
+
+// checkDivergence compares the hashes committed at a height with the ones of
+// the reference node if it has them, or the app hash with the one stored in the
+// next block header otherwise.
+func checkDivergence(
+	db dbm.DB, blocks *blockSource, reference map[int64]heightCommitInfo, height int64, appHash []byte,
+) (forensicsReport, error) {
+
+	info, err := loadHeightCommitInfo(db, height)
+	if err != nil {
+		return forensicsReport{}, err
+	}
+	report := forensicsReport{Height: height, AppHash: info.AppHash}
+
+	if ref, ok := reference[height]; ok {
+		report.ExpectedAppHash = ref.AppHash
+		report.DivergingStores = divergingStores(info.Stores, ref.Stores)
+		report.Diverged = len(report.DivergingStores) > 0 || info.AppHash != ref.AppHash
+	} else if expected := blocks.expectedAppHash(height); expected != nil {
+		report.ExpectedAppHash = fmt.Sprintf("%X", expected)
+		report.Diverged = !bytes.Equal(expected, appHash)
+	}
+
+	return report, nil
+}
+
+// divergingStores returns the names of the stores whose hashes differ, or which
+// only one of the nodes has, sorted.
+func divergingStores(local, reference []storeHash) []string {
+	hashes := make(map[string]string, len(reference))
+	for _, s := range reference {
+		hashes[s.Name] = s.Hash
+	}
+
+	var stores []string
+	for _, s := range local {
+		hash, ok := hashes[s.Name]
+		if !ok || hash != s.Hash {
+			stores = append(stores, s.Name)
+		}
+		delete(hashes, s.Name)
+	}
+	for name := range hashes {
+		stores = append(stores, name)
+	}
+
+	sort.Strings(stores)
+	return stores
+}
+
+// explainDivergence adds to a report the first step of the block writing
+// differently to the diverging stores than the reference node, if its write
+// sets are given, or the steps writing to the diverging stores otherwise. If
+// the diverging stores are unknown, all the stores are considered.
+func explainDivergence(report *forensicsReport, writeSets []writeSet, referenceWriteSets string) error {
+	stores := make(map[string]bool, len(report.DivergingStores))
+	for _, name := range report.DivergingStores {
+		stores[name] = true
+	}
+	inStores := func(name string) bool {
+		return len(stores) == 0 || stores[name]
+	}
+
+	if referenceWriteSets == "" {
+		for _, ws := range writeSets {
+			writes := ws.Writes[:0:0]
+			for _, w := range ws.Writes {
+				if inStores(w.Store) {
+					writes = append(writes, w)
+				}
+			}
+			if len(writes) > 0 {
+				ws.Writes = writes
+				report.CandidateSteps = append(report.CandidateSteps, ws)
+			}
+		}
+		return nil
+	}
+
+	reference, err := loadReferenceWriteSets(referenceWriteSets, report.Height)
+	if err != nil {
+		return err
+	}
+
+	for i, ws := range writeSets {
+		keys := divergingKeys(ws.Writes, reference[writeSetID(ws)].Writes, inStores)
+		if len(keys) > 0 {
+			report.FirstDivergingStep = &writeSets[i]
+			report.DivergingKeys = keys
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// divergingKeys returns the keys of the given stores written differently by the
+// local and reference nodes, sorted by store and key.
+func divergingKeys(local, reference []storeWrite, inStores func(string) bool) []keyDivergence {
+	type storeKey struct{ store, key string }
+	divergences := make(map[storeKey]*keyDivergence)
+	get := func(w storeWrite) *keyDivergence {
+		k := storeKey{w.Store, w.Key}
+		if divergences[k] == nil {
+			divergences[k] = &keyDivergence{Store: w.Store, Key: w.Key}
+		}
+		return divergences[k]
+	}
+
+	for i, w := range local {
+		if inStores(w.Store) {
+			get(w).Local = &local[i]
+		}
+	}
+	for i, w := range reference {
+		if inStores(w.Store) {
+			get(w).Reference = &reference[i]
+		}
+	}
+
+	var keys []keyDivergence
+	for _, d := range divergences {
+		if d.Local == nil || d.Reference == nil || *d.Local != *d.Reference {
+			keys = append(keys, *d)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Store != keys[j].Store {
+			return keys[i].Store < keys[j].Store
+		}
+		return keys[i].Key < keys[j].Key
+	})
+	return keys
+}
+
+func writeSetID(ws writeSet) string {
+	return fmt.Sprintf("%s/%d", ws.Step, ws.TxIndex)
+}
+
+func writeWriteSets(w io.Writer, writeSets []writeSet) error {
+	enc := json.NewEncoder(w)
+	for _, ws := range writeSets {
+		if err := enc.Encode(ws); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadReferenceWriteSets reads the write sets of a height recorded by the
+// reference node, by step.
+func loadReferenceWriteSets(path string, height int64) (map[string]writeSet, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	writeSets := make(map[string]writeSet)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<30)
+	for scanner.Scan() {
+		var ws writeSet
+		if err := json.Unmarshal(scanner.Bytes(), &ws); err != nil {
+			return nil, fmt.Errorf("invalid reference write set: %w", err)
+		}
+		if ws.Height == height {
+			writeSets[writeSetID(ws)] = ws
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(writeSets) == 0 {
+		return nil, fmt.Errorf("no reference write sets at height %d", height)
+	}
+	return writeSets, nil
+}
+
+func printForensicsReport(report forensicsReport) error {
+	encoded, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(encoded))
+	return nil
+}
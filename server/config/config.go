@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cosmos/cosmos-sdk/store/cache"
 	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -39,6 +40,19 @@ type BaseConfig struct {
 
 	// InterBlockCache enables inter-block caching.
 	InterBlockCache bool `mapstructure:"inter-block-cache"`
+
+	// InterBlockCacheSize is the size in bytes of the inter-block cache of a
+	// store, unless set in InterBlockCacheStores.
+	InterBlockCacheSize uint64 `mapstructure:"inter-block-cache-size"`
+
+	// InterBlockCacheStores sets the size in bytes of the inter-block cache of
+	// stores, by store key name. A size of 0 disables the cache of a store.
+	InterBlockCacheStores map[string]uint64 `mapstructure:"inter-block-cache-stores"`
+
+	// InterBlockCacheWarmUp sets the hex encoded key prefixes of stores, by
+	// store key name, whose keys are preloaded into the inter-block cache when
+	// the node starts.
+	InterBlockCacheWarmUp map[string][]string `mapstructure:"inter-block-cache-warm-up"`
 }
 
 // Config defines the server's top level configuration
@@ -79,12 +93,13 @@ func (c *Config) GetMinGasPrices() sdk.DecCoins {
 func DefaultConfig() *Config {
 	return &Config{
 		BaseConfig: BaseConfig{
-			MinGasPrices:      defaultMinGasPrices,
-			InterBlockCache:   true,
-			Pruning:           storetypes.PruningOptionDefault,
-			PruningKeepRecent: "0",
-			PruningKeepEvery:  "0",
-			PruningInterval:   "0",
+			MinGasPrices:        defaultMinGasPrices,
+			InterBlockCache:     true,
+			InterBlockCacheSize: cache.DefaultCommitKVStoreCacheBytes,
+			Pruning:             storetypes.PruningOptionDefault,
+			PruningKeepRecent:   "0",
+			PruningKeepEvery:    "0",
+			PruningInterval:     "0",
 		},
 		BackendConfig: DefaultBackendConfig(),
 		StreamConfig:  DefaultStreamConfig(),
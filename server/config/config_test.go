@@ -1,8 +1,12 @@
 package config
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -18,3 +22,26 @@ func TestSetMinimumFees(t *testing.T) {
 	cfg.SetMinGasPrices(sdk.DecCoins{sdk.NewInt64DecCoin("foo", 5)})
 	require.Equal(t, "5.000000000000000000foo", cfg.MinGasPrices)
 }
+
+func TestInterBlockCacheConfigFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := DefaultConfig()
+	cfg.InterBlockCacheStores = map[string]uint64{"acc": 1 << 20, "evm": 0}
+	cfg.InterBlockCacheWarmUp = map[string][]string{"acc": {"01", "02"}}
+	path := filepath.Join(dir, "app.toml")
+	WriteConfigFile(path, cfg)
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.SetConfigFile(path)
+	require.NoError(t, viper.ReadInConfig())
+
+	parsed, err := ParseConfig()
+	require.NoError(t, err)
+	require.Equal(t, cfg.InterBlockCacheSize, parsed.InterBlockCacheSize)
+	require.Equal(t, cfg.InterBlockCacheStores, parsed.InterBlockCacheStores)
+	require.Equal(t, cfg.InterBlockCacheWarmUp, parsed.InterBlockCacheWarmUp)
+}
@@ -45,6 +45,26 @@ halt-time = {{ .BaseConfig.HaltTime }}
 # InterBlockCache enables inter-block caching.
 inter-block-cache = {{ .BaseConfig.InterBlockCache }}
 
+# InterBlockCacheSize is the size in bytes of the inter-block cache of a store,
+# unless set in inter-block-cache-stores.
+inter-block-cache-size = {{ .BaseConfig.InterBlockCacheSize }}
+
+# InterBlockCacheStores sets the size in bytes of the inter-block cache of
+# stores, by store key name (e.g. acc = 33554432). A size of 0 disables the
+# cache of a store, which suits write-heavy stores.
+[inter-block-cache-stores]
+{{- range $store, $size := .BaseConfig.InterBlockCacheStores }}
+{{ $store }} = {{ $size }}
+{{- end }}
+
+# InterBlockCacheWarmUp sets the hex encoded key prefixes of stores, by store
+# key name (e.g. acc = ["01"]), whose keys are preloaded into the inter-block
+# cache when the node starts.
+[inter-block-cache-warm-up]
+{{- range $store, $prefixes := .BaseConfig.InterBlockCacheWarmUp }}
+{{ $store }} = [{{ range $i, $prefix := $prefixes }}{{ if $i }}, {{ end }}"{{ $prefix }}"{{ end }}]
+{{- end }}
+
 ##### backend configuration options #####
 [backend]
 enable_backend = "{{ .BackendConfig.EnableBackend }}"
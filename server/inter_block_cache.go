@@ -0,0 +1,47 @@
+package server
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/store/cache"
+)
+
+// Inter-block cache options of app.toml, set per store key name
+const (
+	configInterBlockCacheStores = "inter-block-cache-stores"
+	configInterBlockCacheWarmUp = "inter-block-cache-warm-up"
+)
+
+// GetInterBlockCacheConfigFromFlags returns the config of the inter-block
+// cache: the default size of the cache of a store set by the
+// inter-block-cache-size flag, and the sizes and warm-up prefixes set per store
+// in app.toml. Store key names are matched in lower case, as viper keys are
+// case insensitive.
+func GetInterBlockCacheConfigFromFlags() (cache.Config, error) {
+	config := cache.Config{DefaultBytes: viper.GetUint64(FlagInterBlockCacheSize)}
+
+	if err := viper.UnmarshalKey(configInterBlockCacheStores, &config.StoreBytes); err != nil {
+		return cache.Config{}, fmt.Errorf("invalid %s: %w", configInterBlockCacheStores, err)
+	}
+
+	var warmUp map[string][]string
+	if err := viper.UnmarshalKey(configInterBlockCacheWarmUp, &warmUp); err != nil {
+		return cache.Config{}, fmt.Errorf("invalid %s: %w", configInterBlockCacheWarmUp, err)
+	}
+
+	config.WarmUpPrefixes = make(map[string][][]byte, len(warmUp))
+	for store, prefixes := range warmUp {
+		for _, prefix := range prefixes {
+			bz, err := hex.DecodeString(prefix)
+			if err != nil {
+				return cache.Config{}, fmt.Errorf("invalid %s prefix %q of store %s: %w", configInterBlockCacheWarmUp, prefix, store, err)
+			}
+			config.WarmUpPrefixes[store] = append(config.WarmUpPrefixes[store], bz)
+		}
+	}
+
+	return config, nil
+}
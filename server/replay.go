@@ -0,0 +1,486 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	tmstate "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	flagScratchDir         = "scratch-dir"
+	flagInPlace            = "in-place"
+	flagOutput             = "output"
+	flagContinueOnMismatch = "continue-on-mismatch"
+)
+
+// The steps of a block replayed by replayBlock.
+const (
+	replayBeginBlock = "begin_block"
+	replayDeliverTx  = "deliver_tx"
+	replayEndBlock   = "end_block"
+)
+
+// blockSource reads the blocks stored by Tendermint, and the validator sets
+// needed to replay them.
+type blockSource struct {
+	blockStore *store.BlockStore
+	blockDB    dbm.DB
+	stateDB    dbm.DB
+}
+
+// openBlockSource opens the block store and state databases of Tendermint. The
+// node must not be running.
+func openBlockSource(config *cfg.Config) *blockSource {
+	backend := dbm.BackendType(config.DBBackend)
+	blockDB := dbm.NewDB("blockstore", backend, config.DBDir())
+
+	return &blockSource{
+		blockStore: store.NewBlockStore(blockDB),
+		blockDB:    blockDB,
+		stateDB:    dbm.NewDB("state", backend, config.DBDir()),
+	}
+}
+
+func (bs *blockSource) close() {
+	bs.blockDB.Close()
+	bs.stateDB.Close()
+}
+
+// loadBlock returns a stored block.
+func (bs *blockSource) loadBlock(height int64) (*tmtypes.Block, error) {
+	block := bs.blockStore.LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("block %d is not in the block store, which holds blocks %d to %d",
+			height, bs.blockStore.Base(), bs.blockStore.Height())
+	}
+
+	return block, nil
+}
+
+// expectedAppHash returns the app hash resulting from a block, which is stored
+// in the header of the next block. It returns nil if the next block isn't
+// stored.
+func (bs *blockSource) expectedAppHash(height int64) []byte {
+	meta := bs.blockStore.LoadBlockMeta(height + 1)
+	if meta == nil {
+		return nil
+	}
+
+	return meta.Header.AppHash
+}
+
+// initChain initializes an app with the genesis, as Tendermint does before
+// the first block.
+func initChain(app abci.Application, genDoc *tmtypes.GenesisDoc) {
+	validators := make([]*tmtypes.Validator, len(genDoc.Validators))
+	for i, val := range genDoc.Validators {
+		validators[i] = tmtypes.NewValidator(val.PubKey, val.Power)
+	}
+
+	app.InitChain(abci.RequestInitChain{
+		Time:            genDoc.GenesisTime,
+		ChainId:         genDoc.ChainID,
+		ConsensusParams: tmtypes.TM2PB.ConsensusParams(genDoc.ConsensusParams),
+		Validators:      tmtypes.TM2PB.ValidatorUpdates(tmtypes.NewValidatorSet(validators)),
+		AppStateBytes:   genDoc.AppState,
+	})
+}
+
+// replayBlock feeds a stored block to an app through BeginBlock, DeliverTx,
+// EndBlock and Commit, with the same requests as Tendermint. afterStep, if not
+// nil, is called after BeginBlock, each DeliverTx and EndBlock, with the index
+// of the tx for DeliverTx. It returns the responses of the block and the
+// resulting app hash.
+func (bs *blockSource) replayBlock(
+	app abci.Application, block *tmtypes.Block, afterStep func(step string, txIndex int),
+) (*tmstate.ABCIResponses, []byte, error) {
+
+	if afterStep == nil {
+		afterStep = func(string, int) {}
+	}
+
+	commitInfo, byzVals, err := bs.beginBlockValidatorInfo(block)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := tmstate.NewABCIResponses(block)
+
+	beginBlock := app.BeginBlock(abci.RequestBeginBlock{
+		Hash:                block.Hash(),
+		Header:              tmtypes.TM2PB.Header(&block.Header),
+		LastCommitInfo:      commitInfo,
+		ByzantineValidators: byzVals,
+	})
+	responses.BeginBlock = &beginBlock
+	afterStep(replayBeginBlock, 0)
+
+	for i, tx := range block.Txs {
+		res := app.DeliverTx(abci.RequestDeliverTx{Tx: tx})
+		responses.DeliverTxs[i] = &res
+		afterStep(replayDeliverTx, i)
+	}
+
+	endBlock := app.EndBlock(abci.RequestEndBlock{Height: block.Height})
+	responses.EndBlock = &endBlock
+	afterStep(replayEndBlock, 0)
+
+	commit := app.Commit()
+	return responses, commit.Data, nil
+}
+
+// beginBlockValidatorInfo returns the votes of the last commit and the evidence
+// of a block, as Tendermint passes them to BeginBlock.
+func (bs *blockSource) beginBlockValidatorInfo(block *tmtypes.Block) (abci.LastCommitInfo, []abci.Evidence, error) {
+	votes := make([]abci.VoteInfo, block.LastCommit.Size())
+
+	// the last commit of the first block is empty
+	if block.Height > 1 {
+		lastValSet, err := tmstate.LoadValidators(bs.stateDB, block.Height-1)
+		if err != nil {
+			return abci.LastCommitInfo{}, nil, err
+		}
+		if len(lastValSet.Validators) != len(votes) {
+			return abci.LastCommitInfo{}, nil, fmt.Errorf(
+				"commit size (%d) doesn't match the size of the validator set (%d) at height %d",
+				len(votes), len(lastValSet.Validators), block.Height,
+			)
+		}
+
+		for i, val := range lastValSet.Validators {
+			votes[i] = abci.VoteInfo{
+				Validator:       tmtypes.TM2PB.Validator(val),
+				SignedLastBlock: !block.LastCommit.Signatures[i].Absent(),
+			}
+		}
+	}
+
+	byzVals := make([]abci.Evidence, len(block.Evidence.Evidence))
+	for i, ev := range block.Evidence.Evidence {
+		valSet, err := tmstate.LoadValidators(bs.stateDB, ev.Height())
+		if err != nil {
+			return abci.LastCommitInfo{}, nil, err
+		}
+		byzVals[i] = tmtypes.TM2PB.Evidence(ev, valSet, block.Time)
+	}
+
+	return abci.LastCommitInfo{Round: int32(block.LastCommit.Round), Votes: votes}, byzVals, nil
+}
+
+// replayAttribute is an event attribute printed by ReplayCmd.
+type replayAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// replayEvent is an event printed by ReplayCmd.
+type replayEvent struct {
+	Type       string            `json:"type"`
+	Attributes []replayAttribute `json:"attributes"`
+}
+
+// replayTxResult is the result of a tx printed by ReplayCmd.
+type replayTxResult struct {
+	Code      uint32 `json:"code"`
+	Codespace string `json:"codespace,omitempty"`
+	Log       string `json:"log,omitempty"`
+	GasWanted int64  `json:"gas_wanted"`
+	GasUsed   int64  `json:"gas_used"`
+}
+
+// replayLine is a line printed by ReplayCmd for each step of a replayed block.
+// The commit line holds the resulting app hash, the one stored in the header of
+// the next block, and whether they match if that header is stored.
+type replayLine struct {
+	Height  int64  `json:"height"`
+	Step    string `json:"step"`
+	TxIndex *int   `json:"tx_index,omitempty"`
+	TxHash  string `json:"tx_hash,omitempty"`
+
+	Result *replayTxResult `json:"result,omitempty"`
+	Events []replayEvent   `json:"events,omitempty"`
+
+	AppHash         string `json:"app_hash,omitempty"`
+	ExpectedAppHash string `json:"expected_app_hash,omitempty"`
+	Verified        *bool  `json:"verified,omitempty"`
+}
+
+const replayCommit = "commit"
+
+// ReplayCmd re-executes the blocks stored by Tendermint on top of the
+// application state at a height, without connecting to any peer.
+func ReplayCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay [state-height] [to-height]",
+		Short: "Re-execute stored blocks on top of the state at a height",
+		Long: `Load the application state at state-height, and feed the blocks following it up to
+to-height from the Tendermint block store through BeginBlock, DeliverTx, EndBlock and Commit,
+without connecting to any peer. The results and events of each block are printed as JSON lines,
+and the app hash resulting from each block is verified against the one stored in the header of
+the next block. The command stops at the first mismatch, unless --continue-on-mismatch is set.
+
+The application state is copied to a new database, in --scratch-dir if given or else in a temporary
+directory removed afterwards, and rolled back to state-height there, leaving the node's state
+untouched. With --in-place, the node's state itself is rolled back to state-height instead, deleting
+the later heights, which saves the copy of a large state. A state-height of 0 replays from genesis
+on an empty state. The node must not be running.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stateHeight, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil || stateHeight < 0 {
+				return fmt.Errorf("invalid state height %s", args[0])
+			}
+			to, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil || to <= stateHeight {
+				return fmt.Errorf("invalid to height %s", args[1])
+			}
+
+			config := ctx.Config
+			config.SetRoot(viper.GetString(flags.FlagHome))
+
+			scratchDir, cleanup, err := replayScratchDir()
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			db, err := openReplayDB(config.RootDir, scratchDir, stateHeight)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			out := cmd.OutOrStdout()
+			if path := viper.GetString(flagOutput); path != "" {
+				file, err := os.Create(path)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				out = file
+			}
+			buffered := bufio.NewWriter(out)
+			defer buffered.Flush()
+			enc := json.NewEncoder(buffered)
+
+			blocks := openBlockSource(config)
+			defer blocks.close()
+
+			app := appCreator(ctx.Logger, db, nil)
+			if err := prepareReplay(config.GenesisFile(), app, stateHeight+1); err != nil {
+				return err
+			}
+
+			for height := stateHeight + 1; height <= to; height++ {
+				block, err := blocks.loadBlock(height)
+				if err != nil {
+					return err
+				}
+
+				responses, appHash, err := blocks.replayBlock(app, block, nil)
+				if err != nil {
+					return err
+				}
+
+				lines := replayLines(block, responses)
+				commit := replayLine{Height: height, Step: replayCommit, AppHash: fmt.Sprintf("%X", appHash)}
+				expected := blocks.expectedAppHash(height)
+				if expected != nil {
+					verified := bytes.Equal(expected, appHash)
+					commit.ExpectedAppHash = fmt.Sprintf("%X", expected)
+					commit.Verified = &verified
+				}
+
+				for _, line := range append(lines, commit) {
+					if err := enc.Encode(line); err != nil {
+						return err
+					}
+				}
+
+				if commit.Verified != nil && !*commit.Verified && !viper.GetBool(flagContinueOnMismatch) {
+					return fmt.Errorf("app hash mismatch at height %d: got %s, the next block header has %s",
+						height, commit.AppHash, commit.ExpectedAppHash)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagScratchDir, "", "Directory of the new database to replay on, instead of a temporary one")
+	cmd.Flags().Bool(flagInPlace, false, "Roll the node's state back to state-height and replay on it, instead of on a copy")
+	cmd.Flags().String(flagOutput, "", "File to print the JSON lines to, instead of stdout")
+	cmd.Flags().Bool(flagContinueOnMismatch, false, "Keep replaying after an app hash mismatch")
+	return cmd
+}
+
+// replayScratchDir returns the directory of the database to replay on, given
+// with --scratch-dir or else a new temporary directory, or an empty one with
+// --in-place, to replay on the node's own database. The returned function
+// removes the temporary directory.
+func replayScratchDir() (string, func(), error) {
+	scratchDir := viper.GetString(flagScratchDir)
+	if viper.GetBool(flagInPlace) {
+		if scratchDir != "" {
+			return "", nil, fmt.Errorf("--%s and --%s can't be used together", flagInPlace, flagScratchDir)
+		}
+		return "", func() {}, nil
+	}
+	if scratchDir != "" {
+		return scratchDir, func() {}, nil
+	}
+
+	scratchDir, err := ioutil.TempDir("", "replay")
+	if err != nil {
+		return "", nil, err
+	}
+	return scratchDir, func() { os.RemoveAll(scratchDir) }, nil
+}
+
+// openReplayDB copies the application database of the node to a new database
+// in scratchDir, or opens it if scratchDir is empty, to replay in place, and
+// rolls it back to stateHeight. There's nothing to copy nor roll back when
+// replaying from genesis.
+func openReplayDB(rootDir, scratchDir string, stateHeight int64) (dbm.DB, error) {
+	db, err := openReplayDBCopy(rootDir, scratchDir, stateHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	if stateHeight > 0 {
+		if err := rootmulti.RollbackToVersion(db, stateHeight); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+
+	return db, nil
+}
+
+func openReplayDBCopy(rootDir, scratchDir string, stateHeight int64) (dbm.DB, error) {
+	if scratchDir == "" {
+		return openDB(rootDir)
+	}
+
+	scratch, err := sdk.NewLevelDB("application", scratchDir)
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := scratch.Iterator(nil, nil)
+	if err != nil {
+		scratch.Close()
+		return nil, err
+	}
+	empty := !iter.Valid()
+	iter.Close()
+	if !empty {
+		scratch.Close()
+		return nil, fmt.Errorf("the scratch database in %s isn't empty", scratchDir)
+	}
+
+	if stateHeight > 0 {
+		db, err := openDB(rootDir)
+		if err != nil {
+			scratch.Close()
+			return nil, err
+		}
+		err = copyDB(db, scratch)
+		db.Close()
+		if err != nil {
+			scratch.Close()
+			return nil, err
+		}
+	}
+
+	return scratch, nil
+}
+
+// copyDB copies all the key-value pairs of src to dst, in batches.
+func copyDB(src, dst dbm.DB) error {
+	const batchSize = 10000
+
+	iter, err := src.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	batch := dst.NewBatch()
+	n := 0
+	for ; iter.Valid(); iter.Next() {
+		batch.Set(iter.Key(), iter.Value())
+		n++
+		if n%batchSize == 0 {
+			if err := batch.Write(); err != nil {
+				batch.Close()
+				return err
+			}
+			batch.Close()
+			batch = dst.NewBatch()
+		}
+	}
+	defer batch.Close()
+
+	return batch.Write()
+}
+
+// replayLines returns the lines printed by ReplayCmd for the BeginBlock, the
+// txs and the EndBlock of a block.
+func replayLines(block *tmtypes.Block, responses *tmstate.ABCIResponses) []replayLine {
+	lines := make([]replayLine, 0, len(block.Txs)+2)
+	lines = append(lines, replayLine{
+		Height: block.Height, Step: replayBeginBlock, Events: replayEvents(responses.BeginBlock.Events),
+	})
+
+	for i, res := range responses.DeliverTxs {
+		index := i
+		lines = append(lines, replayLine{
+			Height:  block.Height,
+			Step:    replayDeliverTx,
+			TxIndex: &index,
+			TxHash:  fmt.Sprintf("%X", block.Txs[i].Hash()),
+			Result: &replayTxResult{
+				Code:      res.Code,
+				Codespace: res.Codespace,
+				Log:       res.Log,
+				GasWanted: res.GasWanted,
+				GasUsed:   res.GasUsed,
+			},
+			Events: replayEvents(res.Events),
+		})
+	}
+
+	return append(lines, replayLine{
+		Height: block.Height, Step: replayEndBlock, Events: replayEvents(responses.EndBlock.Events),
+	})
+}
+
+func replayEvents(events []abci.Event) []replayEvent {
+	out := make([]replayEvent, len(events))
+	for i, event := range events {
+		out[i] = replayEvent{Type: event.Type, Attributes: make([]replayAttribute, len(event.Attributes))}
+		for j, attr := range event.Attributes {
+			out[i].Attributes[j] = replayAttribute{Key: string(attr.Key), Value: string(attr.Value)}
+		}
+	}
+
+	return out
+}
@@ -21,21 +21,26 @@ import (
 	pvm "github.com/tendermint/tendermint/privval"
 	"github.com/tendermint/tendermint/proxy"
 
+	"github.com/cosmos/cosmos-sdk/store/cache"
 	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 )
 
 // Tendermint full-node start flags
 const (
-	flagWithTendermint     = "with-tendermint"
-	flagAddress            = "address"
-	flagTraceStore         = "trace-store"
-	flagCPUProfile         = "cpu-profile"
-	FlagMinGasPrices       = "minimum-gas-prices"
-	FlagHaltHeight         = "halt-height"
-	FlagHaltTime           = "halt-time"
-	FlagInterBlockCache    = "inter-block-cache"
-	FlagUnsafeSkipUpgrades = "unsafe-skip-upgrades"
-	FlagTrace              = "trace"
+	flagWithTendermint      = "with-tendermint"
+	flagAddress             = "address"
+	flagTraceStore          = "trace-store"
+	flagCPUProfile          = "cpu-profile"
+	FlagMinGasPrices        = "minimum-gas-prices"
+	FlagHaltHeight          = "halt-height"
+	FlagHaltTime            = "halt-time"
+	FlagInterBlockCache     = "inter-block-cache"
+	FlagInterBlockCacheSize = "inter-block-cache-size"
+	FlagStoreMetrics        = "store-metrics"
+	FlagEventSink           = "event-sink"
+	FlagStreamingDir        = "streaming-dir"
+	FlagUnsafeSkipUpgrades  = "unsafe-skip-upgrades"
+	FlagTrace               = "trace"
 
 	FlagPruning           = "pruning"
 	FlagPruningKeepRecent = "pruning-keep-recent"
@@ -115,6 +120,10 @@ which accepts a path for the resulting pprof file.
 	cmd.Flags().Uint64(FlagHaltHeight, 0, "Block height at which to gracefully halt the chain and shutdown the node")
 	cmd.Flags().Uint64(FlagHaltTime, 0, "Minimum block time (in Unix seconds) at which to gracefully halt the chain and shutdown the node")
 	cmd.Flags().Bool(FlagInterBlockCache, true, "Enable inter-block caching")
+	cmd.Flags().Uint64(FlagInterBlockCacheSize, cache.DefaultCommitKVStoreCacheBytes, "Size in bytes of the inter-block cache of each store, unless set per store in app.toml")
+	cmd.Flags().Bool(FlagStoreMetrics, false, "Enable store read/write metrics and hot key sampling, served by the REST server at /store/metrics")
+	cmd.Flags().Bool(FlagEventSink, false, "Index the events of the executed blocks in an embedded database, searched by the REST server at /events")
+	cmd.Flags().String(FlagStreamingDir, "", "Directory to stream each committed block and its state changes to, as a file per block")
 	cmd.Flags().String(flagCPUProfile, "", "Enable CPU profiling and write to the provided file")
 
 	cmd.Flags().String(FlagPruning, storetypes.PruningOptionDefault, "Pruning strategy (default|nothing|everything|custom)")
@@ -10,6 +10,7 @@ import (
 	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/lcd"
 
 	"github.com/cosmos/cosmos-sdk/server/config"
 	"github.com/spf13/cobra"
@@ -20,8 +21,8 @@ import (
 const (
 	FlagListenAddr         = "rest.laddr"
 	FlagExternalListenAddr = "rest.external_laddr"
-	FlagUlockKey           = "rest.unlock_key"
-	FlagUlockKeyHome       = "rest.unlock_key_home"
+	FlagUlockKey           = lcd.FlagUnlockKey
+	FlagUlockKeyHome       = lcd.FlagUnlockKeyHome
 	FlagRestPathPrefix     = "rest.path_prefix"
 	FlagCORS               = "cors"
 	FlagMaxOpenConnections = "max-open"
@@ -185,10 +186,9 @@ func Stop() {
 // registerRestServerFlags registers the flags required for rest server
 func registerRestServerFlags(cmd *cobra.Command) *cobra.Command {
 	cmd.Flags().String(FlagListenAddr, "tcp://0.0.0.0:26659", "The address for the rest-server to listen on. (0.0.0.0:0 means any interface, any port)")
-	cmd.Flags().String(FlagUlockKey, "", "Select the keys to unlock on the RPC server")
-	cmd.Flags().String(FlagUlockKeyHome, "", "The keybase home path")
+	lcd.RegisterSigningServiceFlags(cmd)
 	cmd.Flags().String(FlagRestPathPrefix, "exchain", "Path prefix for registering rest api route.")
-	cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|test)")
+	cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|kwallet|pass|test|remote)")
 	cmd.Flags().String(FlagCORS, "", "Set the rest-server domains that can make CORS requests (* for all)")
 	cmd.Flags().Int(FlagMaxOpenConnections, 1000, "The number of maximum open connections of rest-server")
 	cmd.Flags().String(FlagExternalListenAddr, "127.0.0.1:26659", "Set the rest-server external ip and port, when it is launched by Docker")
@@ -196,7 +196,7 @@ func registerRestServerFlags(cmd *cobra.Command) *cobra.Command {
 	cmd.Flags().Int(FlagWsMaxConnections, 20000, "the max capacity number of websocket client connections")
 	cmd.Flags().Int(FlagWsSubChannelLength, 100, "the length of subscription channel")
 	cmd.Flags().String(flags.FlagChainID, "", "Chain ID of tendermint node for web3")
-	cmd.Flags().StringP(flags.FlagBroadcastMode, "b", flags.BroadcastSync, "Transaction broadcasting mode (sync|async|block) for web3")
+	cmd.Flags().StringP(flags.FlagBroadcastMode, "b", flags.BroadcastSync, "Transaction broadcasting mode (sync|async|block|wait) for web3")
 	return cmd
 }
 
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	tmkv "github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const flagStore = "store"
+
+// kvDiffOutput is a key-value diff printed by StateDiffCmd, with the keys and
+// values hex encoded.
+type kvDiffOutput struct {
+	Key     string `json:"key"`
+	Old     string `json:"old,omitempty"`
+	New     string `json:"new,omitempty"`
+	Decoded string `json:"decoded,omitempty"`
+}
+
+// storeDiffOutput is a store diff printed by StateDiffCmd.
+type storeDiffOutput struct {
+	Store   string         `json:"store"`
+	Added   []kvDiffOutput `json:"added"`
+	Changed []kvDiffOutput `json:"changed"`
+	Removed []kvDiffOutput `json:"removed"`
+}
+
+// StateDiffCmd prints the keys added, changed and removed between two committed
+// heights of the IAVL stores of the app. The values of the stores registered in
+// decoders are also printed decoded, as simulation does. AddCommands registers it
+// without decoders.
+func StateDiffCmd(ctx *Context, cdc *codec.Codec, decoders sdk.StoreDecoderRegistry) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state-diff [from-height] [to-height]",
+		Short: "Print the keys which changed between two committed heights",
+		Long: `Print the keys added, changed and removed between two committed heights of the IAVL
+stores of the app, or of a single store given with --store. Only the changed parts of the
+trees are read, so diffing consecutive heights is cheap even for large stores. The node
+must not be running.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid from height %s: %w", args[0], err)
+			}
+			to, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid to height %s: %w", args[1], err)
+			}
+
+			config := ctx.Config
+			config.SetRoot(viper.GetString(flags.FlagHome))
+
+			db, err := openDB(config.RootDir)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			diffs, err := rootmulti.DiffVersions(db, viper.GetString(flagStore), from, to)
+			if err != nil {
+				return err
+			}
+
+			outputs := make([]storeDiffOutput, 0, len(diffs))
+			for _, diff := range diffs {
+				if diff.IsEmpty() {
+					continue
+				}
+
+				decoder := decoders[diff.Store]
+				outputs = append(outputs, storeDiffOutput{
+					Store:   diff.Store,
+					Added:   kvDiffOutputs(cdc, decoder, diff.Added),
+					Changed: kvDiffOutputs(cdc, decoder, diff.Changed),
+					Removed: kvDiffOutputs(cdc, decoder, diff.Removed),
+				})
+			}
+
+			encoded, err := codec.MarshalJSONIndent(cdc, outputs)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagStore, "", "Name of the store to diff (all IAVL stores if empty)")
+	return cmd
+}
+
+func kvDiffOutputs(
+	cdc *codec.Codec, decoder func(cdc *codec.Codec, kvA, kvB tmkv.Pair) string, diffs []sdk.KVDiff,
+) []kvDiffOutput {
+
+	outputs := make([]kvDiffOutput, len(diffs))
+	for i, diff := range diffs {
+		outputs[i] = kvDiffOutput{
+			Key: hex.EncodeToString(diff.Key),
+			Old: hex.EncodeToString(diff.Old),
+			New: hex.EncodeToString(diff.New),
+		}
+		if decoder != nil {
+			outputs[i].Decoded = decodeKVDiff(cdc, decoder, diff)
+		}
+	}
+
+	return outputs
+}
+
+// decodeKVDiff decodes the old and new values of a key. As the decoders expect
+// two values, the value of an added or removed key is decoded twice. Decoders
+// panic on the keys they don't know, which are left undecoded.
+func decodeKVDiff(
+	cdc *codec.Codec, decoder func(cdc *codec.Codec, kvA, kvB tmkv.Pair) string, diff sdk.KVDiff,
+) (decoded string) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			decoded = ""
+		}
+	}()
+
+	kvA := tmkv.Pair{Key: diff.Key, Value: diff.Old}
+	kvB := tmkv.Pair{Key: diff.Key, Value: diff.New}
+	switch {
+	case diff.Old == nil:
+		kvA = kvB
+	case diff.New == nil:
+		kvB = kvA
+	}
+
+	return decoder(cdc, kvA, kvB)
+}
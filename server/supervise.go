@@ -0,0 +1,54 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/x/upgrade/supervisor"
+)
+
+const (
+	flagDaemonName   = "daemon-name"
+	flagPollInterval = "poll-interval"
+)
+
+// SuperviseCmd runs the node binary under an upgrade supervisor, which restarts
+// it with a locally staged binary when the node halts for an upgrade.
+func SuperviseCmd(ctx *Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "supervise -- [node args...]",
+		Short: "Run the node and switch to a staged binary when it halts for an upgrade",
+		Long: `Run the node binary as a child process and switch to a locally staged binary when
+the node halts for an upgrade. Binaries must be staged in the node home as
+
+  upgrades/genesis/bin/<daemon-name>
+  upgrades/<upgrade name>/bin/<daemon-name>
+
+When the node halts, the upgrade module writes the plan to data/upgrade-info.json.
+The supervisor then stops the node, verifies the staged binary against the checksum
+for this platform if the plan info lists one, points upgrades/current to the
+upgrade directory and restarts the node with the same arguments.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := viper.GetString(flagDaemonName)
+			if name == "" {
+				name = filepath.Base(os.Args[0])
+			}
+
+			s := supervisor.NewSupervisor(ctx.Config.RootDir, name, args, ctx.Logger)
+			s.PollInterval = viper.GetDuration(flagPollInterval)
+			return s.Run()
+		},
+	}
+
+	cmd.Flags().String(flagDaemonName, "", "Name of the staged node binaries (defaults to the name of this binary)")
+	cmd.Flags().Duration(flagPollInterval, time.Second, "Interval at which the upgrade info file is checked")
+	viper.BindPFlag(flagDaemonName, cmd.Flags().Lookup(flagDaemonName))
+	viper.BindPFlag(flagPollInterval, cmd.Flags().Lookup(flagPollInterval))
+
+	return cmd
+}
@@ -157,6 +157,11 @@ func AddCommands(
 		flags.LineBreak,
 		tendermintCmd,
 		ExportCmd(ctx, cdc, appExport),
+		CommitInfoCmd(ctx),
+		StateDiffCmd(ctx, cdc, nil),
+		AppHashForensicsCmd(ctx, appCreator),
+		ReplayCmd(ctx, appCreator),
+		SuperviseCmd(ctx),
 		flags.LineBreak,
 		version.Cmd,
 	)
@@ -1,6 +1,7 @@
 package simapp
 
 import (
+	"fmt"
 	"io"
 	"os"
 
@@ -55,6 +56,7 @@ var (
 		distr.AppModuleBasic{},
 		gov.NewAppModuleBasic(
 			paramsclient.ProposalHandler, distr.ProposalHandler, upgradeclient.ProposalHandler,
+			slashing.ProposalHandler,
 		),
 		params.AppModuleBasic{},
 		crisis.AppModuleBasic{},
@@ -103,8 +105,9 @@ type SimApp struct {
 	invCheckPeriod uint
 
 	// keys to access the substores
-	keys  map[string]*sdk.KVStoreKey
-	tkeys map[string]*sdk.TransientStoreKey
+	keys   map[string]*sdk.KVStoreKey
+	tkeys  map[string]*sdk.TransientStoreKey
+	dbKeys map[string]*sdk.KVStoreKey // node-local stores, mounted as StoreTypeDB
 
 	// subspaces
 	subspaces map[string]params.Subspace
@@ -126,6 +129,9 @@ type SimApp struct {
 	// the module manager
 	mm *module.Manager
 
+	// the configurator the module store migrations are registered with
+	configurator module.Configurator
+
 	// simulation manager
 	sm *module.SimulationManager
 }
@@ -133,7 +139,7 @@ type SimApp struct {
 // NewSimApp returns a reference to an initialized SimApp.
 func NewSimApp(
 	logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest bool, skipUpgradeHeights map[int64]bool,
-	invCheckPeriod uint, baseAppOptions ...func(*bam.BaseApp),
+	homePath string, invCheckPeriod uint, baseAppOptions ...func(*bam.BaseApp),
 ) *SimApp {
 
 	cdc := MakeCodec()
@@ -148,6 +154,8 @@ func NewSimApp(
 		gov.StoreKey, params.StoreKey, upgrade.StoreKey, evidence.StoreKey,
 	)
 	tkeys := sdk.NewTransientStoreKeys(params.TStoreKey)
+	// stores holding node-local state, kept out of the app hash
+	dbKeys := sdk.NewKVStoreKeys(crisis.StoreKey)
 
 	app := &SimApp{
 		BaseApp:        bApp,
@@ -155,6 +163,7 @@ func NewSimApp(
 		invCheckPeriod: invCheckPeriod,
 		keys:           keys,
 		tkeys:          tkeys,
+		dbKeys:         dbKeys,
 		subspaces:      make(map[string]params.Subspace),
 	}
 
@@ -169,6 +178,7 @@ func NewSimApp(
 	app.subspaces[gov.ModuleName] = app.ParamsKeeper.Subspace(gov.DefaultParamspace).WithKeyTable(gov.ParamKeyTable())
 	app.subspaces[crisis.ModuleName] = app.ParamsKeeper.Subspace(crisis.DefaultParamspace)
 	app.subspaces[evidence.ModuleName] = app.ParamsKeeper.Subspace(evidence.DefaultParamspace)
+	app.SetParamStore(app.ParamsKeeper.Subspace(bam.Paramspace).WithKeyTable(params.BaseAppParamsKeyTable()))
 
 	// add keepers
 	app.AccountKeeper = auth.NewAccountKeeper(
@@ -195,16 +205,18 @@ func NewSimApp(
 		app.cdc, keys[slashing.StoreKey], &stakingKeeper, app.subspaces[slashing.ModuleName],
 	)
 	app.CrisisKeeper = crisis.NewKeeper(
-		app.subspaces[crisis.ModuleName], invCheckPeriod, app.SupplyKeeper, auth.FeeCollectorName,
+		app.cdc, dbKeys[crisis.StoreKey], app.subspaces[crisis.ModuleName], invCheckPeriod, app.SupplyKeeper,
+		auth.FeeCollectorName,
 	)
-	app.UpgradeKeeper = upgrade.NewKeeper(skipUpgradeHeights, keys[upgrade.StoreKey], app.cdc)
+	// the upgrade info is written to the node home, unless it is empty
+	app.UpgradeKeeper = upgrade.NewKeeper(skipUpgradeHeights, keys[upgrade.StoreKey], app.cdc, homePath)
 
 	// create evidence keeper with router
 	evidenceKeeper := evidence.NewKeeper(
 		app.cdc, keys[evidence.StoreKey], app.subspaces[evidence.ModuleName], &app.StakingKeeper, app.SlashingKeeper,
 	)
-	evidenceRouter := evidence.NewRouter()
-	// TODO: Register evidence routes.
+	evidenceRouter := evidence.NewRouter().
+		AddRoute(evidence.RouteConflictingHeaders, evidence.NewConflictingHeadersHandler(*evidenceKeeper))
 	evidenceKeeper.SetRouter(evidenceRouter)
 	app.EvidenceKeeper = *evidenceKeeper
 
@@ -213,7 +225,8 @@ func NewSimApp(
 	govRouter.AddRoute(gov.RouterKey, gov.ProposalHandler).
 		AddRoute(params.RouterKey, params.NewParamChangeProposalHandler(app.ParamsKeeper)).
 		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.DistrKeeper)).
-		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.UpgradeKeeper))
+		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.UpgradeKeeper)).
+		AddRoute(slashing.RouterKey, slashing.NewTombstoneAppealProposalHandler(app.SlashingKeeper))
 	app.GovKeeper = gov.NewKeeper(
 		app.cdc, keys[gov.StoreKey], app.subspaces[gov.ModuleName], app.SupplyKeeper,
 		&stakingKeeper, govRouter,
@@ -239,13 +252,17 @@ func NewSimApp(
 		distr.NewAppModule(app.DistrKeeper, app.AccountKeeper, app.SupplyKeeper, app.StakingKeeper),
 		staking.NewAppModule(app.StakingKeeper, app.AccountKeeper, app.SupplyKeeper),
 		upgrade.NewAppModule(app.UpgradeKeeper),
-		evidence.NewAppModule(app.EvidenceKeeper),
+		evidence.NewAppModule(app.EvidenceKeeper, app.AccountKeeper, app.StakingKeeper),
 	)
 
 	// During begin block slashing happens after distr.BeginBlocker so that
 	// there is nothing left over in the validator fee pool, so as to keep the
-	// CanWithdrawInvariant invariant.
-	app.mm.SetOrderBeginBlockers(upgrade.ModuleName, mint.ModuleName, distr.ModuleName, slashing.ModuleName, evidence.ModuleName)
+	// CanWithdrawInvariant invariant. Staking tracks the HistoricalInfo used to
+	// verify conflicting headers evidence.
+	app.mm.SetOrderBeginBlockers(
+		upgrade.ModuleName, mint.ModuleName, distr.ModuleName, slashing.ModuleName,
+		evidence.ModuleName, staking.ModuleName,
+	)
 	app.mm.SetOrderEndBlockers(crisis.ModuleName, gov.ModuleName, staking.ModuleName)
 
 	// NOTE: The genutils moodule must occur after staking so that pools are
@@ -253,12 +270,17 @@ func NewSimApp(
 	app.mm.SetOrderInitGenesis(
 		auth.ModuleName, distr.ModuleName, staking.ModuleName, bank.ModuleName,
 		slashing.ModuleName, gov.ModuleName, mint.ModuleName, supply.ModuleName,
-		crisis.ModuleName, genutil.ModuleName, evidence.ModuleName,
+		crisis.ModuleName, genutil.ModuleName, evidence.ModuleName, upgrade.ModuleName,
 	)
 
 	app.mm.RegisterInvariants(&app.CrisisKeeper)
 	app.mm.RegisterRoutes(app.Router(), app.QueryRouter())
 
+	// upgrade handlers registered with upgrade.NewMigrationsUpgradeHandler run the
+	// module store migrations registered here
+	app.configurator = module.NewConfigurator()
+	app.mm.RegisterMigrations(app.configurator)
+
 	// create the simulation manager and define the order of the modules for deterministic simulations
 	//
 	// NOTE: this is not required apps that don't use the simulator for fuzz testing
@@ -272,6 +294,7 @@ func NewSimApp(
 		staking.NewAppModule(app.StakingKeeper, app.AccountKeeper, app.SupplyKeeper),
 		distr.NewAppModule(app.DistrKeeper, app.AccountKeeper, app.SupplyKeeper, app.StakingKeeper),
 		slashing.NewAppModule(app.SlashingKeeper, app.AccountKeeper, app.StakingKeeper),
+		evidence.NewAppModule(app.EvidenceKeeper, app.AccountKeeper, app.StakingKeeper),
 		params.NewAppModule(), // NOTE: only used for simulation to generate randomized param change proposals
 	)
 
@@ -280,6 +303,9 @@ func NewSimApp(
 	// initialize stores
 	app.MountKVStores(keys)
 	app.MountTransientStores(tkeys)
+	for _, key := range dbKeys {
+		app.MountStore(key, sdk.StoreTypeDB)
+	}
 
 	// initialize BaseApp
 	app.SetInitChainer(app.InitChainer)
@@ -314,7 +340,16 @@ func (app *SimApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.Re
 func (app *SimApp) InitChainer(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
 	var genesisState GenesisState
 	app.cdc.MustUnmarshalJSON(req.AppStateBytes, &genesisState)
-	return app.mm.InitGenesis(ctx, genesisState)
+	res := app.mm.InitGenesis(ctx, genesisState)
+
+	// an exported genesis carries the module versions it was exported at, which
+	// must match the versions of this binary
+	if err := app.mm.ValidateVersionMap(app.UpgradeKeeper.GetModuleVersionMap(ctx)); err != nil {
+		panic(fmt.Sprintf("incompatible genesis: %s", err))
+	}
+	app.UpgradeKeeper.SetModuleVersionMap(ctx, app.mm.GetVersionMap())
+
+	return res
 }
 
 // LoadHeight loads a particular height
@@ -354,7 +389,10 @@ func (app *SimApp) Codec() *codec.Codec {
 //
 // NOTE: This is solely to be used for testing purposes.
 func (app *SimApp) GetKey(storeKey string) *sdk.KVStoreKey {
-	return app.keys[storeKey]
+	if key, ok := app.keys[storeKey]; ok {
+		return key
+	}
+	return app.dbKeys[storeKey]
 }
 
 // GetTKey returns the TransientStoreKey for the provided store key.
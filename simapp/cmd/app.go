@@ -0,0 +1,73 @@
+// Package cmd wires the SimApp into the daemon commands of package server.
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/cli"
+	"github.com/tendermint/tendermint/libs/log"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/simapp"
+	"github.com/cosmos/cosmos-sdk/store"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+)
+
+// FlagInvCheckPeriod is the start flag for the number of blocks between two
+// passes of the invariant checks
+const FlagInvCheckPeriod = "inv-check-period"
+
+var (
+	_ server.AppCreator = NewApp
+)
+
+// AddStartFlags adds the SimApp flags to the start command of the daemon. It
+// is meant to be passed as the registerAppFlagFn of server.AddCommands.
+func AddStartFlags(startCmd *cobra.Command) {
+	startCmd.Flags().Uint(FlagInvCheckPeriod, 0, "Assert registered invariants every N blocks (0 disables the checks)")
+	viper.BindPFlag(FlagInvCheckPeriod, startCmd.Flags().Lookup(FlagInvCheckPeriod))
+
+	crisis.AddModuleInitFlags(startCmd)
+}
+
+// NewApp creates a SimApp configured by the start flags of the daemon.
+func NewApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application {
+	skipUpgradeHeights := make(map[int64]bool)
+	for _, h := range viper.GetIntSlice(server.FlagUnsafeSkipUpgrades) {
+		skipUpgradeHeights[int64(h)] = true
+	}
+
+	pruningOpts, err := server.GetPruningOptionsFromFlags()
+	if err != nil {
+		tmos.Exit(err.Error())
+	}
+
+	baseAppOptions := []func(*baseapp.BaseApp){
+		baseapp.SetPruning(pruningOpts),
+		baseapp.SetMinGasPrices(viper.GetString(server.FlagMinGasPrices)),
+		baseapp.SetHaltHeight(viper.GetUint64(server.FlagHaltHeight)),
+		baseapp.SetHaltTime(viper.GetUint64(server.FlagHaltTime)),
+		baseapp.SetTrace(viper.GetBool(server.FlagTrace)),
+	}
+	if viper.GetBool(server.FlagInterBlockCache) {
+		cacheConfig, err := server.GetInterBlockCacheConfigFromFlags()
+		if err != nil {
+			tmos.Exit(err.Error())
+		}
+		baseAppOptions = append(baseAppOptions, baseapp.SetInterBlockCache(store.NewCommitKVStoreCacheManagerWithConfig(cacheConfig)))
+	}
+
+	app := simapp.NewSimApp(
+		logger, db, traceStore, true, skipUpgradeHeights,
+		viper.GetString(cli.HomeFlag), viper.GetUint(FlagInvCheckPeriod), baseAppOptions...,
+	)
+	app.CrisisKeeper.SetInvCheckBudget(viper.GetDuration(crisis.FlagInvCheckBudget))
+
+	return app
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/simapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+)
+
+// SetTxPreviewConfig sets the config of the tx previews of the SimApp CLI,
+// which warn about funds sent to the module accounts. It is meant to be called
+// before the commands of the CLI are executed.
+func SetTxPreviewConfig() {
+	utils.SetTxPreviewConfig(TxPreviewConfig())
+}
+
+// TxPreviewConfig returns the default TxPreviewConfig with the module accounts
+// of the SimApp blacklisted, sorted by module name.
+func TxPreviewConfig() utils.TxPreviewConfig {
+	names := make([]string, 0, len(simapp.GetMaccPerms()))
+	for name := range simapp.GetMaccPerms() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	config := utils.DefaultTxPreviewConfig()
+	config.Blacklist = make([]sdk.AccAddress, len(names))
+	for i, name := range names {
+		config.Blacklist[i] = supply.NewModuleAddress(name)
+	}
+
+	return config
+}
@@ -23,6 +23,11 @@ func (app *SimApp) ExportAppStateAndValidators(
 	// as if they could withdraw from the start of the next block
 	ctx := app.NewContext(true, abci.Header{Height: app.LastBlockHeight()})
 
+	// refuse to export state whose store migrations have not run yet
+	if err := app.mm.ValidateVersionMap(app.UpgradeKeeper.GetModuleVersionMap(ctx)); err != nil {
+		return nil, nil, err
+	}
+
 	if forZeroHeight {
 		app.prepForZeroHeightGenesis(ctx, jailWhiteList)
 	}
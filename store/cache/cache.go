@@ -1,33 +1,32 @@
 package cache
 
 import (
-	"fmt"
+	"sort"
 
 	"github.com/cosmos/cosmos-sdk/store/cachekv"
 	"github.com/cosmos/cosmos-sdk/store/types"
-
-	lru "github.com/hashicorp/golang-lru"
 )
 
 var (
 	_ types.CommitKVStore             = (*CommitKVStoreCache)(nil)
 	_ types.MultiStorePersistentCache = (*CommitKVStoreCacheManager)(nil)
 
-	// DefaultCommitKVStoreCacheSize defines the persistent ARC cache size for a
-	// CommitKVStoreCache.
-	DefaultCommitKVStoreCacheSize uint = 1000
+	// DefaultCommitKVStoreCacheBytes defines the persistent cache size in
+	// bytes of a CommitKVStoreCache.
+	DefaultCommitKVStoreCacheBytes uint64 = 4 << 20
 )
 
 type (
 	// CommitKVStoreCache implements an inter-block (persistent) cache that wraps a
-	// CommitKVStore. Reads first hit the internal ARC (Adaptive Replacement Cache).
-	// During a cache miss, the read is delegated to the underlying CommitKVStore
-	// and cached. Deletes and writes always happen to both the cache and the
-	// CommitKVStore in a write-through manner. Caching performed in the
-	// CommitKVStore and below is completely irrelevant to this layer.
+	// CommitKVStore. Reads first hit the internal LRU (Least Recently Used)
+	// cache, bounded by the bytes of its keys and values. During a cache miss,
+	// the read is delegated to the underlying CommitKVStore and cached. Deletes
+	// and writes always happen to both the cache and the CommitKVStore in a
+	// write-through manner. Caching performed in the CommitKVStore and below is
+	// completely irrelevant to this layer.
 	CommitKVStoreCache struct {
 		types.CommitKVStore
-		cache *lru.ARCCache
+		cache *lruCache
 	}
 
 	// CommitKVStoreCacheManager maintains a mapping from a StoreKey to a
@@ -35,36 +34,85 @@ type (
 	// in an inter-block (persistent) manner and typically provided by a
 	// CommitMultiStore.
 	CommitKVStoreCacheManager struct {
-		cacheSize uint
-		caches    map[string]types.CommitKVStore
+		config Config
+		caches map[string]types.CommitKVStore
+	}
+
+	// Config defines the sizes of the caches of a CommitKVStoreCacheManager,
+	// and the keys preloaded by WarmUp.
+	Config struct {
+		// DefaultBytes is the size in bytes of the cache of a store missing
+		// from StoreBytes.
+		DefaultBytes uint64
+
+		// StoreBytes are the sizes in bytes of the caches of stores, by store
+		// key name. A size of 0 disables the cache of a store, which is
+		// typically done for write-heavy stores.
+		StoreBytes map[string]uint64
+
+		// WarmUpPrefixes are the key prefixes of stores, by store key name,
+		// whose keys are preloaded into the caches by WarmUp.
+		WarmUpPrefixes map[string][][]byte
 	}
-)
 
-func NewCommitKVStoreCache(store types.CommitKVStore, size uint) *CommitKVStoreCache {
-	cache, err := lru.NewARC(int(size))
-	if err != nil {
-		panic(fmt.Errorf("failed to create KVStore cache: %s", err))
+	// Stats are the size in bytes and the number of entries of the cache of a
+	// store, and the numbers of reads it served (hits) or delegated to the
+	// store (misses) and of entries evicted to fit in its capacity.
+	Stats struct {
+		Store     string `json:"store"`
+		Capacity  uint64 `json:"capacity"`
+		Size      uint64 `json:"size"`
+		Entries   uint64 `json:"entries"`
+		Hits      uint64 `json:"hits"`
+		Misses    uint64 `json:"misses"`
+		Evictions uint64 `json:"evictions"`
 	}
+)
 
+// NewCommitKVStoreCacheBytes returns a CommitKVStoreCache caching up to
+// maxBytes bytes of keys and values of a store.
+func NewCommitKVStoreCacheBytes(store types.CommitKVStore, maxBytes uint64) *CommitKVStoreCache {
 	return &CommitKVStoreCache{
 		CommitKVStore: store,
-		cache:         cache,
+		cache:         newLRUCache(maxBytes),
 	}
 }
 
-func NewCommitKVStoreCacheManager(size uint) *CommitKVStoreCacheManager {
+// DefaultConfig returns a Config caching DefaultCommitKVStoreCacheBytes bytes
+// of each store.
+func DefaultConfig() Config {
+	return Config{DefaultBytes: DefaultCommitKVStoreCacheBytes}
+}
+
+// NewCommitKVStoreCacheManagerWithConfig returns a CommitKVStoreCacheManager
+// sizing the cache of each store after the given config.
+func NewCommitKVStoreCacheManagerWithConfig(config Config) *CommitKVStoreCacheManager {
 	return &CommitKVStoreCacheManager{
-		cacheSize: size,
-		caches:    make(map[string]types.CommitKVStore),
+		config: config,
+		caches: make(map[string]types.CommitKVStore),
+	}
+}
+
+// cacheSize returns the size in bytes of the cache of a store.
+func (cmgr *CommitKVStoreCacheManager) cacheSize(name string) uint64 {
+	if size, ok := cmgr.config.StoreBytes[name]; ok {
+		return size
 	}
+	return cmgr.config.DefaultBytes
 }
 
 // GetStoreCache returns a Cache from the CommitStoreCacheManager for a given
 // StoreKey. If no Cache exists for the StoreKey, then one is created and set.
-// The returned Cache is meant to be used in a persistent manner.
+// The returned Cache is meant to be used in a persistent manner. The store is
+// returned as is if its cache is disabled.
 func (cmgr *CommitKVStoreCacheManager) GetStoreCache(key types.StoreKey, store types.CommitKVStore) types.CommitKVStore {
+	size := cmgr.cacheSize(key.Name())
+	if size == 0 {
+		return store
+	}
+
 	if cmgr.caches[key.Name()] == nil {
-		cmgr.caches[key.Name()] = NewCommitKVStoreCache(store, cmgr.cacheSize)
+		cmgr.caches[key.Name()] = NewCommitKVStoreCacheBytes(store, size)
 	}
 
 	return cmgr.caches[key.Name()]
@@ -84,6 +132,60 @@ func (cmgr *CommitKVStoreCacheManager) Reset() {
 	cmgr.caches = make(map[string]types.CommitKVStore)
 }
 
+// WarmUp preloads the keys under the warm-up prefixes of each store into its
+// cache, until the cache is full, and returns the number of entries loaded.
+// It is meant to be called once the stores are loaded, so that the first
+// blocks don't miss the cache for their hot keys.
+func (cmgr *CommitKVStoreCacheManager) WarmUp() int {
+	loaded := 0
+	for name, prefixes := range cmgr.config.WarmUpPrefixes {
+		ckv, ok := cmgr.caches[name]
+		if !ok {
+			continue
+		}
+		loaded += ckv.(*CommitKVStoreCache).warmUp(prefixes)
+	}
+
+	return loaded
+}
+
+// Stats returns the stats of the cache of each store, sorted by store.
+func (cmgr *CommitKVStoreCacheManager) Stats() []Stats {
+	stats := make([]Stats, 0, len(cmgr.caches))
+	for name, ckv := range cmgr.caches {
+		s := Stats{Store: name}
+		ckv.(*CommitKVStoreCache).cache.stats(&s)
+		stats = append(stats, s)
+	}
+
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Store < stats[j].Store })
+	return stats
+}
+
+// warmUp preloads the keys under the given prefixes into the cache, until it
+// is full, and returns the number of entries loaded.
+func (ckv *CommitKVStoreCache) warmUp(prefixes [][]byte) int {
+	loaded := 0
+	for _, prefix := range prefixes {
+		it := types.KVStorePrefixIterator(ckv.CommitKVStore, prefix)
+		full := false
+		for ; it.Valid(); it.Next() {
+			if !ckv.cache.tryAdd(string(it.Key()), it.Value()) {
+				full = true
+				break
+			}
+			loaded++
+		}
+		it.Close()
+
+		if full {
+			break
+		}
+	}
+
+	return loaded
+}
+
 // CacheWrap returns the inter-block cache as a cache-wrapped CommitKVStore.
 func (ckv *CommitKVStoreCache) CacheWrap() types.CacheWrap {
 	return cachekv.NewStore(ckv)
@@ -96,15 +198,15 @@ func (ckv *CommitKVStoreCache) Get(key []byte) []byte {
 	types.AssertValidKey(key)
 
 	keyStr := string(key)
-	valueI, ok := ckv.cache.Get(keyStr)
+	value, ok := ckv.cache.get(keyStr)
 	if ok {
 		// cache hit
-		return valueI.([]byte)
+		return value
 	}
 
 	// cache miss; write to cache
-	value := ckv.CommitKVStore.Get(key)
-	ckv.cache.Add(keyStr, value)
+	value = ckv.CommitKVStore.Get(key)
+	ckv.cache.add(keyStr, value)
 
 	return value
 }
@@ -115,13 +217,13 @@ func (ckv *CommitKVStoreCache) Set(key, value []byte) {
 	types.AssertValidKey(key)
 	types.AssertValidValue(value)
 
-	ckv.cache.Add(string(key), value)
+	ckv.cache.add(string(key), value)
 	ckv.CommitKVStore.Set(key, value)
 }
 
 // Delete removes a key/value pair from both the write-through cache and the
 // underlying CommitKVStore.
 func (ckv *CommitKVStoreCache) Delete(key []byte) {
-	ckv.cache.Remove(string(key))
+	ckv.cache.remove(string(key))
 	ckv.CommitKVStore.Delete(key)
 }
@@ -15,7 +15,7 @@ import (
 
 func TestGetOrSetStoreCache(t *testing.T) {
 	db := dbm.NewMemDB()
-	mngr := cache.NewCommitKVStoreCacheManager(cache.DefaultCommitKVStoreCacheSize)
+	mngr := cache.NewCommitKVStoreCacheManagerWithConfig(cache.DefaultConfig())
 
 	sKey := types.NewKVStoreKey("test")
 	tree, err := iavl.NewMutableTree(db, 100)
@@ -29,7 +29,7 @@ func TestGetOrSetStoreCache(t *testing.T) {
 
 func TestUnwrap(t *testing.T) {
 	db := dbm.NewMemDB()
-	mngr := cache.NewCommitKVStoreCacheManager(cache.DefaultCommitKVStoreCacheSize)
+	mngr := cache.NewCommitKVStoreCacheManagerWithConfig(cache.DefaultConfig())
 
 	sKey := types.NewKVStoreKey("test")
 	tree, err := iavl.NewMutableTree(db, 100)
@@ -43,7 +43,7 @@ func TestUnwrap(t *testing.T) {
 
 func TestStoreCache(t *testing.T) {
 	db := dbm.NewMemDB()
-	mngr := cache.NewCommitKVStoreCacheManager(cache.DefaultCommitKVStoreCacheSize)
+	mngr := cache.NewCommitKVStoreCacheManagerWithConfig(cache.DefaultConfig())
 
 	sKey := types.NewKVStoreKey("test")
 	tree, err := iavl.NewMutableTree(db, 100)
@@ -51,7 +51,7 @@ func TestStoreCache(t *testing.T) {
 	store := iavlstore.UnsafeNewStore(tree)
 	kvStore := mngr.GetStoreCache(sKey, store)
 
-	for i := uint(0); i < cache.DefaultCommitKVStoreCacheSize*2; i++ {
+	for i := 0; i < 2000; i++ {
 		key := []byte(fmt.Sprintf("key_%d", i))
 		value := []byte(fmt.Sprintf("value_%d", i))
 
@@ -67,3 +67,94 @@ func TestStoreCache(t *testing.T) {
 		require.Nil(t, store.Get(key))
 	}
 }
+
+func newTestStore(t *testing.T) types.CommitKVStore {
+	tree, err := iavl.NewMutableTree(dbm.NewMemDB(), 100)
+	require.NoError(t, err)
+	return iavlstore.UnsafeNewStore(tree)
+}
+
+func storeStats(mngr *cache.CommitKVStoreCacheManager, name string) cache.Stats {
+	for _, s := range mngr.Stats() {
+		if s.Store == name {
+			return s
+		}
+	}
+	return cache.Stats{}
+}
+
+func TestStoreCacheSizeInBytes(t *testing.T) {
+	// each entry is 10 bytes, so the cache holds 3 entries
+	mngr := cache.NewCommitKVStoreCacheManagerWithConfig(cache.Config{DefaultBytes: 35})
+	sKey := types.NewKVStoreKey("test")
+	store := newTestStore(t)
+	kvStore := mngr.GetStoreCache(sKey, store)
+
+	for i := 0; i < 4; i++ {
+		store.Set([]byte(fmt.Sprintf("key_%d", i)), []byte(fmt.Sprintf("val_%d", i)))
+	}
+	for i := 0; i < 4; i++ {
+		kvStore.Get([]byte(fmt.Sprintf("key_%d", i)))
+	}
+	require.Equal(t, cache.Stats{
+		Store: "test", Capacity: 35, Size: 30, Entries: 3, Misses: 4, Evictions: 1,
+	}, storeStats(mngr, "test"))
+
+	// key_0 was evicted as the least recently used entry
+	kvStore.Get([]byte("key_3"))
+	kvStore.Get([]byte("key_0"))
+	s := storeStats(mngr, "test")
+	require.Equal(t, uint64(1), s.Hits)
+	require.Equal(t, uint64(5), s.Misses)
+	require.Equal(t, uint64(2), s.Evictions)
+
+	// a value larger than the cache is never cached
+	kvStore.Set([]byte("big"), make([]byte, 40))
+	require.Equal(t, make([]byte, 40), kvStore.Get([]byte("big")))
+	s = storeStats(mngr, "test")
+	require.Equal(t, uint64(3), s.Entries)
+	require.Equal(t, uint64(6), s.Misses)
+}
+
+func TestStoreCacheConfig(t *testing.T) {
+	mngr := cache.NewCommitKVStoreCacheManagerWithConfig(cache.Config{
+		DefaultBytes: 100,
+		StoreBytes:   map[string]uint64{"big": 1000, "disabled": 0},
+	})
+
+	store := newTestStore(t)
+	require.Equal(t, store, mngr.GetStoreCache(types.NewKVStoreKey("disabled"), store))
+	require.Nil(t, mngr.Unwrap(types.NewKVStoreKey("disabled")))
+
+	mngr.GetStoreCache(types.NewKVStoreKey("big"), newTestStore(t))
+	mngr.GetStoreCache(types.NewKVStoreKey("default"), newTestStore(t))
+	stats := mngr.Stats()
+	require.Len(t, stats, 2)
+	require.Equal(t, cache.Stats{Store: "big", Capacity: 1000}, stats[0])
+	require.Equal(t, cache.Stats{Store: "default", Capacity: 100}, stats[1])
+}
+
+func TestStoreCacheWarmUp(t *testing.T) {
+	mngr := cache.NewCommitKVStoreCacheManagerWithConfig(cache.Config{
+		DefaultBytes:   35,
+		WarmUpPrefixes: map[string][][]byte{"test": {[]byte("hot_"), []byte("key_")}, "unknown": {[]byte("a")}},
+	})
+	sKey := types.NewKVStoreKey("test")
+	store := newTestStore(t)
+	kvStore := mngr.GetStoreCache(sKey, store)
+
+	store.Set([]byte("hot_0"), []byte("val_0"))
+	store.Set([]byte("hot_1"), []byte("val_1"))
+	store.Set([]byte("key_0"), []byte("val_0"))
+	store.Set([]byte("key_1"), []byte("val_1"))
+	store.Set([]byte("other"), []byte("value"))
+
+	// the warm-up stops once the cache is full
+	require.Equal(t, 3, mngr.WarmUp())
+	require.Equal(t, []byte("val_1"), kvStore.Get([]byte("hot_1")))
+	require.Equal(t, []byte("val_0"), kvStore.Get([]byte("key_0")))
+	s := storeStats(mngr, "test")
+	require.Equal(t, uint64(3), s.Entries)
+	require.Equal(t, uint64(2), s.Hits)
+	require.Equal(t, uint64(0), s.Misses)
+}
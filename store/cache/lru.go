@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lruCache is a least recently used cache of values by key, bounded by the
+// bytes of its keys and values. It is safe for concurrent use.
+type lruCache struct {
+	mtx      sync.Mutex
+	capacity uint64
+	size     uint64
+	entries  map[string]*list.Element
+	order    *list.List // most recently used first
+
+	hits, misses, evictions uint64
+}
+
+type lruEntry struct {
+	key   string
+	value []byte
+}
+
+func entrySize(key string, value []byte) uint64 {
+	return uint64(len(key) + len(value))
+}
+
+func newLRUCache(capacity uint64) *lruCache {
+	return &lruCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// get returns the value cached for a key, counting a hit or a miss.
+func (c *lruCache) get(key string) ([]byte, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+
+	c.hits++
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry).value, true
+}
+
+// add caches the value of a key, evicting the least recently used entries
+// until the cache fits in its capacity. A value too large to ever fit is not
+// cached.
+func (c *lruCache) add(key string, value []byte) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.removeEntry(key)
+	if entrySize(key, value) > c.capacity {
+		return
+	}
+
+	c.insert(key, value)
+	for c.size > c.capacity {
+		c.removeElement(c.order.Back())
+		c.evictions++
+	}
+}
+
+// tryAdd caches the value of a key not cached yet if it fits in the remaining
+// capacity, without evicting any entry.
+func (c *lruCache) tryAdd(key string, value []byte) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if _, ok := c.entries[key]; ok {
+		return true
+	}
+	if c.size+entrySize(key, value) > c.capacity {
+		return false
+	}
+
+	// preloaded entries are less recently used than the ones already read
+	c.entries[key] = c.order.PushBack(&lruEntry{key: key, value: value})
+	c.size += entrySize(key, value)
+	return true
+}
+
+// remove removes the value of a key from the cache.
+func (c *lruCache) remove(key string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.removeEntry(key)
+}
+
+// stats fills the size and counters of the cache into s.
+func (c *lruCache) stats(s *Stats) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	s.Capacity = c.capacity
+	s.Size = c.size
+	s.Entries = uint64(len(c.entries))
+	s.Hits = c.hits
+	s.Misses = c.misses
+	s.Evictions = c.evictions
+}
+
+func (c *lruCache) insert(key string, value []byte) {
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value})
+	c.size += entrySize(key, value)
+}
+
+func (c *lruCache) removeEntry(key string) {
+	if elem, ok := c.entries[key]; ok {
+		c.removeElement(elem)
+	}
+}
+
+func (c *lruCache) removeElement(elem *list.Element) {
+	entry := c.order.Remove(elem).(*lruEntry)
+	delete(c.entries, entry.key)
+	c.size -= entrySize(entry.key, entry.value)
+}
@@ -1,43 +1,52 @@
 package cachekv
 
 import (
-	"container/list"
+	"bytes"
 	"errors"
 
-	tmkv "github.com/tendermint/tendermint/libs/kv"
-	dbm "github.com/tendermint/tm-db"
+	"github.com/google/btree"
 )
 
-// Iterates over iterKVCache items.
-// if key is nil, means it was deleted.
+const (
+	memIteratorMinBatchSize = 1
+	memIteratorMaxBatchSize = 256
+)
+
+// Iterates over the dirty items of a snapshot of the cache. It seeks into the
+// B-tree of the snapshot by batches of items, growing up to
+// memIteratorMaxBatchSize, so that only the visited part of the domain is
+// traversed.
+// if value is nil, means it was deleted.
 // Implements Iterator.
 type memIterator struct {
 	start, end []byte
-	items      []*tmkv.Pair
+	items      *btree.BTree
 	ascending  bool
-}
 
-func newMemIterator(start, end []byte, items *list.List, ascending bool) *memIterator {
-	itemsInDomain := make([]*tmkv.Pair, 0)
-	var entered bool
-	for e := items.Front(); e != nil; e = e.Next() {
-		item := e.Value.(*tmkv.Pair)
-		if !dbm.IsKeyInDomain(item.Key, start, end) {
-			if entered {
-				break
-			}
-			continue
-		}
-		itemsInDomain = append(itemsInDomain, item)
-		entered = true
-	}
+	batch     []*cValue // The current batch of dirty items in the domain
+	pos       int       // Position of the current item in the batch
+	batchSize int       // Maximum size of the next batch
+	exhausted bool      // True once the last batch of the domain is fetched
+	pivot     cValue    // Key the current batch was fetched from
+}
 
-	return &memIterator{
+func newMemIterator(start, end []byte, items *btree.BTree, ascending bool) *memIterator {
+	mi := &memIterator{
 		start:     start,
 		end:       end,
-		items:     itemsInDomain,
+		items:     items,
 		ascending: ascending,
+		batchSize: memIteratorMinBatchSize,
 	}
+
+	if ascending {
+		mi.fetch(start, true)
+	} else {
+		// the end of the domain is exclusive
+		mi.fetch(end, false)
+	}
+
+	return mi
 }
 
 func (mi *memIterator) Domain() ([]byte, []byte) {
@@ -45,7 +54,7 @@ func (mi *memIterator) Domain() ([]byte, []byte) {
 }
 
 func (mi *memIterator) Valid() bool {
-	return len(mi.items) > 0
+	return mi.pos < len(mi.batch)
 }
 
 func (mi *memIterator) assertValid() {
@@ -56,33 +65,29 @@ func (mi *memIterator) assertValid() {
 
 func (mi *memIterator) Next() {
 	mi.assertValid()
-	if mi.ascending {
-		mi.items = mi.items[1:]
-	} else {
-		mi.items = mi.items[:len(mi.items)-1]
+
+	mi.pos++
+	if mi.pos == len(mi.batch) && !mi.exhausted {
+		mi.fetch(mi.batch[mi.pos-1].key, false)
 	}
 }
 
 func (mi *memIterator) Key() []byte {
 	mi.assertValid()
-	if mi.ascending {
-		return mi.items[0].Key
-	}
-	return mi.items[len(mi.items)-1].Key
+	return mi.batch[mi.pos].key
 }
 
 func (mi *memIterator) Value() []byte {
 	mi.assertValid()
-	if mi.ascending {
-		return mi.items[0].Value
-	}
-	return mi.items[len(mi.items)-1].Value
+	return mi.batch[mi.pos].value
 }
 
 func (mi *memIterator) Close() {
 	mi.start = nil
 	mi.end = nil
 	mi.items = nil
+	mi.batch = nil
+	mi.pos = 0
 }
 
 // Error returns an error if the memIterator is invalid defined by the Valid
@@ -94,3 +99,51 @@ func (mi *memIterator) Error() error {
 
 	return nil
 }
+
+// fetch collects the next batch of dirty items in the domain, starting from
+// the given key in the iteration order, or from the first item if it is nil.
+func (mi *memIterator) fetch(from []byte, inclusive bool) {
+	if cap(mi.batch) < mi.batchSize {
+		mi.batch = make([]*cValue, 0, mi.batchSize)
+	} else {
+		mi.batch = mi.batch[:0]
+	}
+	mi.pos = 0
+	if mi.batchSize < memIteratorMaxBatchSize {
+		mi.batchSize *= 2
+	}
+
+	mi.exhausted = true
+	collect := func(i btree.Item) bool {
+		item := i.(*cValue)
+		switch {
+		case !inclusive && from != nil && bytes.Equal(item.key, from):
+			return true
+		case mi.ascending && mi.end != nil && bytes.Compare(item.key, mi.end) >= 0:
+			return false
+		case !mi.ascending && mi.start != nil && bytes.Compare(item.key, mi.start) < 0:
+			return false
+		case !item.dirty:
+			return true
+		case len(mi.batch) == cap(mi.batch):
+			mi.exhausted = false
+			return false
+		}
+
+		mi.batch = append(mi.batch, item)
+		return true
+	}
+
+	mi.pivot.key = from
+	switch {
+	case mi.ascending && from == nil:
+		mi.items.Ascend(collect)
+	case mi.ascending:
+		mi.items.AscendGreaterOrEqual(&mi.pivot, collect)
+	case from == nil:
+		mi.items.Descend(collect)
+	default:
+		mi.items.DescendLessOrEqual(&mi.pivot, collect)
+	}
+	mi.pivot.key = nil
+}
@@ -13,11 +13,16 @@ import (
 // If the cache iterator has the same key as the parent, the
 // cache shadows (overrides) the parent.
 //
-// TODO: Optimize by memoizing.
+// Whether the current item exists is memoized until the next call to Next, so
+// that the iterators of nested caches, which merge the iterators of their
+// parents, only cost O(depth) per item.
 type cacheMergeIterator struct {
 	parent    types.Iterator
 	cache     types.Iterator
 	ascending bool
+
+	settled bool // True once the current item is found, until Next
+	valid   bool // Memoized validity of the current item, if settled
 }
 
 var _ types.Iterator = (*cacheMergeIterator)(nil)
@@ -59,6 +64,8 @@ func (iter *cacheMergeIterator) Next() {
 	iter.skipUntilExistsOrInvalid()
 	iter.assertValid()
 
+	iter.settled = false
+
 	// If parent is invalid, get the next cache item.
 	if !iter.parent.Valid() {
 		iter.cache.Next()
@@ -146,6 +153,7 @@ func (iter *cacheMergeIterator) Value() []byte {
 
 // Close implements Iterator
 func (iter *cacheMergeIterator) Close() {
+	iter.settled = false
 	iter.parent.Close()
 	iter.cache.Close()
 }
@@ -194,6 +202,15 @@ func (iter *cacheMergeIterator) skipCacheDeletes(until []byte) {
 // item exists, or until iterator becomes invalid.
 // Returns whether the iterator is valid.
 func (iter *cacheMergeIterator) skipUntilExistsOrInvalid() bool {
+	if !iter.settled {
+		iter.valid = iter.skipUntilExists()
+		iter.settled = true
+	}
+
+	return iter.valid
+}
+
+func (iter *cacheMergeIterator) skipUntilExists() bool {
 	for {
 		// If parent is invalid, fast-forward cache.
 		if !iter.parent.Valid() {
@@ -2,15 +2,10 @@ package cachekv
 
 import (
 	"bytes"
-	"container/list"
 	"io"
-	"reflect"
-	"sort"
 	"sync"
-	"unsafe"
 
-	tmkv "github.com/tendermint/tendermint/libs/kv"
-	dbm "github.com/tendermint/tm-db"
+	"github.com/google/btree"
 
 	"github.com/cosmos/cosmos-sdk/store/tracekv"
 	"github.com/cosmos/cosmos-sdk/store/types"
@@ -19,28 +14,39 @@ import (
 // If value is nil but deleted is false, it means the parent doesn't have the
 // key.  (No need to delete upon Write())
 type cValue struct {
+	key     []byte
 	value   []byte
 	deleted bool
 	dirty   bool
 }
 
+// Less implements btree.Item.
+func (cv *cValue) Less(than btree.Item) bool {
+	return bytes.Compare(cv.key, than.(*cValue).key) < 0
+}
+
+// cacheDegree is the degree of the B-tree of the cache.
+const cacheDegree = 32
+
 // Store wraps an in-memory cache around an underlying types.KVStore.
+//
+// The cache is an ordered B-tree, so that dirty items never have to be sorted.
+// Iterators work on a copy-on-write clone of the tree, which is O(1), and seek
+// into it in O(log n), so that iterating over deeply nested caches only costs
+// O(log n) per level instead of sorting and scanning all their items.
 type Store struct {
-	mtx           sync.Mutex
-	cache         map[string]*cValue
-	unsortedCache map[string]struct{}
-	sortedCache   *list.List // always ascending sorted
-	parent        types.KVStore
+	mtx    sync.Mutex
+	cache  *btree.BTree // of *cValue, always ascending sorted
+	pivot  cValue       // reused to look up keys in the cache
+	parent types.KVStore
 }
 
 var _ types.CacheKVStore = (*Store)(nil)
 
 func NewStore(parent types.KVStore) *Store {
 	return &Store{
-		cache:         make(map[string]*cValue),
-		unsortedCache: make(map[string]struct{}),
-		sortedCache:   list.New(),
-		parent:        parent,
+		cache:  btree.New(cacheDegree),
+		parent: parent,
 	}
 }
 
@@ -56,8 +62,8 @@ func (store *Store) Get(key []byte) (value []byte) {
 
 	types.AssertValidKey(key)
 
-	cacheValue, ok := store.cache[string(key)]
-	if !ok {
+	cacheValue := store.getCacheValue(key)
+	if cacheValue == nil {
 		value = store.parent.Get(key)
 		store.setCacheValue(key, value, false, false)
 	} else {
@@ -99,35 +105,26 @@ func (store *Store) Write() {
 	store.mtx.Lock()
 	defer store.mtx.Unlock()
 
-	// We need a copy of all of the keys.
-	// Not the best, but probably not a bottleneck depending.
-	keys := make([]string, 0, len(store.cache))
-	for key, dbValue := range store.cache {
-		if dbValue.dirty {
-			keys = append(keys, key)
-		}
-	}
-
-	sort.Strings(keys)
-
+	// The cache is sorted, so dirty items are written in ascending order.
 	// TODO: Consider allowing usage of Batch, which would allow the write to
 	// at least happen atomically.
-	for _, key := range keys {
-		cacheValue := store.cache[key]
+	store.cache.Ascend(func(i btree.Item) bool {
+		cacheValue := i.(*cValue)
 		switch {
+		case !cacheValue.dirty:
+			// Skip, it was only read from parent.
 		case cacheValue.deleted:
-			store.parent.Delete([]byte(key))
+			store.parent.Delete(cacheValue.key)
 		case cacheValue.value == nil:
 			// Skip, it already doesn't exist in parent.
 		default:
-			store.parent.Set([]byte(key), cacheValue.value)
+			store.parent.Set(cacheValue.key, cacheValue.value)
 		}
-	}
+		return true
+	})
 
 	// Clear the cache
-	store.cache = make(map[string]*cValue)
-	store.unsortedCache = make(map[string]struct{})
-	store.sortedCache = list.New()
+	store.cache = btree.New(cacheDegree)
 }
 
 //----------------------------------------
@@ -168,94 +165,35 @@ func (store *Store) iterator(start, end []byte, ascending bool) types.Iterator {
 		parent = store.parent.ReverseIterator(start, end)
 	}
 
-	store.dirtyItems(start, end)
-	cache = newMemIterator(start, end, store.sortedCache, ascending)
+	// the clone is a snapshot of the cache, which is not affected by later
+	// writes to the store
+	cache = newMemIterator(start, end, store.cache.Clone(), ascending)
 
 	return newCacheMergeIterator(parent, cache, ascending)
 }
 
-// strToByte is meant to make a zero allocation conversion
-// from string -> []byte to speed up operations, it is not meant
-// to be used generally, but for a specific pattern to check for available
-// keys within a domain.
-func strToByte(s string) []byte {
-	var b []byte
-	hdr := (*reflect.SliceHeader)(unsafe.Pointer(&b))
-	hdr.Cap = len(s)
-	hdr.Len = len(s)
-	hdr.Data = (*reflect.StringHeader)(unsafe.Pointer(&s)).Data
-	return b
-}
-
-// byteSliceToStr is meant to make a zero allocation conversion
-// from []byte -> string to speed up operations, it is not meant
-// to be used generally, but for a specific pattern to delete keys
-// from a map.
-func byteSliceToStr(b []byte) string {
-	hdr := (*reflect.StringHeader)(unsafe.Pointer(&b))
-	return *(*string)(unsafe.Pointer(hdr))
-}
-
-// Constructs a slice of dirty items, to use w/ memIterator.
-func (store *Store) dirtyItems(start, end []byte) {
-	unsorted := make([]*tmkv.Pair, 0)
-
-	n := len(store.unsortedCache)
-	for key := range store.unsortedCache {
-		if dbm.IsKeyInDomain(strToByte(key), start, end) {
-			cacheValue := store.cache[key]
-			unsorted = append(unsorted, &tmkv.Pair{Key: []byte(key), Value: cacheValue.value})
-		}
-	}
-
-	if len(unsorted) == n { // This pattern allows the Go compiler to emit the map clearing idiom for the entire map.
-		for key := range store.unsortedCache {
-			delete(store.unsortedCache, key)
-		}
-	} else { // Otherwise, normally delete the unsorted keys from the map.
-		for _, kv := range unsorted {
-			delete(store.unsortedCache, byteSliceToStr(kv.Key))
-		}
-	}
-
-	sort.Slice(unsorted, func(i, j int) bool {
-		return bytes.Compare(unsorted[i].Key, unsorted[j].Key) < 0
-	})
+//----------------------------------------
+// etc
 
-	for e := store.sortedCache.Front(); e != nil && len(unsorted) != 0; {
-		uitem := unsorted[0]
-		sitem := e.Value.(*tmkv.Pair)
-		comp := bytes.Compare(uitem.Key, sitem.Key)
-		switch comp {
-		case -1:
-			unsorted = unsorted[1:]
-			store.sortedCache.InsertBefore(uitem, e)
-		case 1:
-			e = e.Next()
-		case 0:
-			unsorted = unsorted[1:]
-			e.Value = uitem
-			e = e.Next()
-		}
-	}
+// getCacheValue returns the cached value of a key, or nil if it isn't cached.
+func (store *Store) getCacheValue(key []byte) *cValue {
+	store.pivot.key = key
+	item := store.cache.Get(&store.pivot)
+	store.pivot.key = nil
 
-	for _, kvp := range unsorted {
-		store.sortedCache.PushBack(kvp)
+	if item == nil {
+		return nil
 	}
-
+	return item.(*cValue)
 }
 
-//----------------------------------------
-// etc
-
-// Only entrypoint to mutate store.cache.
+// Only entrypoint to mutate store.cache. Cached values are never modified in
+// place, as they may be shared with the clones of the cache held by iterators.
 func (store *Store) setCacheValue(key, value []byte, deleted bool, dirty bool) {
-	store.cache[string(key)] = &cValue{
+	store.cache.ReplaceOrInsert(&cValue{
+		key:     types.Cp(key),
 		value:   value,
 		deleted: deleted,
 		dirty:   dirty,
-	}
-	if dirty {
-		store.unsortedCache[string(key)] = struct{}{}
-	}
+	})
 }
@@ -2,6 +2,7 @@ package cachekv_test
 
 import (
 	"crypto/rand"
+	"fmt"
 	"sort"
 	"testing"
 
@@ -9,6 +10,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/store/cachekv"
 	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	"github.com/cosmos/cosmos-sdk/store/types"
 )
 
 func benchmarkCacheKVStoreIterator(numKVs int, b *testing.B) {
@@ -44,3 +46,42 @@ func BenchmarkCacheKVStoreIterator1000(b *testing.B)   { benchmarkCacheKVStoreIt
 func BenchmarkCacheKVStoreIterator10000(b *testing.B)  { benchmarkCacheKVStoreIterator(10000, b) }
 func BenchmarkCacheKVStoreIterator50000(b *testing.B)  { benchmarkCacheKVStoreIterator(50000, b) }
 func BenchmarkCacheKVStoreIterator100000(b *testing.B) { benchmarkCacheKVStoreIterator(100000, b) }
+
+// benchmarkCacheKVStoreNestedIterator iterates over a prefix of the top of a
+// stack of nested caches, each one with a few dirty keys, as the ante handler,
+// the msgs and the nested calls of a tx do.
+func benchmarkCacheKVStoreNestedIterator(depth int, b *testing.B) {
+	const (
+		numKVs      = 10000
+		dirtyPerLvl = 10
+	)
+
+	mem := dbadapter.Store{DB: dbm.NewMemDB()}
+	var store types.CacheKVStore = cachekv.NewStore(mem)
+	for i := 0; i < numKVs; i++ {
+		store.Set([]byte(fmt.Sprintf("key%08d", i)), []byte("value"))
+	}
+
+	for d := 0; d < depth; d++ {
+		store = store.CacheWrap().(types.CacheKVStore)
+		for i := 0; i < dirtyPerLvl; i++ {
+			store.Set([]byte(fmt.Sprintf("key%08d", (d*dirtyPerLvl+i)*97%numKVs)), []byte("dirty"))
+		}
+	}
+
+	prefix := []byte("key000012")
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		// a write between iterations, like a keeper updating what it iterates
+		store.Set([]byte(fmt.Sprintf("key%08d", n%numKVs)), []byte("value"))
+
+		iter := store.Iterator(prefix, types.PrefixEndBytes(prefix))
+		for ; iter.Valid(); iter.Next() {
+		}
+		iter.Close()
+	}
+}
+
+func BenchmarkCacheKVStoreNestedIterator1(b *testing.B)  { benchmarkCacheKVStoreNestedIterator(1, b) }
+func BenchmarkCacheKVStoreNestedIterator4(b *testing.B)  { benchmarkCacheKVStoreNestedIterator(4, b) }
+func BenchmarkCacheKVStoreNestedIterator16(b *testing.B) { benchmarkCacheKVStoreNestedIterator(16, b) }
@@ -0,0 +1,172 @@
+package cachekv_test
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/cachekv"
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// modelStore is the reference model of a cache: the whole key-value state as
+// seen through it.
+type modelStore map[string][]byte
+
+func (m modelStore) clone() modelStore {
+	c := make(modelStore, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+func (m modelStore) iterate(start, end []byte, ascending bool) (pairs [][2][]byte) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		if dbm.IsKeyInDomain([]byte(k), start, end) {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+	if !ascending {
+		for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+			keys[i], keys[j] = keys[j], keys[i]
+		}
+	}
+
+	for _, k := range keys {
+		pairs = append(pairs, [2][]byte{[]byte(k), m[k]})
+	}
+	return pairs
+}
+
+func iteratePairs(st types.KVStore, start, end []byte, ascending bool) (pairs [][2][]byte) {
+	var iter types.Iterator
+	if ascending {
+		iter = st.Iterator(start, end)
+	} else {
+		iter = st.ReverseIterator(start, end)
+	}
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		pairs = append(pairs, [2][]byte{iter.Key(), iter.Value()})
+	}
+	return pairs
+}
+
+// TestCacheKVStoreFuzz runs random operations on a stack of nested caches and
+// checks them against a reference model of each cache.
+func TestCacheKVStoreFuzz(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		t.Run(fmt.Sprintf("seed=%d", seed), func(t *testing.T) {
+			fuzzCacheKVStore(t, rand.New(rand.NewSource(seed)), 2000)
+		})
+	}
+}
+
+func fuzzCacheKVStore(t *testing.T, r *rand.Rand, steps int) {
+	const (
+		maxKey   = 200
+		maxDepth = 6
+	)
+
+	randKey := func() []byte {
+		// short keys often are prefixes of other keys
+		return []byte(fmt.Sprintf("%x", r.Intn(maxKey)))
+	}
+	randBound := func() []byte {
+		if r.Intn(5) == 0 {
+			return nil
+		}
+		return randKey()
+	}
+
+	mem := dbadapter.Store{DB: dbm.NewMemDB()}
+	stores := []types.CacheKVStore{cachekv.NewStore(mem)}
+	models := []modelStore{{}}
+
+	for step := 0; step < steps; step++ {
+		top := len(stores) - 1
+		st, model := stores[top], models[top]
+
+		switch op := r.Intn(10); {
+		case op < 3:
+			key, value := randKey(), []byte(fmt.Sprintf("value%d", step))
+			st.Set(key, value)
+			model[string(key)] = value
+
+		case op < 5:
+			key := randKey()
+			st.Delete(key)
+			delete(model, string(key))
+
+		case op < 6:
+			key := randKey()
+			require.Equal(t, model[string(key)], st.Get(key), "step %d: get %s", step, key)
+
+		case op < 8:
+			start, end := randBound(), randBound()
+			if start != nil && end != nil && bytes.Compare(start, end) > 0 {
+				start, end = end, start
+			}
+			ascending := r.Intn(2) == 0
+			require.Equal(t, model.iterate(start, end, ascending), iteratePairs(st, start, end, ascending),
+				"step %d: iterate [%s, %s) ascending %v", step, start, end, ascending)
+
+		case op < 9 && len(stores) < maxDepth:
+			stores = append(stores, st.CacheWrap().(types.CacheKVStore))
+			models = append(models, model.clone())
+
+		case top > 0:
+			// write or discard the top cache
+			if r.Intn(3) > 0 {
+				st.Write()
+				models[top-1] = model
+			}
+			stores, models = stores[:top], models[:top]
+
+		default:
+			st.Write()
+		}
+	}
+
+	// writing all the caches down the stack writes the state of the top one
+	for top := len(stores) - 1; top >= 0; top-- {
+		stores[top].Write()
+	}
+	require.Equal(t, models[len(models)-1].iterate(nil, nil, true), iteratePairs(mem, nil, nil, true))
+}
+
+// TestCacheKVStoreIterateWhileWriting checks that iterators are not affected by
+// writes to the cache made during the iteration.
+func TestCacheKVStoreIterateWhileWriting(t *testing.T) {
+	st := newCacheKVStore()
+	for i := 0; i < 100; i++ {
+		st.Set(keyFmt(i), valFmt(i))
+	}
+
+	iter := st.Iterator(nil, nil)
+	i := 0
+	for ; iter.Valid(); iter.Next() {
+		require.Equal(t, keyFmt(i), iter.Key())
+		require.Equal(t, valFmt(i), iter.Value())
+
+		st.Delete(keyFmt(i + 1))
+		st.Set(keyFmt(i+1000), valFmt(i))
+		if i%10 == 0 {
+			st.Write()
+		}
+		i++
+	}
+	iter.Close()
+	require.Equal(t, 100, i)
+}
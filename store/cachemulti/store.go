@@ -8,6 +8,9 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/store/cachekv"
 	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	"github.com/cosmos/cosmos-sdk/store/listenkv"
+	"github.com/cosmos/cosmos-sdk/store/metrics"
+	"github.com/cosmos/cosmos-sdk/store/transient"
 	"github.com/cosmos/cosmos-sdk/store/types"
 )
 
@@ -25,9 +28,16 @@ type Store struct {
 
 	traceWriter  io.Writer
 	traceContext types.TraceContext
+
+	metrics *metrics.Collector
+	limiter *transient.Limiter
 }
 
-var _ types.CacheMultiStore = Store{}
+var (
+	_ metrics.CacheMultiStore   = Store{}
+	_ listenkv.CacheMultiStore  = Store{}
+	_ transient.CacheMultiStore = Store{}
+)
 
 // NewFromKVStore creates a new Store object from a mapping of store keys to
 // CacheWrapper objects and a KVStore as the database. Each CacheWrapper store
@@ -71,7 +81,10 @@ func newCacheMultiStoreFromCMS(cms Store) Store {
 		stores[k] = v
 	}
 
-	return NewFromKVStore(cms.db, stores, nil, cms.traceWriter, cms.traceContext)
+	store := NewFromKVStore(cms.db, stores, nil, cms.traceWriter, cms.traceContext)
+	store.metrics = cms.metrics
+	store.limiter = cms.limiter
+	return store
 }
 
 // SetTracer sets the tracer for the MultiStore that the underlying
@@ -102,6 +115,33 @@ func (cms Store) TracingEnabled() bool {
 	return cms.traceWriter != nil
 }
 
+// SetMetrics returns a copy of the Store whose KVStores, and the ones of the
+// stores cache-wrapping it, report their operations to the given collector.
+func (cms Store) SetMetrics(collector *metrics.Collector) types.CacheMultiStore {
+	cms.metrics = collector
+	return cms
+}
+
+// SetLimiter returns a copy of the Store whose transient KVStores count their
+// writes into the given limiter.
+func (cms Store) SetLimiter(limiter *transient.Limiter) types.CacheMultiStore {
+	cms.limiter = limiter
+	return cms
+}
+
+// SetWriteListener returns a copy of the Store whose KVStores notify the given
+// listener of their writes. The stores cache-wrapping it don't notify the
+// listener themselves, but their writes are notified when they are written.
+func (cms Store) SetWriteListener(listener types.WriteListener) types.CacheMultiStore {
+	stores := make(map[types.StoreKey]types.CacheWrap, len(cms.stores))
+	for key, store := range cms.stores {
+		stores[key] = listenkv.NewStore(store.(types.KVStore), key, listener)
+	}
+
+	cms.stores = stores
+	return cms
+}
+
 // GetStoreType returns the type of the store.
 func (cms Store) GetStoreType() types.StoreType {
 	return types.StoreTypeMulti
@@ -150,5 +190,12 @@ func (cms Store) GetKVStore(key types.StoreKey) types.KVStore {
 	if key == nil {
 		panic(fmt.Sprintf("kv store with key %v has not been registered in stores", key))
 	}
-	return store.(types.KVStore)
+	kvStore := store.(types.KVStore)
+	if _, ok := key.(*types.TransientStoreKey); ok && cms.limiter != nil {
+		kvStore = transient.NewLimitedStore(kvStore, key.Name(), cms.limiter)
+	}
+	if cms.metrics != nil {
+		return metrics.NewStore(kvStore, key.Name(), cms.metrics)
+	}
+	return kvStore
 }
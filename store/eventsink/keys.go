@@ -0,0 +1,95 @@
+package eventsink
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The keys of the sink database. Events are stored by position, i.e. height
+// and sequence in the block, and each index maps its fields followed by the
+// position to an empty value, so that the events of an index entry are ordered
+// by height.
+var (
+	lastHeightKey = []byte{0x00}
+
+	eventPrefix     = []byte{0x01} // position -> event
+	typeIndexPrefix = []byte{0x02} // type, position
+	attrIndexPrefix = []byte{0x03} // type, attribute key, attribute value, position
+	modIndexPrefix  = []byte{0x04} // module, position
+)
+
+const positionLen = 8 + 4
+
+// position locates an event in the sink: the height of its block, and its
+// index among the events of the block.
+type position struct {
+	height int64
+	seq    uint32
+}
+
+func (p position) bytes() []byte {
+	bz := make([]byte, positionLen)
+	binary.BigEndian.PutUint64(bz, uint64(p.height))
+	binary.BigEndian.PutUint32(bz[8:], p.seq)
+	return bz
+}
+
+// next returns the position following p.
+func (p position) next() position {
+	if p.seq == ^uint32(0) {
+		return position{height: p.height + 1}
+	}
+	return position{height: p.height, seq: p.seq + 1}
+}
+
+// cursor returns the cursor of the search results following p.
+func (p position) cursor() string {
+	return fmt.Sprintf("%d.%d", p.height, p.seq)
+}
+
+func parsePosition(bz []byte) position {
+	return position{
+		height: int64(binary.BigEndian.Uint64(bz)),
+		seq:    binary.BigEndian.Uint32(bz[8:]),
+	}
+}
+
+func parseCursor(cursor string) (position, error) {
+	parts := strings.Split(cursor, ".")
+	if len(parts) != 2 {
+		return position{}, fmt.Errorf("invalid cursor %q", cursor)
+	}
+
+	height, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || height < 0 {
+		return position{}, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	seq, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return position{}, fmt.Errorf("invalid cursor %q", cursor)
+	}
+
+	return position{height: height, seq: uint32(seq)}, nil
+}
+
+// indexKey returns the prefix of an index followed by its length prefixed
+// fields, which keeps the prefixes of different field values disjoint.
+func indexKey(prefix []byte, fields ...string) []byte {
+	key := append([]byte{}, prefix...)
+	for _, field := range fields {
+		key = appendLengthPrefixed(key, field)
+	}
+	return key
+}
+
+func appendLengthPrefixed(bz []byte, s string) []byte {
+	var lenBz [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lenBz[:], uint64(len(s)))
+	return append(append(bz, lenBz[:n]...), s...)
+}
+
+func eventKey(p position) []byte {
+	return append(append([]byte{}, eventPrefix...), p.bytes()...)
+}
@@ -0,0 +1,177 @@
+package eventsink
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+const (
+	// DefaultLimit is the number of events returned by Search if the query
+	// doesn't set a limit.
+	DefaultLimit = 100
+
+	// MaxLimit is the maximum number of events returned by Search.
+	MaxLimit = 1000
+
+	// MaxScan is the maximum number of index entries scanned by a call to
+	// Search, which bounds the cost of the queries whose filters match few of
+	// the entries of the index serving them.
+	MaxScan = 10000
+)
+
+// Query selects the events matching all its set fields, between MinHeight and
+// MaxHeight inclusive, with MaxHeight 0 meaning no upper bound. Attribute
+// filters require the event type, since the attributes are indexed by event
+// type. Cursor resumes a search after the last event it returned.
+type Query struct {
+	Type       string      `json:"type"`
+	Module     string      `json:"module"`
+	Attributes []Attribute `json:"attributes"`
+	MinHeight  int64       `json:"min_height"`
+	MaxHeight  int64       `json:"max_height"`
+	Cursor     string      `json:"cursor"`
+	Limit      int         `json:"limit"`
+}
+
+// SearchResult holds the events found by Search, ordered by height and then by
+// emission. If the search stopped at the limit, or after scanning MaxScan index
+// entries, Next is the cursor to resume it. A search stopped by the scan cap may
+// return fewer events than the limit, or none, with more matching events after
+// Next.
+type SearchResult struct {
+	Events []Event `json:"events"`
+	Next   string  `json:"next,omitempty"`
+}
+
+// ValidateBasic checks that a query can be served by the indexes of the sink.
+func (q Query) ValidateBasic() error {
+	if len(q.Attributes) > 0 && q.Type == "" {
+		return errors.New("attribute filters require the event type")
+	}
+	if q.MinHeight < 0 || q.MaxHeight < 0 {
+		return errors.New("heights must not be negative")
+	}
+	if q.Limit < 0 || q.Limit > MaxLimit {
+		return fmt.Errorf("limit must be between 0 and %d", MaxLimit)
+	}
+	return nil
+}
+
+func (q Query) matches(e Event) bool {
+	if (q.Type != "" && e.Type != q.Type) || (q.Module != "" && e.Module != q.Module) {
+		return false
+	}
+
+	for _, filter := range q.Attributes {
+		found := false
+		for _, attr := range e.Attributes {
+			if attr == filter {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// indexPrefix returns the prefix of the most selective index serving the
+// query: the first attribute filter, the event type, the module or else all
+// the events.
+func (q Query) indexPrefix() []byte {
+	switch {
+	case len(q.Attributes) > 0:
+		return indexKey(attrIndexPrefix, q.Type, q.Attributes[0].Key, q.Attributes[0].Value)
+	case q.Type != "":
+		return indexKey(typeIndexPrefix, q.Type)
+	case q.Module != "":
+		return indexKey(modIndexPrefix, q.Module)
+	default:
+		return eventPrefix
+	}
+}
+
+// Search returns the indexed events matching a query. The index serving the
+// query is scanned over the height range, up to MaxScan entries, and the events
+// are filtered by the remaining fields of the query.
+func (s *Sink) Search(q Query) (SearchResult, error) {
+	if err := q.ValidateBasic(); err != nil {
+		return SearchResult{}, err
+	}
+	limit := q.Limit
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+
+	start := position{height: q.MinHeight}
+	if q.Cursor != "" {
+		after, err := parseCursor(q.Cursor)
+		if err != nil {
+			return SearchResult{}, err
+		}
+		if after.height >= start.height {
+			start = after.next()
+		}
+	}
+
+	prefix := q.indexPrefix()
+	startKey := append(append([]byte{}, prefix...), start.bytes()...)
+	endKey := types.PrefixEndBytes(prefix)
+	if q.MaxHeight > 0 {
+		if q.MaxHeight < start.height {
+			return SearchResult{Events: []Event{}}, nil
+		}
+		endKey = append(append([]byte{}, prefix...), position{height: q.MaxHeight + 1}.bytes()...)
+	}
+
+	iter, err := s.db.Iterator(startKey, endKey)
+	if err != nil {
+		return SearchResult{}, err
+	}
+	defer iter.Close()
+
+	res := SearchResult{Events: []Event{}}
+	for scanned := 1; iter.Valid(); iter.Next() {
+		key := iter.Key()
+		pos := parsePosition(key[len(key)-positionLen:])
+
+		bz := iter.Value()
+		if q.indexed() {
+			if bz, err = s.db.Get(eventKey(pos)); err != nil {
+				return SearchResult{}, err
+			}
+		}
+
+		var e Event
+		if err := cdc.UnmarshalBinaryBare(bz, &e); err != nil {
+			return SearchResult{}, fmt.Errorf("invalid event at height %d: %w", pos.height, err)
+		}
+		if q.matches(e) {
+			e.Cursor = pos.cursor()
+			res.Events = append(res.Events, e)
+			if len(res.Events) == limit {
+				res.Next = e.Cursor
+				break
+			}
+		}
+
+		if scanned == MaxScan {
+			res.Next = pos.cursor()
+			break
+		}
+		scanned++
+	}
+
+	return res, nil
+}
+
+// indexed returns whether the query is served by an index rather than by the
+// events themselves.
+func (q Query) indexed() bool {
+	return len(q.Attributes) > 0 || q.Type != "" || q.Module != ""
+}
@@ -0,0 +1,169 @@
+package eventsink
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// The sources of the indexed events.
+const (
+	SourceBeginBlock = "begin_block"
+	SourceTx         = "tx"
+	SourceEndBlock   = "end_block"
+)
+
+// attributeKeyModule is the attribute naming the module which emitted an
+// event, as set on the message events.
+const attributeKeyModule = "module"
+
+var cdc = codec.New()
+
+// Attribute is an attribute of an event.
+type Attribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Event is an event indexed by the sink, with the block and tx it was emitted
+// in. Module is the value of the module attribute of the event, if any. Cursor
+// is set on the events returned by Search.
+type Event struct {
+	Height     int64       `json:"height"`
+	Source     string      `json:"source"`
+	TxIndex    uint32      `json:"tx_index,omitempty"`
+	TxHash     string      `json:"tx_hash,omitempty"`
+	Module     string      `json:"module,omitempty"`
+	Type       string      `json:"type"`
+	Attributes []Attribute `json:"attributes"`
+	Cursor     string      `json:"cursor,omitempty"`
+}
+
+// Sink indexes the events of the blocks executed by an app in a database. It
+// is fed the responses of BeginBlock, DeliverTx and EndBlock, and writes the
+// events of a block atomically on Commit, along with its height. A sink opened
+// on an existing database resumes from the last height it indexed, ignoring
+// the blocks up to it, such as the ones replayed by Tendermint on restart.
+//
+// The events are fed and committed by the consensus goroutine, while Search is
+// safe to call concurrently.
+type Sink struct {
+	db         dbm.DB
+	lastHeight int64 // accessed atomically
+
+	// the events of the block being executed
+	height  int64
+	skip    bool
+	txIndex uint32
+	events  []Event
+}
+
+// NewSink returns a sink indexing events in db.
+func NewSink(db dbm.DB) (*Sink, error) {
+	bz, err := db.Get(lastHeightKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastHeight int64
+	if bz != nil {
+		if err := cdc.UnmarshalBinaryBare(bz, &lastHeight); err != nil {
+			return nil, fmt.Errorf("invalid last indexed height: %w", err)
+		}
+	}
+
+	return &Sink{db: db, lastHeight: lastHeight}, nil
+}
+
+// LastHeight returns the last height indexed by the sink, or 0 if none was.
+func (s *Sink) LastHeight() int64 {
+	return atomic.LoadInt64(&s.lastHeight)
+}
+
+// BeginBlock starts indexing a block with the events of its BeginBlock. The
+// blocks up to the last indexed height are ignored.
+func (s *Sink) BeginBlock(height int64, events []abci.Event) {
+	s.height = height
+	s.skip = height <= s.LastHeight()
+	s.txIndex = 0
+	s.events = s.events[:0]
+	s.add(SourceBeginBlock, "", events)
+}
+
+// DeliverTx indexes the events of the next tx of the block.
+func (s *Sink) DeliverTx(tx []byte, res abci.ResponseDeliverTx) {
+	if !s.skip && len(res.Events) > 0 {
+		s.add(SourceTx, fmt.Sprintf("%X", tmtypes.Tx(tx).Hash()), res.Events)
+	}
+	s.txIndex++
+}
+
+// EndBlock indexes the events of the EndBlock of the block.
+func (s *Sink) EndBlock(events []abci.Event) {
+	s.add(SourceEndBlock, "", events)
+}
+
+func (s *Sink) add(source, txHash string, events []abci.Event) {
+	if s.skip {
+		return
+	}
+
+	for _, event := range events {
+		e := Event{
+			Height:     s.height,
+			Source:     source,
+			Type:       event.Type,
+			Attributes: make([]Attribute, len(event.Attributes)),
+		}
+		if source == SourceTx {
+			e.TxIndex = s.txIndex
+			e.TxHash = txHash
+		}
+		for i, attr := range event.Attributes {
+			e.Attributes[i] = Attribute{Key: string(attr.Key), Value: string(attr.Value)}
+			if e.Attributes[i].Key == attributeKeyModule && e.Module == "" {
+				e.Module = e.Attributes[i].Value
+			}
+		}
+		s.events = append(s.events, e)
+	}
+}
+
+// Commit writes the events of the block and its height. Nothing is written if
+// the block was already indexed.
+func (s *Sink) Commit() error {
+	if s.skip {
+		return nil
+	}
+
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	for i, e := range s.events {
+		pos := position{height: s.height, seq: uint32(i)}
+		batch.Set(eventKey(pos), cdc.MustMarshalBinaryBare(e))
+
+		suffix := pos.bytes()
+		batch.Set(append(indexKey(typeIndexPrefix, e.Type), suffix...), []byte{})
+		if e.Module != "" {
+			batch.Set(append(indexKey(modIndexPrefix, e.Module), suffix...), []byte{})
+		}
+		for _, attr := range e.Attributes {
+			batch.Set(append(indexKey(attrIndexPrefix, e.Type, attr.Key, attr.Value), suffix...), []byte{})
+		}
+	}
+	batch.Set(lastHeightKey, cdc.MustMarshalBinaryBare(s.height))
+
+	if err := batch.WriteSync(); err != nil {
+		return fmt.Errorf("failed to index the events of block %d: %w", s.height, err)
+	}
+
+	atomic.StoreInt64(&s.lastHeight, s.height)
+	s.events = s.events[:0]
+	return nil
+}
@@ -0,0 +1,162 @@
+package eventsink
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/kv"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func newEvent(typ string, attrs ...string) abci.Event {
+	event := abci.Event{Type: typ}
+	for i := 0; i < len(attrs); i += 2 {
+		event.Attributes = append(event.Attributes, kv.Pair{Key: []byte(attrs[i]), Value: []byte(attrs[i+1])})
+	}
+	return event
+}
+
+// indexBlock feeds a block to the sink, whose txs each emit a transfer event
+// to a recipient.
+func indexBlock(t *testing.T, sink *Sink, height int64, recipients ...string) {
+	sink.BeginBlock(height, []abci.Event{newEvent("mint", "module", "mint", "amount", fmt.Sprint(height))})
+	for _, recipient := range recipients {
+		sink.DeliverTx([]byte(recipient+fmt.Sprint(height)), abci.ResponseDeliverTx{Events: []abci.Event{
+			newEvent("message", "action", "send", "module", "bank"),
+			newEvent("transfer", "recipient", recipient, "amount", "1"),
+		}})
+	}
+	sink.EndBlock([]abci.Event{newEvent("rewards", "height", fmt.Sprint(height))})
+	require.NoError(t, sink.Commit())
+}
+
+func heights(events []Event) []int64 {
+	hs := make([]int64, len(events))
+	for i, e := range events {
+		hs[i] = e.Height
+	}
+	return hs
+}
+
+func TestSinkIndexesBlocks(t *testing.T) {
+	sink, err := NewSink(dbm.NewMemDB())
+	require.NoError(t, err)
+	require.Equal(t, int64(0), sink.LastHeight())
+
+	indexBlock(t, sink, 1, "alice", "bob")
+	indexBlock(t, sink, 2)
+	indexBlock(t, sink, 3, "alice")
+	require.Equal(t, int64(3), sink.LastHeight())
+
+	res, err := sink.Search(Query{MaxHeight: 1})
+	require.NoError(t, err)
+	require.Len(t, res.Events, 6)
+	require.Equal(t, Event{
+		Height: 1, Source: SourceBeginBlock, Module: "mint", Type: "mint", Cursor: "1.0",
+		Attributes: []Attribute{{"module", "mint"}, {"amount", "1"}},
+	}, res.Events[0])
+	require.Equal(t, Event{
+		Height: 1, Source: SourceTx, TxIndex: 1, TxHash: fmt.Sprintf("%X", tmtypes.Tx("bob1").Hash()),
+		Type: "transfer", Cursor: "1.4", Attributes: []Attribute{{"recipient", "bob"}, {"amount", "1"}},
+	}, res.Events[4])
+	require.Equal(t, SourceEndBlock, res.Events[5].Source)
+
+	res, err = sink.Search(Query{Type: "transfer", Attributes: []Attribute{{"recipient", "alice"}}})
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 3}, heights(res.Events))
+	require.Equal(t, uint32(0), res.Events[0].TxIndex)
+
+	res, err = sink.Search(Query{Type: "transfer", Attributes: []Attribute{{"recipient", "alice"}}, MinHeight: 2})
+	require.NoError(t, err)
+	require.Equal(t, []int64{3}, heights(res.Events))
+
+	// all the attribute filters must match
+	res, err = sink.Search(Query{Type: "transfer", Attributes: []Attribute{{"recipient", "alice"}, {"amount", "2"}}})
+	require.NoError(t, err)
+	require.Empty(t, res.Events)
+
+	res, err = sink.Search(Query{Module: "bank"})
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 1, 3}, heights(res.Events))
+
+	res, err = sink.Search(Query{Type: "rewards", MinHeight: 2, MaxHeight: 2})
+	require.NoError(t, err)
+	require.Equal(t, []int64{2}, heights(res.Events))
+
+	_, err = sink.Search(Query{Attributes: []Attribute{{"recipient", "alice"}}})
+	require.Error(t, err)
+	_, err = sink.Search(Query{Limit: MaxLimit + 1})
+	require.Error(t, err)
+	_, err = sink.Search(Query{Cursor: "1"})
+	require.Error(t, err)
+}
+
+func TestSinkSearchCursor(t *testing.T) {
+	sink, err := NewSink(dbm.NewMemDB())
+	require.NoError(t, err)
+	for height := int64(1); height <= 10; height++ {
+		indexBlock(t, sink, height, "alice", "bob")
+	}
+
+	query := Query{Type: "transfer", Attributes: []Attribute{{"recipient", "bob"}}, MaxHeight: 9, Limit: 4}
+	var found []int64
+	for pages := 0; ; pages++ {
+		require.True(t, pages < 4)
+
+		res, err := sink.Search(query)
+		require.NoError(t, err)
+		found = append(found, heights(res.Events)...)
+		if res.Next == "" {
+			break
+		}
+		query.Cursor = res.Next
+	}
+	require.Equal(t, []int64{1, 2, 3, 4, 5, 6, 7, 8, 9}, found)
+}
+
+func TestSinkSearchScanCap(t *testing.T) {
+	sink, err := NewSink(dbm.NewMemDB())
+	require.NoError(t, err)
+	recipients := make([]string, MaxScan)
+	for i := range recipients {
+		recipients[i] = "bob"
+	}
+	indexBlock(t, sink, 1, recipients...)
+	indexBlock(t, sink, 2, "alice")
+
+	// the amount index is scanned, whose entries match the recipient only from
+	// the second block on
+	query := Query{Type: "transfer", Attributes: []Attribute{{"amount", "1"}, {"recipient", "alice"}}}
+	res, err := sink.Search(query)
+	require.NoError(t, err)
+	require.Empty(t, res.Events)
+	require.NotEmpty(t, res.Next)
+
+	query.Cursor = res.Next
+	res, err = sink.Search(query)
+	require.NoError(t, err)
+	require.Equal(t, []int64{2}, heights(res.Events))
+	require.Empty(t, res.Next)
+}
+
+func TestSinkResumes(t *testing.T) {
+	db := dbm.NewMemDB()
+	sink, err := NewSink(db)
+	require.NoError(t, err)
+	indexBlock(t, sink, 1, "alice")
+	indexBlock(t, sink, 2, "alice")
+
+	// the blocks replayed on restart aren't indexed again
+	sink, err = NewSink(db)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), sink.LastHeight())
+	indexBlock(t, sink, 2, "alice")
+	indexBlock(t, sink, 3, "alice")
+
+	res, err := sink.Search(Query{Type: "transfer"})
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2, 3}, heights(res.Events))
+}
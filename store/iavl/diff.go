@@ -0,0 +1,211 @@
+package iavl
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	amino "github.com/tendermint/go-amino"
+	"github.com/tendermint/iavl"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// Prefixes of the IAVL nodes and roots in the database of a tree.
+const (
+	diffNodePrefix = 'n' // n<hash>
+	diffRootPrefix = 'r' // r<version>
+)
+
+// diffNode is the part of a persisted IAVL node needed to diff trees.
+type diffNode struct {
+	hash      []byte
+	version   int64
+	height    int8
+	key       []byte
+	value     []byte
+	leftHash  []byte
+	rightHash []byte
+}
+
+// DiffVersions returns the keys added, changed and removed between two
+// versions of the IAVL tree persisted in db, from an older version to a newer
+// one. Version 0 is the empty tree preceding the first version.
+//
+// As IAVL trees are persistent, a node of the newer tree whose version is not
+// newer than the older tree is the root of a subtree shared by both trees.
+// Only the paths to the changed leaves are thus read, so the cost of the diff
+// depends on the number of changes rather than the size of the trees.
+func DiffVersions(db dbm.DB, from, to int64) (types.StoreDiff, error) {
+	diff := types.StoreDiff{From: from, To: to}
+	if from > to {
+		return diff, fmt.Errorf("cannot diff version %d against older version %d", from, to)
+	}
+
+	fromRoot, err := diffRoot(db, from)
+	if err != nil {
+		return diff, err
+	}
+	toRoot, err := diffRoot(db, to)
+	if err != nil {
+		return diff, err
+	}
+	if bytes.Equal(fromRoot, toRoot) {
+		return diff, nil
+	}
+
+	// collect the leaves of the newer tree which are not in the older one, and
+	// the roots of the subtrees shared by both trees
+	shared := make(map[string]bool)
+	var newLeaves []*diffNode
+	err = walkDiffNodes(db, toRoot, func(node *diffNode) bool {
+		if node.version <= from {
+			shared[string(node.hash)] = true
+			return false
+		}
+		if node.height == 0 {
+			newLeaves = append(newLeaves, node)
+		}
+		return true
+	})
+	if err != nil {
+		return diff, err
+	}
+
+	// collect the leaves of the older tree which are not in the newer one
+	var oldLeaves []*diffNode
+	err = walkDiffNodes(db, fromRoot, func(node *diffNode) bool {
+		if shared[string(node.hash)] {
+			return false
+		}
+		if node.height == 0 {
+			oldLeaves = append(oldLeaves, node)
+		}
+		return true
+	})
+	if err != nil {
+		return diff, err
+	}
+
+	// both lists of leaves are sorted by key
+	for len(oldLeaves) > 0 || len(newLeaves) > 0 {
+		switch {
+		case len(newLeaves) == 0 || (len(oldLeaves) > 0 && bytes.Compare(oldLeaves[0].key, newLeaves[0].key) < 0):
+			diff.Removed = append(diff.Removed, types.KVDiff{Key: oldLeaves[0].key, Old: oldLeaves[0].value})
+			oldLeaves = oldLeaves[1:]
+
+		case len(oldLeaves) == 0 || bytes.Compare(oldLeaves[0].key, newLeaves[0].key) > 0:
+			diff.Added = append(diff.Added, types.KVDiff{Key: newLeaves[0].key, New: newLeaves[0].value})
+			newLeaves = newLeaves[1:]
+
+		default:
+			// a leaf set to its current value is rewritten, and isn't a change
+			if !bytes.Equal(oldLeaves[0].value, newLeaves[0].value) {
+				diff.Changed = append(diff.Changed, types.KVDiff{
+					Key: newLeaves[0].key, Old: oldLeaves[0].value, New: newLeaves[0].value,
+				})
+			}
+			oldLeaves, newLeaves = oldLeaves[1:], newLeaves[1:]
+		}
+	}
+
+	return diff, nil
+}
+
+// diffRoot returns the hash of the root of a version of the tree, which is
+// empty if the tree is.
+func diffRoot(db dbm.DB, version int64) ([]byte, error) {
+	if version == 0 {
+		return nil, nil
+	}
+
+	key := make([]byte, 9)
+	key[0] = diffRootPrefix
+	binary.BigEndian.PutUint64(key[1:], uint64(version))
+
+	ok, err := db.Has(key)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("version %d: %w", version, iavl.ErrVersionDoesNotExist)
+	}
+
+	return db.Get(key)
+}
+
+// walkDiffNodes walks the tree of the given root in order, only descending
+// into the nodes for which fn returns true.
+func walkDiffNodes(db dbm.DB, hash []byte, fn func(node *diffNode) bool) error {
+	if len(hash) == 0 {
+		return nil
+	}
+
+	node, err := loadDiffNode(db, hash)
+	if err != nil {
+		return err
+	}
+	if !fn(node) || node.height == 0 {
+		return nil
+	}
+
+	if err := walkDiffNodes(db, node.leftHash, fn); err != nil {
+		return err
+	}
+	return walkDiffNodes(db, node.rightHash, fn)
+}
+
+// loadDiffNode reads a node from the database. It decodes the nodes as
+// iavl.MakeNode does, whose result doesn't expose the fields of the node.
+func loadDiffNode(db dbm.DB, hash []byte) (*diffNode, error) {
+	buf, err := db.Get(append([]byte{diffNodePrefix}, hash...))
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, fmt.Errorf("node %X not found", hash)
+	}
+
+	node := &diffNode{hash: hash}
+	var n int
+
+	if node.height, n, err = amino.DecodeInt8(buf); err != nil {
+		return nil, fmt.Errorf("decoding height of node %X: %w", hash, err)
+	}
+	buf = buf[n:]
+
+	// size
+	if _, n, err = amino.DecodeVarint(buf); err != nil {
+		return nil, fmt.Errorf("decoding size of node %X: %w", hash, err)
+	}
+	buf = buf[n:]
+
+	if node.version, n, err = amino.DecodeVarint(buf); err != nil {
+		return nil, fmt.Errorf("decoding version of node %X: %w", hash, err)
+	}
+	buf = buf[n:]
+
+	if node.key, n, err = amino.DecodeByteSlice(buf); err != nil {
+		return nil, fmt.Errorf("decoding key of node %X: %w", hash, err)
+	}
+	buf = buf[n:]
+
+	if node.height == 0 {
+		if node.value, _, err = amino.DecodeByteSlice(buf); err != nil {
+			return nil, fmt.Errorf("decoding value of node %X: %w", hash, err)
+		}
+		return node, nil
+	}
+
+	if node.leftHash, n, err = amino.DecodeByteSlice(buf); err != nil {
+		return nil, fmt.Errorf("decoding left hash of node %X: %w", hash, err)
+	}
+	buf = buf[n:]
+
+	if node.rightHash, _, err = amino.DecodeByteSlice(buf); err != nil {
+		return nil, fmt.Errorf("decoding right hash of node %X: %w", hash, err)
+	}
+
+	return node, nil
+}
@@ -0,0 +1,160 @@
+package iavl
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/iavl"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// countingDB counts the reads of a database.
+type countingDB struct {
+	dbm.DB
+	gets int
+}
+
+func (db *countingDB) Get(key []byte) ([]byte, error) {
+	db.gets++
+	return db.DB.Get(key)
+}
+
+// naiveDiff diffs two versions of a tree by iterating over both.
+func naiveDiff(t *testing.T, tree *iavl.MutableTree, from, to int64) types.StoreDiff {
+	pairs := func(version int64) map[string][]byte {
+		m := make(map[string][]byte)
+		if version == 0 {
+			return m
+		}
+		itree, err := tree.GetImmutable(version)
+		require.NoError(t, err)
+		itree.Iterate(func(key, value []byte) bool {
+			m[string(key)] = value
+			return false
+		})
+		return m
+	}
+	fromPairs, toPairs := pairs(from), pairs(to)
+
+	diff := types.StoreDiff{From: from, To: to}
+	for k, v := range toPairs {
+		old, ok := fromPairs[k]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, types.KVDiff{Key: []byte(k), New: v})
+		case !bytes.Equal(old, v):
+			diff.Changed = append(diff.Changed, types.KVDiff{Key: []byte(k), Old: old, New: v})
+		}
+	}
+	for k, v := range fromPairs {
+		if _, ok := toPairs[k]; !ok {
+			diff.Removed = append(diff.Removed, types.KVDiff{Key: []byte(k), Old: v})
+		}
+	}
+
+	for _, diffs := range [][]types.KVDiff{diff.Added, diff.Changed, diff.Removed} {
+		sort.Slice(diffs, func(i, j int) bool { return bytes.Compare(diffs[i].Key, diffs[j].Key) < 0 })
+	}
+	return diff
+}
+
+func TestDiffVersions(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree, err := iavl.NewMutableTree(db, cacheSize)
+	require.NoError(t, err)
+
+	r := rand.New(rand.NewSource(42))
+	const versions = 20
+	for v := 1; v <= versions; v++ {
+		for i := 0; i < 30; i++ {
+			key := []byte(fmt.Sprintf("key%03d", r.Intn(200)))
+			switch r.Intn(4) {
+			case 0:
+				tree.Remove(key)
+			case 1:
+				// set the current value, which rewrites the leaf
+				_, value := tree.Get(key)
+				if value != nil {
+					tree.Set(key, value)
+				}
+			default:
+				tree.Set(key, []byte(fmt.Sprintf("value%d", r.Intn(3))))
+			}
+		}
+		_, _, err := tree.SaveVersion()
+		require.NoError(t, err)
+	}
+
+	for from := int64(0); from <= versions; from++ {
+		for to := from; to <= versions; to++ {
+			diff, err := DiffVersions(db, from, to)
+			require.NoError(t, err)
+			require.Equal(t, naiveDiff(t, tree, from, to), diff, "from %d to %d", from, to)
+		}
+	}
+
+	_, err = DiffVersions(db, 2, 1)
+	require.Error(t, err)
+	_, err = DiffVersions(db, 1, versions+1)
+	require.Error(t, err)
+}
+
+func TestDiffVersionsSkipsUnchangedSubtrees(t *testing.T) {
+	db := &countingDB{DB: dbm.NewMemDB()}
+	tree, err := iavl.NewMutableTree(db, cacheSize)
+	require.NoError(t, err)
+
+	for i := 0; i < 10000; i++ {
+		tree.Set([]byte(fmt.Sprintf("key%05d", i)), []byte("value"))
+	}
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+
+	tree.Set([]byte("key05000"), []byte("changed"))
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+
+	db.gets = 0
+	diff, err := DiffVersions(db, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, []types.KVDiff{{Key: []byte("key05000"), Old: []byte("value"), New: []byte("changed")}}, diff.Changed)
+	require.Empty(t, diff.Added)
+	require.Empty(t, diff.Removed)
+
+	// the roots and the paths to the changed leaf, of a tree of height ~15
+	require.True(t, db.gets < 100, "read %d nodes", db.gets)
+}
+
+func TestQueryDiff(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree, _ := newAlohaTree(t, db)
+	tree.Set([]byte("hello"), []byte("hallo"))
+	tree.Remove([]byte("aloha"))
+	_, _, err := tree.SaveVersion()
+	require.NoError(t, err)
+
+	store, err := LoadStore(db, types.CommitID{Version: 2}, false, 0)
+	require.NoError(t, err)
+
+	from := make([]byte, 8)
+	binary.BigEndian.PutUint64(from, 1)
+	res := store.(*Store).Query(abci.RequestQuery{Path: "/diff", Data: from, Height: 2})
+	require.True(t, res.IsOK(), res.Log)
+
+	var diff types.StoreDiff
+	require.NoError(t, cdc.UnmarshalBinaryLengthPrefixed(res.Value, &diff))
+	require.Equal(t, []types.KVDiff{{Key: []byte("hello"), Old: []byte("goodbye"), New: []byte("hallo")}}, diff.Changed)
+	require.Equal(t, []types.KVDiff{{Key: []byte("aloha"), Old: []byte("shalom")}}, diff.Removed)
+
+	// the older version must be given as 8 bytes
+	res = store.(*Store).Query(abci.RequestQuery{Path: "/diff", Data: []byte{1}, Height: 2})
+	require.False(t, res.IsOK())
+}
@@ -0,0 +1,242 @@
+package iavl
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/iavl"
+	tmkv "github.com/tendermint/tendermint/libs/kv"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// chanIterator is the former goroutine based iavlIterator, kept as a reference
+// for the tests and benchmarks of the synchronous one.
+type chanIterator struct {
+	start, end []byte
+	key, value []byte
+	iterCh     chan tmkv.Pair
+	quitCh     chan struct{}
+	invalid    bool
+}
+
+var _ types.Iterator = (*chanIterator)(nil)
+
+func newChanIterator(tree *iavl.ImmutableTree, start, end []byte, ascending bool) *chanIterator {
+	iter := &chanIterator{
+		start:  types.Cp(start),
+		end:    types.Cp(end),
+		iterCh: make(chan tmkv.Pair),
+		quitCh: make(chan struct{}),
+	}
+
+	go func() {
+		tree.IterateRange(iter.start, iter.end, ascending, func(key, value []byte) bool {
+			select {
+			case <-iter.quitCh:
+				return true
+			case iter.iterCh <- tmkv.Pair{Key: key, Value: value}:
+				return false
+			}
+		})
+		close(iter.iterCh)
+	}()
+
+	iter.receiveNext()
+	return iter
+}
+
+func (iter *chanIterator) Domain() ([]byte, []byte) { return iter.start, iter.end }
+func (iter *chanIterator) Valid() bool              { return !iter.invalid }
+func (iter *chanIterator) Next()                    { iter.assertIsValid(); iter.receiveNext() }
+func (iter *chanIterator) Key() []byte              { iter.assertIsValid(); return iter.key }
+func (iter *chanIterator) Value() []byte            { iter.assertIsValid(); return iter.value }
+func (iter *chanIterator) Error() error             { return nil }
+
+func (iter *chanIterator) Close() {
+	close(iter.quitCh)
+	for range iter.iterCh {
+	}
+}
+
+func (iter *chanIterator) receiveNext() {
+	pair, ok := <-iter.iterCh
+	if !ok {
+		iter.invalid = true
+		return
+	}
+	iter.key, iter.value = pair.Key, pair.Value
+}
+
+func (iter *chanIterator) assertIsValid() {
+	if iter.invalid {
+		panic("invalid iterator")
+	}
+}
+
+func newRandTree(t require.TestingT, size int, keyLen int) *iavl.MutableTree {
+	tree, err := iavl.NewMutableTree(dbm.NewMemDB(), cacheSize)
+	require.NoError(t, err)
+
+	for i := 0; i < size; i++ {
+		tree.Set(randBytes(keyLen), randBytes(8))
+	}
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+
+	return tree
+}
+
+func collectPairs(iter types.Iterator) (pairs []tmkv.Pair) {
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		pairs = append(pairs, tmkv.Pair{Key: iter.Key(), Value: iter.Value()})
+	}
+	return pairs
+}
+
+func TestIAVLIteratorMatchesChanIterator(t *testing.T) {
+	// enough keys for batches to reach their maximum size
+	tree := newRandTree(t, 2000, 2)
+	immutable := tree.ImmutableTree
+
+	bounds := [][]byte{nil, {0x00}, {0x00, 0x00}, {0x10}, {0x7f, 0xff}, {0x80}, {0xfe, 0x01}, {0xff, 0xff}}
+	for _, start := range bounds {
+		for _, end := range bounds {
+			if start != nil && end != nil && bytes.Compare(start, end) > 0 {
+				continue
+			}
+			for _, ascending := range []bool{true, false} {
+				expected := collectPairs(newChanIterator(immutable, start, end, ascending))
+				actual := collectPairs(newIAVLIterator(immutable, start, end, ascending))
+				require.Equal(t, expected, actual, "start %X end %X ascending %v", start, end, ascending)
+			}
+		}
+	}
+}
+
+func TestIAVLIteratorSnapshot(t *testing.T) {
+	tree := newRandTree(t, 100, 4)
+	iavlStore := UnsafeNewStore(tree)
+
+	expected := collectPairs(iavlStore.Iterator(nil, nil))
+
+	// writes during the iteration don't affect it
+	iter := iavlStore.Iterator(nil, nil)
+	var actual []tmkv.Pair
+	for ; iter.Valid(); iter.Next() {
+		actual = append(actual, tmkv.Pair{Key: iter.Key(), Value: iter.Value()})
+		iavlStore.Delete(iter.Key())
+		iavlStore.Set(append(iter.Key(), 0x00), []byte("new"))
+	}
+	iter.Close()
+	require.Equal(t, expected, actual)
+
+	require.False(t, iter.Valid())
+	require.Panics(t, func() { iter.Key() })
+	require.Panics(t, func() { iter.Next() })
+}
+
+// Staking reads the few delegations of a delegator out of all delegations,
+// with keys of the form prefix | delegator | validator.
+func BenchmarkIAVLIteratorStakingDelegations(b *testing.B) {
+	const (
+		delegators    = 1000
+		perDelegator  = 5
+		addrLen       = 20
+		delegationKey = 0x31
+	)
+
+	tree, err := iavl.NewMutableTree(dbm.NewMemDB(), cacheSize)
+	require.NoError(b, err)
+
+	delAddrs := make([][]byte, delegators)
+	for i := range delAddrs {
+		delAddrs[i] = append([]byte{delegationKey}, randBytes(addrLen)...)
+		for j := 0; j < perDelegator; j++ {
+			tree.Set(append(types.Cp(delAddrs[i]), randBytes(addrLen)...), randBytes(60))
+		}
+	}
+	_, _, err = tree.SaveVersion()
+	require.NoError(b, err)
+
+	benchmarkIterators(b, tree.ImmutableTree, func(b *testing.B, newIter iteratorConstructor) {
+		for i := 0; i < b.N; i++ {
+			prefix := delAddrs[i%delegators]
+			iter := newIter(prefix, types.PrefixEndBytes(prefix), true)
+			for ; iter.Valid(); iter.Next() {
+				_ = iter.Value()
+			}
+			iter.Close()
+		}
+	})
+}
+
+// Distribution walks all the outstanding rewards of the validators, e.g. at
+// genesis export or when allocating tokens.
+func BenchmarkIAVLIteratorDistributionRewards(b *testing.B) {
+	const (
+		validators         = 10000
+		outstandingRewards = 0x02
+	)
+
+	tree, err := iavl.NewMutableTree(dbm.NewMemDB(), cacheSize)
+	require.NoError(b, err)
+
+	for i := 0; i < validators; i++ {
+		tree.Set(append([]byte{outstandingRewards}, randBytes(20)...), randBytes(40))
+	}
+	_, _, err = tree.SaveVersion()
+	require.NoError(b, err)
+
+	prefix := []byte{outstandingRewards}
+	for _, ascending := range []bool{true, false} {
+		b.Run(fmt.Sprintf("ascending=%v", ascending), func(b *testing.B) {
+			benchmarkIterators(b, tree.ImmutableTree, func(b *testing.B, newIter iteratorConstructor) {
+				for i := 0; i < b.N; i++ {
+					iter := newIter(prefix, types.PrefixEndBytes(prefix), ascending)
+					for ; iter.Valid(); iter.Next() {
+						_ = iter.Value()
+					}
+					iter.Close()
+				}
+			})
+		})
+	}
+}
+
+// Distribution also reads the first entry of a range only, e.g. the first
+// slash event of a validator after a given height.
+func BenchmarkIAVLIteratorDistributionFirst(b *testing.B) {
+	tree := newRandTree(b, 10000, 8)
+
+	benchmarkIterators(b, tree.ImmutableTree, func(b *testing.B, newIter iteratorConstructor) {
+		for i := 0; i < b.N; i++ {
+			iter := newIter(randBytes(1), nil, true)
+			if iter.Valid() {
+				_ = iter.Value()
+			}
+			iter.Close()
+		}
+	})
+}
+
+type iteratorConstructor func(start, end []byte, ascending bool) types.Iterator
+
+func benchmarkIterators(b *testing.B, tree *iavl.ImmutableTree, bench func(*testing.B, iteratorConstructor)) {
+	b.Run("sync", func(b *testing.B) {
+		b.ReportAllocs()
+		bench(b, func(start, end []byte, ascending bool) types.Iterator {
+			return newIAVLIterator(tree, start, end, ascending)
+		})
+	})
+	b.Run("channel", func(b *testing.B) {
+		b.ReportAllocs()
+		bench(b, func(start, end []byte, ascending bool) types.Iterator {
+			return newChanIterator(tree, start, end, ascending)
+		})
+	})
+}
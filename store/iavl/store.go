@@ -1,10 +1,10 @@
 package iavl
 
 import (
-	"io"
-	"sync"
-	"fmt"
+	"encoding/binary"
 	"errors"
+	"fmt"
+	"io"
 
 	"github.com/tendermint/iavl"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -32,6 +32,7 @@ var (
 // Store Implements types.KVStore and CommitKVStore.
 type Store struct {
 	tree Tree
+	db   dbm.DB
 }
 
 // LoadStore returns an IAVL Store as a CommitKVStore. Internally, it will load the
@@ -62,6 +63,7 @@ func LoadStoreWithInitialVersion(db dbm.DB, id types.CommitID, lazyLoading bool,
 
 	return &Store{
 		tree: tree,
+		db:   db,
 	}, nil
 }
 
@@ -291,6 +293,21 @@ func (st *Store) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 		iterator.Close()
 		res.Value = cdc.MustMarshalBinaryLengthPrefixed(KVs)
 
+	case "/diff": // diff against an older version
+		if len(req.Data) != 8 {
+			return sdkerrors.QueryResult(sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "data must hold the older version as 8 big endian bytes"))
+		}
+		if st.db == nil {
+			return sdkerrors.QueryResult(sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "store is not backed by a database"))
+		}
+
+		diff, err := DiffVersions(st.db, int64(binary.BigEndian.Uint64(req.Data)), res.Height)
+		if err != nil {
+			return sdkerrors.QueryResult(sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error()))
+		}
+
+		res.Value = cdc.MustMarshalBinaryLengthPrefixed(diff)
+
 	default:
 		return sdkerrors.QueryResult(sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unexpected query path: %v", req.Path))
 	}
@@ -300,73 +317,62 @@ func (st *Store) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 
 //----------------------------------------
 
+// iavlIterator iterates over a snapshot of an IAVL tree without goroutines. As
+// the tree only exposes callback traversals, it traverses the tree by batches,
+// each one resuming after the last key of the previous one. Batches start with
+// a single pair and double up to iteratorMaxBatchSize, so that scans which only
+// look at the first pairs of a range don't pay for traversing the whole range,
+// and they reuse the same buffer once grown.
+//
 // Implements types.Iterator.
 type iavlIterator struct {
 	// Domain
 	start, end []byte
 
-	key   []byte // The current key (mutable)
-	value []byte // The current value (mutable)
-
 	// Underlying store
 	tree *iavl.ImmutableTree
 
-	// Channel to push iteration values.
-	iterCh chan tmkv.Pair
-
-	// Close this to release goroutine.
-	quitCh chan struct{}
-
-	// Close this to signal that state is initialized.
-	initCh chan struct{}
-
-	mtx sync.Mutex
-
 	ascending bool // Iteration order
 
-	invalid bool // True once, true forever (mutable)
+	pairs     []tmkv.Pair // The current batch
+	pos       int         // Position of the current pair in the batch
+	batchSize int         // Size of the next batch
+	exhausted bool        // True once the last batch of the domain is fetched
+
+	resume  []byte                       // Start of the next ascending batch
+	collect func(key, value []byte) bool // Traversal callback filling a batch
 }
 
+const (
+	iteratorMinBatchSize = 1
+	iteratorMaxBatchSize = 256
+)
+
 var _ types.Iterator = (*iavlIterator)(nil)
 
 // newIAVLIterator will create a new iavlIterator.
-// CONTRACT: Caller must release the iavlIterator, as each one creates a new
-// goroutine.
 func newIAVLIterator(tree *iavl.ImmutableTree, start, end []byte, ascending bool) *iavlIterator {
 	iter := &iavlIterator{
-		tree:      tree,
 		start:     types.Cp(start),
 		end:       types.Cp(end),
 		ascending: ascending,
-		iterCh:    make(chan tmkv.Pair), // Set capacity > 0?
-		quitCh:    make(chan struct{}),
-		initCh:    make(chan struct{}),
+		batchSize: iteratorMinBatchSize,
 	}
-	go iter.iterateRoutine()
-	go iter.initRoutine()
-	return iter
-}
 
-// Run this to funnel items from the tree to iterCh.
-func (iter *iavlIterator) iterateRoutine() {
-	iter.tree.IterateRange(
-		iter.start, iter.end, iter.ascending,
-		func(key, value []byte) bool {
-			select {
-			case <-iter.quitCh:
-				return true // done with iteration.
-			case iter.iterCh <- tmkv.Pair{Key: key, Value: value}:
-				return false // yay.
-			}
-		},
-	)
-	close(iter.iterCh) // done.
-}
+	if tree != nil {
+		// Nodes are copy-on-write, so holding the current root is enough for
+		// later writes to the tree not to affect the iteration.
+		snapshot := *tree
+		iter.tree = &snapshot
+	}
+
+	iter.collect = func(key, value []byte) bool {
+		iter.pairs = append(iter.pairs, tmkv.Pair{Key: key, Value: value})
+		return len(iter.pairs) == cap(iter.pairs)
+	}
 
-// Run this to fetch the first item.
-func (iter *iavlIterator) initRoutine() {
-	iter.receiveNext()
-	close(iter.initCh)
+	iter.fetch(iter.start, iter.end)
+	return iter
 }
 
 // Implements types.Iterator.
@@ -376,53 +382,47 @@ func (iter *iavlIterator) Domain() (start, end []byte) {
 
 // Implements types.Iterator.
 func (iter *iavlIterator) Valid() bool {
-	iter.waitInit()
-	iter.mtx.Lock()
-
-	validity := !iter.invalid
-	iter.mtx.Unlock()
-	return validity
+	return iter.pos < len(iter.pairs)
 }
 
 // Implements types.Iterator.
 func (iter *iavlIterator) Next() {
-	iter.waitInit()
-	iter.mtx.Lock()
-	iter.assertIsValid(true)
+	iter.assertIsValid()
 
-	iter.receiveNext()
-	iter.mtx.Unlock()
+	iter.pos++
+	if iter.pos < len(iter.pairs) || iter.exhausted {
+		return
+	}
+
+	last := iter.pairs[len(iter.pairs)-1].Key
+	if iter.ascending {
+		// the smallest key greater than the last one
+		iter.resume = append(append(iter.resume[:0], last...), 0)
+		iter.fetch(iter.resume, iter.end)
+	} else {
+		iter.fetch(iter.start, last)
+	}
 }
 
 // Implements types.Iterator.
 func (iter *iavlIterator) Key() []byte {
-	iter.waitInit()
-	iter.mtx.Lock()
-	iter.assertIsValid(true)
-
-	key := iter.key
-	iter.mtx.Unlock()
-	return key
+	iter.assertIsValid()
+	return iter.pairs[iter.pos].Key
 }
 
 // Implements types.Iterator.
 func (iter *iavlIterator) Value() []byte {
-	iter.waitInit()
-	iter.mtx.Lock()
-	iter.assertIsValid(true)
-
-	val := iter.value
-	iter.mtx.Unlock()
-	return val
+	iter.assertIsValid()
+	return iter.pairs[iter.pos].Value
 }
 
-// Close closes the IAVL iterator by closing the quit channel and waiting for
-// the iterCh to finish/close.
+// Close releases the tree and the current batch of the iterator, which is
+// invalid afterwards.
 func (iter *iavlIterator) Close() {
-	close(iter.quitCh)
-	// wait iterCh to close
-	for range iter.iterCh {
-	}
+	iter.tree = nil
+	iter.pairs = nil
+	iter.pos = 0
+	iter.exhausted = true
 }
 
 // Error performs a no-op.
@@ -432,40 +432,30 @@ func (iter *iavlIterator) Error() error {
 
 //----------------------------------------
 
-func (iter *iavlIterator) setNext(key, value []byte) {
-	iter.assertIsValid(false)
-
-	iter.key = key
-	iter.value = value
-}
-
-func (iter *iavlIterator) setInvalid() {
-	iter.assertIsValid(false)
-
-	iter.invalid = true
-}
-
-func (iter *iavlIterator) waitInit() {
-	<-iter.initCh
-}
+// fetch traverses the next batch of pairs of the given range.
+func (iter *iavlIterator) fetch(start, end []byte) {
+	iter.pos = 0
+	if iter.tree == nil {
+		iter.pairs = iter.pairs[:0]
+		iter.exhausted = true
+		return
+	}
 
-func (iter *iavlIterator) receiveNext() {
-	kvPair, ok := <-iter.iterCh
-	if ok {
-		iter.setNext(kvPair.Key, kvPair.Value)
+	if cap(iter.pairs) < iter.batchSize {
+		iter.pairs = make([]tmkv.Pair, 0, iter.batchSize)
 	} else {
-		iter.setInvalid()
+		iter.pairs = iter.pairs[:0]
 	}
+	if iter.batchSize < iteratorMaxBatchSize {
+		iter.batchSize *= 2
+	}
+
+	iter.exhausted = !iter.tree.IterateRange(start, end, iter.ascending, iter.collect)
 }
 
-// assertIsValid panics if the iterator is invalid. If unlockMutex is true,
-// it also unlocks the mutex before panicing, to prevent deadlocks in code that
-// recovers from panics
-func (iter *iavlIterator) assertIsValid(unlockMutex bool) {
-	if iter.invalid {
-		if unlockMutex {
-			iter.mtx.Unlock()
-		}
+// assertIsValid panics if the iterator is invalid.
+func (iter *iavlIterator) assertIsValid() {
+	if !iter.Valid() {
 		panic("invalid iterator")
 	}
 }
@@ -0,0 +1,90 @@
+package listenkv
+
+import (
+	"io"
+
+	"github.com/cosmos/cosmos-sdk/store/cachekv"
+	"github.com/cosmos/cosmos-sdk/store/tracekv"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+var _ types.KVStore = &Store{}
+
+// CacheMultiStore is implemented by the cache multi-stores which can notify a
+// WriteListener of the writes to their KVStores.
+type CacheMultiStore interface {
+	types.CacheMultiStore
+
+	// SetWriteListener returns a copy of the multi-store whose KVStores notify
+	// the given listener of their writes, including the writes of the stores
+	// cache-wrapping them when they are written.
+	SetWriteListener(listener types.WriteListener) types.CacheMultiStore
+}
+
+// Store notifies a WriteListener of the writes to an underlying KVStore, under
+// its store key. It implements the KVStore interface.
+type Store struct {
+	parent   types.KVStore
+	storeKey types.StoreKey
+	listener types.WriteListener
+}
+
+// NewStore returns a reference to a new listenkv Store.
+func NewStore(parent types.KVStore, storeKey types.StoreKey, listener types.WriteListener) *Store {
+	return &Store{parent: parent, storeKey: storeKey, listener: listener}
+}
+
+// Implements Store.
+func (s *Store) GetStoreType() types.StoreType {
+	return s.parent.GetStoreType()
+}
+
+// Implements KVStore.
+func (s *Store) Get(key []byte) []byte {
+	return s.parent.Get(key)
+}
+
+// Implements KVStore.
+func (s *Store) Has(key []byte) bool {
+	return s.parent.Has(key)
+}
+
+// Implements KVStore.
+func (s *Store) Set(key []byte, value []byte) {
+	types.AssertValidValue(value)
+	s.parent.Set(key, value)
+	s.listener.OnWrite(s.storeKey, key, value, false)
+}
+
+// Implements KVStore.
+func (s *Store) Delete(key []byte) {
+	s.parent.Delete(key)
+	s.listener.OnWrite(s.storeKey, key, nil, true)
+}
+
+// Implements KVStore.
+func (s *Store) Iterator(start, end []byte) types.Iterator {
+	return s.parent.Iterator(start, end)
+}
+
+// Implements KVStore.
+func (s *Store) ReverseIterator(start, end []byte) types.Iterator {
+	return s.parent.ReverseIterator(start, end)
+}
+
+// Write writes the parent store, which must be a cache, to its own parent. The
+// listener isn't notified again of the writes it was notified of when they were
+// made to the cache.
+func (s *Store) Write() {
+	s.parent.(types.CacheWrap).Write()
+}
+
+// Implements KVStore.
+func (s *Store) CacheWrap() types.CacheWrap {
+	return cachekv.NewStore(s)
+}
+
+// CacheWrapWithTrace implements the KVStore interface.
+func (s *Store) CacheWrapWithTrace(w io.Writer, tc types.TraceContext) types.CacheWrap {
+	return cachekv.NewStore(tracekv.NewStore(s, w, tc))
+}
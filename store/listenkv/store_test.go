@@ -0,0 +1,89 @@
+package listenkv_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/cachekv"
+	"github.com/cosmos/cosmos-sdk/store/cachemulti"
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	"github.com/cosmos/cosmos-sdk/store/listenkv"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+type write struct {
+	store  string
+	key    string
+	value  string
+	delete bool
+}
+
+type recorder struct {
+	writes []write
+}
+
+func (r *recorder) OnWrite(storeKey types.StoreKey, key, value []byte, delete bool) {
+	r.writes = append(r.writes, write{storeKey.Name(), string(key), string(value), delete})
+}
+
+func TestListenKVStore(t *testing.T) {
+	key := types.NewKVStoreKey("test")
+	parent := cachekv.NewStore(dbadapter.Store{DB: dbm.NewMemDB()})
+	rec := &recorder{}
+	store := listenkv.NewStore(parent, key, rec)
+
+	store.Set([]byte("a"), []byte("1"))
+	store.Set([]byte("b"), []byte("2"))
+	store.Delete([]byte("a"))
+	require.Equal(t, []byte("2"), store.Get([]byte("b")))
+	require.False(t, store.Has([]byte("a")))
+
+	require.Equal(t, []write{
+		{"test", "a", "1", false},
+		{"test", "b", "2", false},
+		{"test", "a", "", true},
+	}, rec.writes)
+
+	// reads aren't notified
+	iter := store.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+	}
+	iter.Close()
+	require.Len(t, rec.writes, 3)
+
+	// the writes of a cache wrapping the store are notified when it's written
+	cache := store.CacheWrap()
+	cache.(types.KVStore).Set([]byte("c"), []byte("3"))
+	require.Len(t, rec.writes, 3)
+	cache.Write()
+	require.Equal(t, write{"test", "c", "3", false}, rec.writes[3])
+}
+
+func TestCacheMultiStoreWriteListener(t *testing.T) {
+	keyA, keyB := types.NewKVStoreKey("a"), types.NewKVStoreKey("b")
+	stores := map[types.StoreKey]types.CacheWrapper{
+		keyA: dbadapter.Store{DB: dbm.NewMemDB()},
+		keyB: dbadapter.Store{DB: dbm.NewMemDB()},
+	}
+	rec := &recorder{}
+	cms := cachemulti.NewStore(dbm.NewMemDB(), stores, nil, nil, nil).SetWriteListener(rec)
+
+	cms.GetKVStore(keyA).Set([]byte("k"), []byte("v"))
+	require.Equal(t, []write{{"a", "k", "v", false}}, rec.writes)
+
+	// a discarded branch isn't notified, a written one is
+	branch := cms.CacheMultiStore()
+	branch.GetKVStore(keyB).Set([]byte("discarded"), []byte("v"))
+	require.Len(t, rec.writes, 1)
+
+	branch = cms.CacheMultiStore()
+	branch.GetKVStore(keyB).Delete([]byte("k"))
+	branch.Write()
+	require.Equal(t, []write{{"a", "k", "v", false}, {"b", "k", "", true}}, rec.writes)
+
+	// the writes reach the underlying stores
+	cms.Write()
+	require.Equal(t, []byte("v"), stores[keyA].(types.KVStore).Get([]byte("k")))
+}
@@ -0,0 +1,358 @@
+package metrics
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+const (
+	// DefaultHotKeys is the default number of hot keys tracked by a Collector.
+	DefaultHotKeys = 32
+
+	// DefaultSampleRate is the default rate at which a Collector samples the
+	// accessed keys for hot keys, i.e. one access out of DefaultSampleRate.
+	DefaultSampleRate = 16
+)
+
+// CacheMultiStore is implemented by the cache multi-stores which can collect
+// the metrics of the KVStores they return.
+type CacheMultiStore interface {
+	types.CacheMultiStore
+
+	// SetMetrics returns a copy of the multi-store whose KVStores report their
+	// operations to the given collector.
+	SetMetrics(collector *Collector) types.CacheMultiStore
+}
+
+// OpStats counts the operations on a store and the bytes they read or wrote.
+// Has counts as a get, and the keys and values visited by iterators count as
+// read bytes.
+type OpStats struct {
+	Gets          uint64 `json:"gets"`
+	Sets          uint64 `json:"sets"`
+	Deletes       uint64 `json:"deletes"`
+	Iterations    uint64 `json:"iterations"`
+	IteratedItems uint64 `json:"iterated_items"`
+	ReadBytes     uint64 `json:"read_bytes"`
+	WriteBytes    uint64 `json:"write_bytes"`
+}
+
+// IsZero returns true if no operation was counted.
+func (s OpStats) IsZero() bool {
+	return s == OpStats{}
+}
+
+// Add returns the sum of two OpStats.
+func (s OpStats) Add(other OpStats) OpStats {
+	return OpStats{
+		Gets:          s.Gets + other.Gets,
+		Sets:          s.Sets + other.Sets,
+		Deletes:       s.Deletes + other.Deletes,
+		Iterations:    s.Iterations + other.Iterations,
+		IteratedItems: s.IteratedItems + other.IteratedItems,
+		ReadBytes:     s.ReadBytes + other.ReadBytes,
+		WriteBytes:    s.WriteBytes + other.WriteBytes,
+	}
+}
+
+// Sub returns the difference of two OpStats.
+func (s OpStats) Sub(other OpStats) OpStats {
+	return OpStats{
+		Gets:          s.Gets - other.Gets,
+		Sets:          s.Sets - other.Sets,
+		Deletes:       s.Deletes - other.Deletes,
+		Iterations:    s.Iterations - other.Iterations,
+		IteratedItems: s.IteratedItems - other.IteratedItems,
+		ReadBytes:     s.ReadBytes - other.ReadBytes,
+		WriteBytes:    s.WriteBytes - other.WriteBytes,
+	}
+}
+
+// StoreStats are the OpStats of a store, named by its store key.
+type StoreStats struct {
+	Store  string  `json:"store"`
+	Module string  `json:"module"`
+	Ops    OpStats `json:"ops"`
+}
+
+// ModuleStats are the OpStats of all the stores of a module.
+type ModuleStats struct {
+	Module string  `json:"module"`
+	Ops    OpStats `json:"ops"`
+}
+
+// TxStats are the OpStats of the stores accessed by a tx.
+type TxStats struct {
+	Hash   string       `json:"hash"`
+	Stores []StoreStats `json:"stores"`
+}
+
+// BlockStats are the OpStats of the stores accessed in a block, including its
+// begin and end blockers, and of each of its txs.
+type BlockStats struct {
+	Height int64        `json:"height"`
+	Stores []StoreStats `json:"stores"`
+	Txs    []TxStats    `json:"txs"`
+}
+
+// HotKey is a frequently accessed key. Its count is estimated from the sampled
+// accesses, and overestimated by at most Error.
+type HotKey struct {
+	Store string `json:"store"`
+	Key   string `json:"key"`
+	Count uint64 `json:"count"`
+	Error uint64 `json:"error"`
+}
+
+// Stats are the metrics gathered by a Collector: the totals since it was
+// created, per store and per module, the totals of the last committed block,
+// and the hot keys.
+type Stats struct {
+	Stores    []StoreStats  `json:"stores"`
+	Modules   []ModuleStats `json:"modules"`
+	LastBlock BlockStats    `json:"last_block"`
+	HotKeys   []HotKey      `json:"hot_keys"`
+}
+
+// counters are the OpStats of a store, updated atomically.
+type counters struct {
+	gets, sets, deletes, iterations, iteratedItems, readBytes, writeBytes uint64
+}
+
+func (c *counters) load() OpStats {
+	return OpStats{
+		Gets:          atomic.LoadUint64(&c.gets),
+		Sets:          atomic.LoadUint64(&c.sets),
+		Deletes:       atomic.LoadUint64(&c.deletes),
+		Iterations:    atomic.LoadUint64(&c.iterations),
+		IteratedItems: atomic.LoadUint64(&c.iteratedItems),
+		ReadBytes:     atomic.LoadUint64(&c.readBytes),
+		WriteBytes:    atomic.LoadUint64(&c.writeBytes),
+	}
+}
+
+// Collector gathers the metrics of the KVStores wrapped by NewStore. Counting
+// an operation only costs a few atomic additions. The accessed keys are
+// sampled at a given rate into a top-K sketch of the hot keys.
+//
+// The operations of the current block and tx are delimited by BeginBlock,
+// EndBlock, BeginTx and EndTx, which must not be called concurrently, as
+// BaseApp does for DeliverTx.
+type Collector struct {
+	accesses uint64 // first for 64-bit alignment of atomic operations
+
+	storesMtx sync.RWMutex
+	stores    map[string]*counters
+	modules   map[string]string
+
+	sampleRate uint64
+	hotKeysMtx sync.Mutex
+	hotKeys    *topK
+
+	blockMtx   sync.Mutex
+	height     int64
+	blockStart map[string]OpStats
+	txStart    map[string]OpStats
+	txs        []TxStats
+	lastBlock  BlockStats
+}
+
+// NewCollector returns a Collector tracking the given number of hot keys, which
+// samples one key access out of sampleRate.
+func NewCollector(hotKeys int, sampleRate uint64) *Collector {
+	if hotKeys <= 0 {
+		panic(fmt.Sprintf("invalid number of hot keys: %d", hotKeys))
+	}
+	if sampleRate == 0 {
+		panic("sample rate must be positive")
+	}
+
+	return &Collector{
+		stores:     make(map[string]*counters),
+		modules:    make(map[string]string),
+		sampleRate: sampleRate,
+		hotKeys:    newTopK(hotKeys),
+	}
+}
+
+// SetModule sets the module owning a store, which defaults to the name of its
+// store key.
+func (c *Collector) SetModule(store, module string) {
+	c.storesMtx.Lock()
+	defer c.storesMtx.Unlock()
+
+	c.modules[store] = module
+}
+
+// counters returns the counters of a store, creating them if needed.
+func (c *Collector) counters(store string) *counters {
+	c.storesMtx.RLock()
+	cs, ok := c.stores[store]
+	c.storesMtx.RUnlock()
+	if ok {
+		return cs
+	}
+
+	c.storesMtx.Lock()
+	defer c.storesMtx.Unlock()
+
+	if cs, ok = c.stores[store]; !ok {
+		cs = &counters{}
+		c.stores[store] = cs
+	}
+	return cs
+}
+
+// sample adds an access to a key to the hot keys, if it is sampled.
+func (c *Collector) sample(store string, key []byte) {
+	if atomic.AddUint64(&c.accesses, 1)%c.sampleRate != 0 {
+		return
+	}
+
+	c.hotKeysMtx.Lock()
+	c.hotKeys.add(store + "/" + string(key))
+	c.hotKeysMtx.Unlock()
+}
+
+// snapshot returns the current totals of all the stores.
+func (c *Collector) snapshot() map[string]OpStats {
+	c.storesMtx.RLock()
+	defer c.storesMtx.RUnlock()
+
+	snapshot := make(map[string]OpStats, len(c.stores))
+	for store, cs := range c.stores {
+		snapshot[store] = cs.load()
+	}
+	return snapshot
+}
+
+// storeStats returns the non-zero differences between two snapshots, sorted by
+// store.
+func (c *Collector) storeStats(current, start map[string]OpStats) []StoreStats {
+	c.storesMtx.RLock()
+	defer c.storesMtx.RUnlock()
+
+	stats := make([]StoreStats, 0, len(current))
+	for store, ops := range current {
+		if ops = ops.Sub(start[store]); ops.IsZero() {
+			continue
+		}
+		stats = append(stats, StoreStats{Store: store, Module: c.moduleOf(store), Ops: ops})
+	}
+
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Store < stats[j].Store })
+	return stats
+}
+
+// moduleOf returns the module owning a store. storesMtx must be held.
+func (c *Collector) moduleOf(store string) string {
+	if module, ok := c.modules[store]; ok {
+		return module
+	}
+	return store
+}
+
+// BeginBlock starts counting the operations of a block.
+func (c *Collector) BeginBlock(height int64) {
+	c.blockMtx.Lock()
+	defer c.blockMtx.Unlock()
+
+	c.height = height
+	c.blockStart = c.snapshot()
+	c.txs = nil
+}
+
+// EndBlock ends counting the operations of the current block, whose stats are
+// returned by Stats until the next block ends.
+func (c *Collector) EndBlock() {
+	c.blockMtx.Lock()
+	defer c.blockMtx.Unlock()
+
+	if c.blockStart == nil {
+		return
+	}
+
+	c.lastBlock = BlockStats{
+		Height: c.height,
+		Stores: c.storeStats(c.snapshot(), c.blockStart),
+		Txs:    c.txs,
+	}
+	c.blockStart = nil
+	c.txs = nil
+}
+
+// BeginTx starts counting the operations of a tx of the current block.
+func (c *Collector) BeginTx() {
+	c.blockMtx.Lock()
+	defer c.blockMtx.Unlock()
+
+	c.txStart = c.snapshot()
+}
+
+// EndTx ends counting the operations of the current tx, of the given hash.
+func (c *Collector) EndTx(hash []byte) {
+	c.blockMtx.Lock()
+	defer c.blockMtx.Unlock()
+
+	if c.txStart == nil {
+		return
+	}
+
+	c.txs = append(c.txs, TxStats{
+		Hash:   fmt.Sprintf("%X", hash),
+		Stores: c.storeStats(c.snapshot(), c.txStart),
+	})
+	c.txStart = nil
+}
+
+// Stats returns the metrics gathered so far.
+func (c *Collector) Stats() Stats {
+	var stats Stats
+
+	stats.Stores = c.storeStats(c.snapshot(), nil)
+
+	modules := make(map[string]OpStats)
+	for _, s := range stats.Stores {
+		modules[s.Module] = modules[s.Module].Add(s.Ops)
+	}
+	for module, ops := range modules {
+		stats.Modules = append(stats.Modules, ModuleStats{Module: module, Ops: ops})
+	}
+	sort.Slice(stats.Modules, func(i, j int) bool { return stats.Modules[i].Module < stats.Modules[j].Module })
+
+	c.blockMtx.Lock()
+	stats.LastBlock = c.lastBlock
+	c.blockMtx.Unlock()
+
+	c.hotKeysMtx.Lock()
+	top := c.hotKeys.top()
+	c.hotKeysMtx.Unlock()
+
+	for _, e := range top {
+		store, key := splitHotKey(e.key)
+		stats.HotKeys = append(stats.HotKeys, HotKey{
+			Store: store,
+			Key:   hex.EncodeToString(key),
+			Count: e.count * c.sampleRate,
+			Error: e.err * c.sampleRate,
+		})
+	}
+
+	return stats
+}
+
+// splitHotKey splits a key of the hot keys sketch into its store and key.
+// Store names never contain a slash.
+func splitHotKey(hotKey string) (string, []byte) {
+	i := strings.IndexByte(hotKey, '/')
+	if i < 0 {
+		return hotKey, nil
+	}
+	return hotKey[:i], []byte(hotKey[i+1:])
+}
@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// opMetrics are the Prometheus metrics of OpStats, by name suffix.
+var opMetrics = []struct {
+	name string
+	help string
+	get  func(OpStats) uint64
+}{
+	{"gets", "Gets of keys, including Has", func(s OpStats) uint64 { return s.Gets }},
+	{"sets", "Sets of keys", func(s OpStats) uint64 { return s.Sets }},
+	{"deletes", "Deletes of keys", func(s OpStats) uint64 { return s.Deletes }},
+	{"iterations", "Iterators created", func(s OpStats) uint64 { return s.Iterations }},
+	{"iterated_items", "Items visited by iterators", func(s OpStats) uint64 { return s.IteratedItems }},
+	{"read_bytes", "Bytes of the keys and values read", func(s OpStats) uint64 { return s.ReadBytes }},
+	{"write_bytes", "Bytes of the keys and values written", func(s OpStats) uint64 { return s.WriteBytes }},
+}
+
+// WritePrometheus writes stats in the Prometheus text exposition format. The
+// totals are counters labeled by store and module, the stats of the last block
+// are gauges labeled by store, and the hot keys are gauges labeled by store and
+// hex encoded key. Per tx stats are only exposed as JSON.
+func WritePrometheus(w io.Writer, stats Stats) error {
+	pw := &promWriter{w: w}
+
+	for _, m := range opMetrics {
+		name := "store_" + m.name + "_total"
+		pw.header(name, m.help+", by store.", "counter")
+		for _, s := range stats.Stores {
+			pw.sample(name, m.get(s.Ops), "store", s.Store, "module", s.Module)
+		}
+	}
+
+	pw.header("store_last_block_height", "Height of the last block with store metrics.", "gauge")
+	pw.sample("store_last_block_height", uint64(stats.LastBlock.Height))
+
+	for _, m := range opMetrics {
+		name := "store_last_block_" + m.name
+		pw.header(name, m.help+" in the last block, by store.", "gauge")
+		for _, s := range stats.LastBlock.Stores {
+			pw.sample(name, m.get(s.Ops), "store", s.Store, "module", s.Module)
+		}
+	}
+
+	pw.header("store_last_block_txs", "Txs of the last block.", "gauge")
+	pw.sample("store_last_block_txs", uint64(len(stats.LastBlock.Txs)))
+
+	pw.header("store_hot_key_accesses", "Estimated accesses to the hot keys, by store and key.", "gauge")
+	for _, k := range stats.HotKeys {
+		pw.sample("store_hot_key_accesses", k.Count, "store", k.Store, "key", k.Key)
+	}
+
+	return pw.err
+}
+
+// promWriter writes Prometheus metrics, keeping the first write error.
+type promWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (pw *promWriter) printf(format string, args ...interface{}) {
+	if pw.err == nil {
+		_, pw.err = fmt.Fprintf(pw.w, format, args...)
+	}
+}
+
+func (pw *promWriter) header(name, help, typ string) {
+	pw.printf("# HELP %s %s\n", name, help)
+	pw.printf("# TYPE %s %s\n", name, typ)
+}
+
+// sample writes a sample with the given label names and values.
+func (pw *promWriter) sample(name string, value uint64, labels ...string) {
+	if len(labels) == 0 {
+		pw.printf("%s %d\n", name, value)
+		return
+	}
+
+	pairs := make([]string, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", labels[i], labels[i+1]))
+	}
+	pw.printf("%s{%s} %d\n", name, strings.Join(pairs, ","), value)
+}
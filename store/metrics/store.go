@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"io"
+	"sync/atomic"
+
+	"github.com/cosmos/cosmos-sdk/store/cachekv"
+	"github.com/cosmos/cosmos-sdk/store/tracekv"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+var _ types.KVStore = &Store{}
+
+// Store counts the operations on an underlying KVStore into a Collector, under
+// the name of its store key. It implements the KVStore interface.
+type Store struct {
+	parent    types.KVStore
+	name      string
+	collector *Collector
+	counters  *counters
+}
+
+// NewStore returns a reference to a new metrics Store.
+func NewStore(parent types.KVStore, name string, collector *Collector) *Store {
+	return &Store{
+		parent:    parent,
+		name:      name,
+		collector: collector,
+		counters:  collector.counters(name),
+	}
+}
+
+// Implements Store.
+func (s *Store) GetStoreType() types.StoreType {
+	return s.parent.GetStoreType()
+}
+
+// Implements KVStore.
+func (s *Store) Get(key []byte) []byte {
+	value := s.parent.Get(key)
+
+	atomic.AddUint64(&s.counters.gets, 1)
+	atomic.AddUint64(&s.counters.readBytes, uint64(len(key)+len(value)))
+	s.collector.sample(s.name, key)
+
+	return value
+}
+
+// Implements KVStore.
+func (s *Store) Set(key []byte, value []byte) {
+	s.parent.Set(key, value)
+
+	atomic.AddUint64(&s.counters.sets, 1)
+	atomic.AddUint64(&s.counters.writeBytes, uint64(len(key)+len(value)))
+	s.collector.sample(s.name, key)
+}
+
+// Implements KVStore.
+func (s *Store) Has(key []byte) bool {
+	has := s.parent.Has(key)
+
+	atomic.AddUint64(&s.counters.gets, 1)
+	atomic.AddUint64(&s.counters.readBytes, uint64(len(key)))
+	s.collector.sample(s.name, key)
+
+	return has
+}
+
+// Implements KVStore.
+func (s *Store) Delete(key []byte) {
+	s.parent.Delete(key)
+
+	atomic.AddUint64(&s.counters.deletes, 1)
+	atomic.AddUint64(&s.counters.writeBytes, uint64(len(key)))
+	s.collector.sample(s.name, key)
+}
+
+// Implements KVStore.
+func (s *Store) Iterator(start, end []byte) types.Iterator {
+	atomic.AddUint64(&s.counters.iterations, 1)
+	return &iterator{parent: s.parent.Iterator(start, end), counters: s.counters}
+}
+
+// Implements KVStore.
+func (s *Store) ReverseIterator(start, end []byte) types.Iterator {
+	atomic.AddUint64(&s.counters.iterations, 1)
+	return &iterator{parent: s.parent.ReverseIterator(start, end), counters: s.counters}
+}
+
+// Implements KVStore.
+func (s *Store) CacheWrap() types.CacheWrap {
+	return cachekv.NewStore(s)
+}
+
+// CacheWrapWithTrace implements the KVStore interface.
+func (s *Store) CacheWrapWithTrace(w io.Writer, tc types.TraceContext) types.CacheWrap {
+	return cachekv.NewStore(tracekv.NewStore(s, w, tc))
+}
+
+// iterator counts the items visited by an underlying iterator, and their bytes.
+type iterator struct {
+	parent   types.Iterator
+	counters *counters
+}
+
+// Implements Iterator.
+func (it *iterator) Domain() (start []byte, end []byte) {
+	return it.parent.Domain()
+}
+
+// Implements Iterator.
+func (it *iterator) Valid() bool {
+	return it.parent.Valid()
+}
+
+// Implements Iterator.
+func (it *iterator) Next() {
+	if it.parent.Valid() {
+		atomic.AddUint64(&it.counters.iteratedItems, 1)
+		atomic.AddUint64(&it.counters.readBytes, uint64(len(it.parent.Key())+len(it.parent.Value())))
+	}
+
+	it.parent.Next()
+}
+
+// Implements Iterator.
+func (it *iterator) Key() []byte {
+	return it.parent.Key()
+}
+
+// Implements Iterator.
+func (it *iterator) Value() []byte {
+	return it.parent.Value()
+}
+
+// Implements Iterator.
+func (it *iterator) Close() {
+	it.parent.Close()
+}
+
+// Implements Iterator.
+func (it *iterator) Error() error {
+	return it.parent.Error()
+}
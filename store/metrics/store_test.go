@@ -0,0 +1,162 @@
+package metrics_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	"github.com/cosmos/cosmos-sdk/store/metrics"
+)
+
+func bz(s string) []byte { return []byte(s) }
+
+func storeOps(stats []metrics.StoreStats, store string) metrics.OpStats {
+	for _, s := range stats {
+		if s.Store == store {
+			return s.Ops
+		}
+	}
+	return metrics.OpStats{}
+}
+
+func TestStoreCountsOperations(t *testing.T) {
+	collector := metrics.NewCollector(metrics.DefaultHotKeys, 1)
+	collector.SetModule("acc", "auth")
+	mem := dbadapter.Store{DB: dbm.NewMemDB()}
+	st := metrics.NewStore(mem, "acc", collector)
+
+	st.Set(bz("k1"), bz("v1"))
+	st.Set(bz("k2"), bz("value"))
+	require.Equal(t, bz("v1"), st.Get(bz("k1")))
+	require.True(t, st.Has(bz("k2")))
+	st.Delete(bz("k2"))
+
+	iter := st.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+	}
+	iter.Close()
+
+	stats := collector.Stats()
+	require.Equal(t, metrics.OpStats{
+		Gets:          2,
+		Sets:          2,
+		Deletes:       1,
+		Iterations:    1,
+		IteratedItems: 1,
+		ReadBytes:     4 + 2 + 4,
+		WriteBytes:    4 + 7 + 2,
+	}, storeOps(stats.Stores, "acc"))
+	require.Equal(t, []metrics.ModuleStats{{Module: "auth", Ops: stats.Stores[0].Ops}}, stats.Modules)
+
+	// the operations of a cache wrapping the store are counted on write
+	cache := st.CacheWrap()
+	cache.(interface{ Set(k, v []byte) }).Set(bz("k3"), bz("v3"))
+	require.Equal(t, uint64(2), storeOps(collector.Stats().Stores, "acc").Sets)
+	cache.Write()
+	require.Equal(t, uint64(3), storeOps(collector.Stats().Stores, "acc").Sets)
+}
+
+func TestCollectorBlocksAndTxs(t *testing.T) {
+	collector := metrics.NewCollector(metrics.DefaultHotKeys, metrics.DefaultSampleRate)
+	mem := dbadapter.Store{DB: dbm.NewMemDB()}
+	acc := metrics.NewStore(mem, "acc", collector)
+	bank := metrics.NewStore(dbadapter.Store{DB: dbm.NewMemDB()}, "bank", collector)
+
+	// operations outside of a block are only counted in the totals
+	acc.Set(bz("k"), bz("v"))
+
+	collector.BeginBlock(10)
+	acc.Get(bz("k"))
+	collector.BeginTx()
+	bank.Set(bz("k"), bz("v"))
+	collector.EndTx([]byte{0xab})
+	collector.BeginTx()
+	collector.EndTx([]byte{0xcd})
+	collector.EndBlock()
+
+	last := collector.Stats().LastBlock
+	require.Equal(t, int64(10), last.Height)
+	require.Equal(t, []metrics.StoreStats{
+		{Store: "acc", Module: "acc", Ops: metrics.OpStats{Gets: 1, ReadBytes: 2}},
+		{Store: "bank", Module: "bank", Ops: metrics.OpStats{Sets: 1, WriteBytes: 2}},
+	}, last.Stores)
+	require.Equal(t, []metrics.TxStats{
+		{Hash: "AB", Stores: []metrics.StoreStats{
+			{Store: "bank", Module: "bank", Ops: metrics.OpStats{Sets: 1, WriteBytes: 2}},
+		}},
+		{Hash: "CD", Stores: []metrics.StoreStats{}},
+	}, last.Txs)
+
+	// the last block is kept until the next one ends
+	collector.BeginBlock(11)
+	acc.Get(bz("k"))
+	require.Equal(t, int64(10), collector.Stats().LastBlock.Height)
+	collector.EndBlock()
+	require.Equal(t, int64(11), collector.Stats().LastBlock.Height)
+	require.Empty(t, collector.Stats().LastBlock.Txs)
+}
+
+func TestCollectorHotKeys(t *testing.T) {
+	collector := metrics.NewCollector(2, 1)
+	st := metrics.NewStore(dbadapter.Store{DB: dbm.NewMemDB()}, "acc", collector)
+
+	for i := 0; i < 20; i++ {
+		st.Get(bz("hot"))
+	}
+	// keys containing a slash, which separates the store from the key
+	for i := 0; i < 10; i++ {
+		st.Get([]byte{byte(i), '/'})
+	}
+
+	hotKeys := collector.Stats().HotKeys
+	require.Len(t, hotKeys, 2)
+	require.Equal(t, "acc", hotKeys[0].Store)
+	require.Equal(t, hex.EncodeToString(bz("hot")), hotKeys[0].Key)
+	require.Equal(t, uint64(20), hotKeys[0].Count)
+	require.Zero(t, hotKeys[0].Error)
+
+	// the last cold key replaced the previous ones, inheriting their count as
+	// overestimation error
+	require.Equal(t, hex.EncodeToString([]byte{9, '/'}), hotKeys[1].Key)
+	require.Equal(t, uint64(10), hotKeys[1].Count)
+	require.Equal(t, uint64(9), hotKeys[1].Error)
+}
+
+func TestCollectorHotKeysSampling(t *testing.T) {
+	collector := metrics.NewCollector(metrics.DefaultHotKeys, 4)
+	st := metrics.NewStore(dbadapter.Store{DB: dbm.NewMemDB()}, "acc", collector)
+
+	for i := 0; i < 100; i++ {
+		st.Get(bz("hot"))
+	}
+
+	hotKeys := collector.Stats().HotKeys
+	require.Len(t, hotKeys, 1)
+	require.Equal(t, uint64(100), hotKeys[0].Count)
+}
+
+func TestWritePrometheus(t *testing.T) {
+	collector := metrics.NewCollector(metrics.DefaultHotKeys, 1)
+	collector.SetModule("acc", "auth")
+	st := metrics.NewStore(dbadapter.Store{DB: dbm.NewMemDB()}, "acc", collector)
+
+	collector.BeginBlock(7)
+	st.Set(bz("k"), bz("v"))
+	collector.EndBlock()
+
+	var buf bytes.Buffer
+	require.NoError(t, metrics.WritePrometheus(&buf, collector.Stats()))
+	out := buf.String()
+
+	require.Contains(t, out, "# TYPE store_sets_total counter\n")
+	require.Contains(t, out, `store_sets_total{store="acc",module="auth"} 1`+"\n")
+	require.Contains(t, out, `store_write_bytes_total{store="acc",module="auth"} 2`+"\n")
+	require.Contains(t, out, "store_last_block_height 7\n")
+	require.Contains(t, out, `store_last_block_sets{store="acc",module="auth"} 1`+"\n")
+	require.Contains(t, out, "store_last_block_txs 0\n")
+	require.Contains(t, out, `store_hot_key_accesses{store="acc",key="6b"} 1`+"\n")
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"sort"
+)
+
+// topK is a Space-Saving sketch of the k most frequent keys of a stream. It
+// tracks at most k keys: a key which isn't tracked replaces the least frequent
+// tracked one, inheriting its count as overestimation error. The count of a
+// key is thus never underestimated, and any key more frequent than n/k among
+// n accesses is guaranteed to be tracked.
+type topK struct {
+	k       int
+	entries []*topKEntry
+	byKey   map[string]*topKEntry
+}
+
+type topKEntry struct {
+	key   string
+	count uint64
+	err   uint64
+}
+
+func newTopK(k int) *topK {
+	return &topK{
+		k:     k,
+		byKey: make(map[string]*topKEntry, k),
+	}
+}
+
+// add counts an access to a key.
+func (t *topK) add(key string) {
+	if e, ok := t.byKey[key]; ok {
+		e.count++
+		return
+	}
+
+	if len(t.entries) < t.k {
+		e := &topKEntry{key: key, count: 1}
+		t.entries = append(t.entries, e)
+		t.byKey[key] = e
+		return
+	}
+
+	// Replace the least frequent key. k is small, so a linear scan is cheaper
+	// than maintaining a heap on every access.
+	min := t.entries[0]
+	for _, e := range t.entries[1:] {
+		if e.count < min.count {
+			min = e
+		}
+	}
+
+	delete(t.byKey, min.key)
+	min.key = key
+	min.err = min.count
+	min.count++
+	t.byKey[key] = min
+}
+
+// top returns the tracked keys, the most frequent first.
+func (t *topK) top() []topKEntry {
+	top := make([]topKEntry, len(t.entries))
+	for i, e := range t.entries {
+		top[i] = *e
+	}
+
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].count != top[j].count {
+			return top[i].count > top[j].count
+		}
+		return top[i].key < top[j].key
+	})
+
+	return top
+}
@@ -971,3 +971,187 @@ func (src Store) Copy() *Store {
 
 	return dst
 }
+
+// DiffVersions returns the diffs between two committed versions of the IAVL
+// stores persisted in db, sorted by store name, or of the named store only if
+// storeName isn't empty. Version 0 is the empty state preceding the first
+// version. A store mounted after the older version is diffed
+// against an empty store, while a store deleted before the newer version isn't
+// diffed since its data is deleted.
+func DiffVersions(db dbm.DB, storeName string, from, to int64) ([]types.StoreDiff, error) {
+	if from > to {
+		return nil, fmt.Errorf("cannot diff version %d against older version %d", from, to)
+	}
+
+	// version 0 is the empty state preceding the first version
+	var fromInfo commitInfo
+	if from > 0 {
+		info, err := getCommitInfo(db, from)
+		if err != nil {
+			return nil, fmt.Errorf("version %d: %w", from, err)
+		}
+		fromInfo = info
+	}
+	toInfo, err := getCommitInfo(db, to)
+	if err != nil {
+		return nil, fmt.Errorf("version %d: %w", to, err)
+	}
+
+	fromVersions := make(map[string]int64, len(fromInfo.StoreInfos))
+	for _, si := range fromInfo.StoreInfos {
+		fromVersions[si.Name] = si.Core.CommitID.Version
+	}
+
+	var diffs []types.StoreDiff
+	for _, si := range toInfo.StoreInfos {
+		// only IAVL stores are versioned
+		if (storeName != "" && si.Name != storeName) || si.Core.CommitID.Version < 0 {
+			continue
+		}
+
+		storeDB := dbm.NewPrefixDB(db, []byte("s/k:"+si.Name+"/"))
+		diff, err := iavl.DiffVersions(storeDB, fromVersions[si.Name], si.Core.CommitID.Version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff store %s: %w", si.Name, err)
+		}
+
+		diff.Store = si.Name
+		diffs = append(diffs, diff)
+	}
+
+	if storeName != "" && len(diffs) == 0 {
+		return nil, fmt.Errorf("no IAVL store %s at version %d", storeName, to)
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Store < diffs[j].Store })
+	return diffs, nil
+}
+
+// StoreHashes returns the app hash committed at a version, and the commit
+// hashes of the stores it aggregates by store name.
+func StoreHashes(db dbm.DB, version int64) (appHash []byte, hashes map[string][]byte, err error) {
+	cInfo, err := getCommitInfo(db, version)
+	if err != nil {
+		return nil, nil, fmt.Errorf("version %d: %w", version, err)
+	}
+
+	hashes = make(map[string][]byte, len(cInfo.StoreInfos))
+	for _, si := range cInfo.StoreInfos {
+		hashes[si.Name] = si.Core.CommitID.Hash
+	}
+
+	return cInfo.Hash(), hashes, nil
+}
+
+// RollbackToVersion rolls the multistore persisted in db back to a committed
+// version, deleting the later versions of its IAVL stores and the stores
+// mounted after it, so that it loads and commits again from that version.
+//
+// Every store is loaded at the version before any is modified, so that the
+// rollback fails without modifying db if a store doesn't keep it. The commit
+// info is then rolled back at once, before the later versions of the stores are
+// deleted store by store. Should deleting them fail, e.g. on an I/O error, db is
+// left at the version with the later versions of some stores remaining, and
+// running the rollback again completes it.
+func RollbackToVersion(db dbm.DB, version int64) error {
+	latest := getLatestVersion(db)
+	if version < 1 || version > latest {
+		return fmt.Errorf("cannot roll back to version %d, the latest version is %d", version, latest)
+	}
+
+	cInfo, err := getCommitInfo(db, version)
+	if err != nil {
+		return fmt.Errorf("version %d: %w", version, err)
+	}
+	latestInfo, err := getCommitInfo(db, latest)
+	if err != nil {
+		return fmt.Errorf("version %d: %w", latest, err)
+	}
+
+	// check that all the stores keep the version before rolling any back
+	trees := make(map[string]*iavltree.MutableTree)
+	storeVersions := make(map[string]int64)
+	for _, si := range cInfo.StoreInfos {
+		// only IAVL stores are versioned
+		if si.Core.CommitID.Version < 0 {
+			continue
+		}
+
+		storeVersions[si.Name] = si.Core.CommitID.Version
+		if si.Core.CommitID.Version == 0 {
+			continue
+		}
+
+		tree, err := iavltree.NewMutableTree(dbm.NewPrefixDB(db, []byte("s/k:"+si.Name+"/")), 0)
+		if err != nil {
+			return err
+		}
+		if _, err := tree.LoadVersion(si.Core.CommitID.Version); err != nil {
+			return fmt.Errorf("store %s can't load version %d: %w", si.Name, si.Core.CommitID.Version, err)
+		}
+
+		trees[si.Name] = tree
+	}
+
+	batch := db.NewBatch()
+	defer batch.Close()
+
+	// the stores mounted after the version, or empty at it, are deleted
+	for _, si := range latestInfo.StoreInfos {
+		if v, ok := storeVersions[si.Name]; ok && v > 0 {
+			continue
+		}
+		if err := deletePrefix(db, batch, []byte("s/k:"+si.Name+"/")); err != nil {
+			return err
+		}
+	}
+
+	for v := version + 1; v <= latest; v++ {
+		batch.Delete([]byte(fmt.Sprintf(commitInfoKeyFmt, v)))
+	}
+	setLatestVersion(batch, version)
+
+	if pruneHeights, err := getPruningHeights(db); err == nil {
+		setPruningHeights(batch, keepVersionsUpTo(pruneHeights, version))
+	}
+	if versions, err := getVersions(db); err == nil {
+		setVersions(batch, keepVersionsUpTo(versions, version))
+	}
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	for name, tree := range trees {
+		if _, err := tree.LoadVersionForOverwriting(storeVersions[name]); err != nil {
+			return fmt.Errorf("failed to roll back store %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func deletePrefix(db dbm.DB, batch dbm.Batch, prefix []byte) error {
+	iter, err := dbm.IteratePrefix(db, prefix)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		batch.Delete(iter.Key())
+	}
+
+	return nil
+}
+
+func keepVersionsUpTo(versions []int64, version int64) []int64 {
+	kept := make([]int64, 0, len(versions))
+	for _, v := range versions {
+		if v <= version {
+			kept = append(kept, v)
+		}
+	}
+
+	return kept
+}
@@ -543,6 +543,82 @@ func TestMultiStore_PruningRestart(t *testing.T) {
 //-----------------------------------------------------------------------
 // utils
 
+func TestRollbackToVersion(t *testing.T) {
+	db := dbm.NewMemDB()
+	ms := newMultiStoreWithMounts(db, types.PruneNothing)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	k, v := []byte("key"), []byte("value")
+	var commitIDs []types.CommitID
+	for i := 0; i < 3; i++ {
+		ms.getStoreByName("store1").(types.KVStore).Set(k, []byte(fmt.Sprintf("%s%d", v, i)))
+		commitIDs = append(commitIDs, ms.Commit())
+	}
+
+	require.Error(t, RollbackToVersion(db, 0))
+	require.Error(t, RollbackToVersion(db, 4))
+	require.NoError(t, RollbackToVersion(db, 2))
+
+	ms = newMultiStoreWithMounts(db, types.PruneNothing)
+	require.NoError(t, ms.LoadLatestVersion())
+	require.Equal(t, commitIDs[1], ms.LastCommitID())
+	require.Equal(t, []byte("value1"), ms.getStoreByName("store1").(types.KVStore).Get(k))
+
+	// version 3 is committed again, with a different state
+	ms.getStoreByName("store1").(types.KVStore).Set(k, []byte("other"))
+	commitID := ms.Commit()
+	require.Equal(t, int64(3), commitID.Version)
+	require.NotEqual(t, commitIDs[2].Hash, commitID.Hash)
+}
+
+func TestRollbackToVersionRetry(t *testing.T) {
+	db := dbm.NewMemDB()
+	ms := newMultiStoreWithMounts(db, types.PruneNothing)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	k := []byte("key")
+	for i := 0; i < 3; i++ {
+		ms.getStoreByName("store1").(types.KVStore).Set(k, []byte(fmt.Sprintf("value%d", i)))
+		ms.Commit()
+	}
+
+	// a rollback interrupted after rolling back the commit info, with the
+	// stores still at version 3
+	batch := db.NewBatch()
+	batch.Delete([]byte(fmt.Sprintf(commitInfoKeyFmt, 3)))
+	setLatestVersion(batch, 2)
+	require.NoError(t, batch.Write())
+	batch.Close()
+
+	require.NoError(t, RollbackToVersion(db, 2))
+
+	ms = newMultiStoreWithMounts(db, types.PruneNothing)
+	require.NoError(t, ms.LoadLatestVersion())
+	require.Equal(t, []byte("value1"), ms.getStoreByName("store1").(types.KVStore).Get(k))
+
+	ms.getStoreByName("store1").(types.KVStore).Set(k, []byte("other"))
+	require.Equal(t, int64(3), ms.Commit().Version)
+}
+
+func TestRollbackToPrunedVersion(t *testing.T) {
+	db := dbm.NewMemDB()
+	ms := newMultiStoreWithMounts(db, types.PruneEverything)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	var commitID types.CommitID
+	for i := 0; i < 12; i++ {
+		ms.getStoreByName("store1").(types.KVStore).Set([]byte("key"), []byte(fmt.Sprintf("value%d", i)))
+		commitID = ms.Commit()
+	}
+
+	require.Error(t, RollbackToVersion(db, 2))
+
+	// the failed rollback left the stores untouched
+	ms = newMultiStoreWithMounts(db, types.PruneEverything)
+	require.NoError(t, ms.LoadLatestVersion())
+	require.Equal(t, commitID, ms.LastCommitID())
+}
+
 func newMultiStoreWithMounts(db dbm.DB, pruningOpts types.PruningOptions) *Store {
 	store := NewStore(db)
 	store.pruningOpts = pruningOpts
@@ -12,6 +12,9 @@ func NewCommitMultiStore(db dbm.DB) types.CommitMultiStore {
 	return rootmulti.NewStore(db)
 }
 
-func NewCommitKVStoreCacheManager() types.MultiStorePersistentCache {
-	return cache.NewCommitKVStoreCacheManager(cache.DefaultCommitKVStoreCacheSize)
+// NewCommitKVStoreCacheManagerWithConfig returns an inter-block cache sizing
+// the cache of each store in bytes after the given config, such as
+// cache.DefaultConfig().
+func NewCommitKVStoreCacheManagerWithConfig(config cache.Config) types.MultiStorePersistentCache {
+	return cache.NewCommitKVStoreCacheManagerWithConfig(config)
 }
@@ -0,0 +1,158 @@
+package file
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/streaming"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+var cdc = codec.New()
+
+var _ streaming.Service = (*StreamingService)(nil)
+
+// blockHeader is the first record of a block file, counting the records
+// following it.
+type blockHeader struct {
+	Height    int64 `json:"height"`
+	NumTxs    int64 `json:"num_txs"`
+	NumWrites int64 `json:"num_writes"`
+}
+
+// StreamingService writes each committed block to a file of a directory named
+// block-<height>. A file holds amino encoded records, each prefixed by its
+// uvarint length, in this order:
+//
+//   - the block header, counting the txs and the writes of the block
+//   - the BeginBlock request and response
+//   - the request and response of each tx
+//   - the EndBlock request and response
+//   - the Commit response
+//   - the KV writes committed by the block
+//
+// A file is written under a temporary name and renamed once complete, so that
+// consumers watching the directory never read a partial block.
+type StreamingService struct {
+	dir string
+}
+
+// NewStreamingService returns a service writing the blocks to files of dir,
+// which is created if needed.
+func NewStreamingService(dir string) (*StreamingService, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &StreamingService{dir: dir}, nil
+}
+
+// BlockPath returns the path of the file of a block.
+func (s *StreamingService) BlockPath(height int64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("block-%d", height))
+}
+
+// ListenCommit implements the streaming.Service interface.
+func (s *StreamingService) ListenCommit(block streaming.Block) error {
+	height := block.RequestBeginBlock.Header.Height
+
+	tmp, err := ioutil.TempFile(s.dir, fmt.Sprintf(".block-%d-", height))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	err = writeBlock(tmp, block)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write block %d: %w", height, err)
+	}
+
+	return os.Rename(tmp.Name(), s.BlockPath(height))
+}
+
+func writeBlock(w io.Writer, block streaming.Block) error {
+	buffered := bufio.NewWriter(w)
+
+	records := []interface{}{
+		blockHeader{
+			Height:    block.RequestBeginBlock.Header.Height,
+			NumTxs:    int64(len(block.DeliverTxs)),
+			NumWrites: int64(len(block.Writes)),
+		},
+		block.RequestBeginBlock,
+		block.ResponseBeginBlock,
+	}
+	for _, tx := range block.DeliverTxs {
+		records = append(records, tx.Request, tx.Response)
+	}
+	records = append(records, block.RequestEndBlock, block.ResponseEndBlock, block.ResponseCommit)
+	for _, write := range block.Writes {
+		records = append(records, write)
+	}
+
+	for _, record := range records {
+		bz, err := cdc.MarshalBinaryLengthPrefixed(record)
+		if err != nil {
+			return err
+		}
+		if _, err := buffered.Write(bz); err != nil {
+			return err
+		}
+	}
+
+	return buffered.Flush()
+}
+
+// ReadBlock reads a block written by a StreamingService.
+func ReadBlock(path string) (streaming.Block, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return streaming.Block{}, err
+	}
+	defer file.Close()
+
+	r := bufio.NewReader(file)
+	read := func(ptr interface{}) {
+		if err == nil {
+			_, err = cdc.UnmarshalBinaryLengthPrefixedReader(r, ptr, 0)
+		}
+	}
+
+	var header blockHeader
+	read(&header)
+	if err != nil {
+		return streaming.Block{}, err
+	}
+
+	var block streaming.Block
+	read(&block.RequestBeginBlock)
+	read(&block.ResponseBeginBlock)
+	block.DeliverTxs = make([]streaming.Tx, header.NumTxs)
+	for i := range block.DeliverTxs {
+		read(&block.DeliverTxs[i].Request)
+		read(&block.DeliverTxs[i].Response)
+	}
+	read(&block.RequestEndBlock)
+	read(&block.ResponseEndBlock)
+	read(&block.ResponseCommit)
+	block.Writes = make([]types.StoreKVPair, header.NumWrites)
+	for i := range block.Writes {
+		read(&block.Writes[i])
+	}
+
+	if err != nil {
+		return streaming.Block{}, fmt.Errorf("invalid block file %s: %w", path, err)
+	}
+	return block, nil
+}
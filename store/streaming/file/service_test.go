@@ -0,0 +1,77 @@
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/cosmos/cosmos-sdk/store/streaming"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+func TestStreamingService(t *testing.T) {
+	dir, err := ioutil.TempDir("", "streaming")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	service, err := NewStreamingService(dir)
+	require.NoError(t, err)
+
+	event := abci.Event{Type: "transfer", Attributes: []kv.Pair{{Key: []byte("recipient"), Value: []byte("alice")}}}
+	block := streaming.Block{
+		RequestBeginBlock: abci.RequestBeginBlock{
+			Hash:   []byte("hash"),
+			Header: abci.Header{ChainID: "test", Height: 7, Time: time.Unix(1600000000, 0).UTC()},
+		},
+		ResponseBeginBlock: abci.ResponseBeginBlock{Events: []abci.Event{event}},
+		DeliverTxs: []streaming.Tx{
+			{
+				Request:  abci.RequestDeliverTx{Tx: []byte("tx1")},
+				Response: abci.ResponseDeliverTx{GasUsed: 10, Events: []abci.Event{event}},
+			},
+			{
+				Request:  abci.RequestDeliverTx{Tx: []byte("tx2")},
+				Response: abci.ResponseDeliverTx{Code: 5, Log: "failed"},
+			},
+		},
+		RequestEndBlock:  abci.RequestEndBlock{Height: 7},
+		ResponseEndBlock: abci.ResponseEndBlock{Events: []abci.Event{event}},
+		ResponseCommit:   abci.ResponseCommit{Data: []byte("apphash")},
+		Writes: []types.StoreKVPair{
+			{StoreKey: "bank", Key: []byte("a"), Value: []byte("1")},
+			{StoreKey: "acc", Key: []byte("b"), Delete: true},
+		},
+	}
+	require.NoError(t, service.ListenCommit(block))
+
+	// only the complete block file is left
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Equal(t, "block-7", files[0].Name())
+
+	read, err := ReadBlock(service.BlockPath(7))
+	require.NoError(t, err)
+	require.Equal(t, block, read)
+
+	// a block without txs nor writes
+	empty := streaming.Block{RequestBeginBlock: abci.RequestBeginBlock{Header: abci.Header{Height: 8}}}
+	require.NoError(t, service.ListenCommit(empty))
+	read, err = ReadBlock(service.BlockPath(8))
+	require.NoError(t, err)
+	require.Equal(t, int64(8), read.RequestBeginBlock.Header.Height)
+	require.Empty(t, read.DeliverTxs)
+	require.Empty(t, read.Writes)
+
+	// a truncated file is invalid
+	bz, err := ioutil.ReadFile(service.BlockPath(7))
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(service.BlockPath(7), bz[:len(bz)-3], 0644))
+	_, err = ReadBlock(service.BlockPath(7))
+	require.Error(t, err)
+}
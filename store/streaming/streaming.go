@@ -0,0 +1,39 @@
+package streaming
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// Service is notified of each block committed by the BaseApp, to stream the
+// state changes to external consumers.
+type Service interface {
+	// ListenCommit is called once a block is committed, so that no uncommitted
+	// data is ever streamed. The block must not be retained after the call.
+	//
+	// NOTE: As the block is already committed, an error can't undo it and is
+	// only logged by the BaseApp: the block is dropped from the stream, which
+	// consumers may detect by the gap in the heights of the streamed blocks.
+	ListenCommit(block Block) error
+}
+
+// Tx is a tx delivered in a streamed Block.
+type Tx struct {
+	Request  abci.RequestDeliverTx  `json:"request"`
+	Response abci.ResponseDeliverTx `json:"response"`
+}
+
+// Block is a block committed by the BaseApp: the ABCI requests and responses of
+// its execution, and the KV writes it committed to the stores persisted in the
+// app hash, in the order they were written to the DeliverTx state. The writes
+// of the first block include the ones of InitChain.
+type Block struct {
+	RequestBeginBlock  abci.RequestBeginBlock  `json:"request_begin_block"`
+	ResponseBeginBlock abci.ResponseBeginBlock `json:"response_begin_block"`
+	DeliverTxs         []Tx                    `json:"deliver_txs"`
+	RequestEndBlock    abci.RequestEndBlock    `json:"request_end_block"`
+	ResponseEndBlock   abci.ResponseEndBlock   `json:"response_end_block"`
+	ResponseCommit     abci.ResponseCommit     `json:"response_commit"`
+	Writes             []types.StoreKVPair     `json:"writes"`
+}
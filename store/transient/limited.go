@@ -0,0 +1,73 @@
+package transient
+
+import (
+	"io"
+
+	"github.com/cosmos/cosmos-sdk/store/cachekv"
+	"github.com/cosmos/cosmos-sdk/store/tracekv"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+var _ types.KVStore = &LimitedStore{}
+
+// LimitedStore counts the writes to an underlying transient KVStore into a
+// Limiter, under the name of its store key, before applying them. It
+// implements the KVStore interface.
+type LimitedStore struct {
+	parent  types.KVStore
+	name    string
+	limiter *Limiter
+}
+
+// NewLimitedStore returns a reference to a new LimitedStore.
+func NewLimitedStore(parent types.KVStore, name string, limiter *Limiter) *LimitedStore {
+	return &LimitedStore{parent: parent, name: name, limiter: limiter}
+}
+
+// Implements Store.
+func (s *LimitedStore) GetStoreType() types.StoreType {
+	return s.parent.GetStoreType()
+}
+
+// Implements KVStore.
+func (s *LimitedStore) Get(key []byte) []byte {
+	return s.parent.Get(key)
+}
+
+// Implements KVStore.
+func (s *LimitedStore) Set(key []byte, value []byte) {
+	types.AssertValidValue(value)
+	s.limiter.consume(s.name, uint64(len(key)+len(value)))
+	s.parent.Set(key, value)
+}
+
+// Implements KVStore.
+func (s *LimitedStore) Has(key []byte) bool {
+	return s.parent.Has(key)
+}
+
+// Implements KVStore.
+func (s *LimitedStore) Delete(key []byte) {
+	s.limiter.consume(s.name, uint64(len(key)))
+	s.parent.Delete(key)
+}
+
+// Implements KVStore.
+func (s *LimitedStore) Iterator(start, end []byte) types.Iterator {
+	return s.parent.Iterator(start, end)
+}
+
+// Implements KVStore.
+func (s *LimitedStore) ReverseIterator(start, end []byte) types.Iterator {
+	return s.parent.ReverseIterator(start, end)
+}
+
+// Implements KVStore.
+func (s *LimitedStore) CacheWrap() types.CacheWrap {
+	return cachekv.NewStore(s)
+}
+
+// CacheWrapWithTrace implements the KVStore interface.
+func (s *LimitedStore) CacheWrapWithTrace(w io.Writer, tc types.TraceContext) types.CacheWrap {
+	return cachekv.NewStore(tracekv.NewStore(s, w, tc))
+}
@@ -0,0 +1,198 @@
+package transient
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// CacheMultiStore is implemented by the cache multi-stores which can limit the
+// bytes written to their transient stores.
+type CacheMultiStore interface {
+	types.CacheMultiStore
+
+	// SetLimiter returns a copy of the multi-store whose transient KVStores
+	// count their writes into the given limiter.
+	SetLimiter(limiter *Limiter) types.CacheMultiStore
+}
+
+// Usage counts the writes to a transient store and the bytes they wrote: the
+// key and value of a set, the key of a delete.
+type Usage struct {
+	Store  string `json:"store"`
+	Module string `json:"module"`
+	Writes uint64 `json:"writes"`
+	Bytes  uint64 `json:"bytes"`
+}
+
+// ModuleUsage is the Usage of all the transient stores of a module.
+type ModuleUsage struct {
+	Module string `json:"module"`
+	Writes uint64 `json:"writes"`
+	Bytes  uint64 `json:"bytes"`
+}
+
+// BlockUsage is the Usage of the transient stores written in a block.
+type BlockUsage struct {
+	Height int64   `json:"height"`
+	Bytes  uint64  `json:"bytes"`
+	Stores []Usage `json:"stores"`
+}
+
+// Stats are the metrics gathered by a Limiter: the limit of the last block, the
+// totals since it was created per module, the usage of the last committed block, the block
+// which wrote the most bytes, and the number of writes rejected by the limit.
+type Stats struct {
+	Limit     uint64        `json:"limit"`
+	Modules   []ModuleUsage `json:"modules"`
+	LastBlock BlockUsage    `json:"last_block"`
+	PeakBlock BlockUsage    `json:"peak_block"`
+	Rejected  uint64        `json:"rejected"`
+}
+
+// Limiter caps the bytes written to the transient stores wrapped by
+// NewLimitedStore during a block, with the limit given for the block. Only the writes of txs are rejected, by
+// panicking with an ErrorTransientStoreLimit which fails the tx: the writes of
+// the begin and end blockers are counted, but can't fail without halting the
+// chain. The writes of failed txs are counted too, as their memory was used.
+//
+// A block is delimited by BeginBlock and EndBlock, and its txs by BeginTx and
+// EndTx, which must not be called concurrently, as BaseApp does for DeliverTx.
+type Limiter struct {
+	mtx     sync.Mutex
+	limit   uint64
+	modules map[string]string
+
+	height  int64
+	inTx    bool
+	used    uint64
+	block   map[string]*Usage
+	totals  map[string]*ModuleUsage
+	last    BlockUsage
+	peak    BlockUsage
+	rejects uint64
+}
+
+// NewLimiter returns a Limiter, which counts the bytes written to the transient
+// stores without limiting them until a block sets a limit.
+func NewLimiter() *Limiter {
+	return &Limiter{
+		modules: make(map[string]string),
+		block:   make(map[string]*Usage),
+		totals:  make(map[string]*ModuleUsage),
+	}
+}
+
+// SetModule sets the module owning a transient store, which defaults to the
+// name of its store key.
+func (l *Limiter) SetModule(store, module string) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.modules[store] = module
+}
+
+// moduleOf returns the module owning a store. mtx must be held.
+func (l *Limiter) moduleOf(store string) string {
+	if module, ok := l.modules[store]; ok {
+		return module
+	}
+	return store
+}
+
+// consume counts a write of n bytes to a store, panicking if it is written by
+// a tx and exceeds the limit.
+func (l *Limiter) consume(store string, n uint64) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if l.inTx && l.limit > 0 && l.used+n > l.limit {
+		l.rejects++
+		panic(types.ErrorTransientStoreLimit{Store: store, Limit: l.limit, Used: l.used})
+	}
+
+	l.used += n
+
+	usage, ok := l.block[store]
+	if !ok {
+		usage = &Usage{Store: store, Module: l.moduleOf(store)}
+		l.block[store] = usage
+	}
+	usage.Writes++
+	usage.Bytes += n
+
+	total, ok := l.totals[usage.Module]
+	if !ok {
+		total = &ModuleUsage{Module: usage.Module}
+		l.totals[usage.Module] = total
+	}
+	total.Writes++
+	total.Bytes += n
+}
+
+// BeginBlock starts counting the writes of a block, allowing the given number
+// of bytes to be written by its txs, 0 meaning no limit.
+func (l *Limiter) BeginBlock(height int64, limit uint64) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.height = height
+	l.limit = limit
+	l.used = 0
+	l.block = make(map[string]*Usage)
+}
+
+// EndBlock ends counting the writes of the current block, whose usage is
+// returned by Stats until the next block ends.
+func (l *Limiter) EndBlock() {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	block := BlockUsage{Height: l.height, Bytes: l.used, Stores: make([]Usage, 0, len(l.block))}
+	for _, usage := range l.block {
+		block.Stores = append(block.Stores, *usage)
+	}
+	sort.Slice(block.Stores, func(i, j int) bool { return block.Stores[i].Store < block.Stores[j].Store })
+
+	l.last = block
+	if block.Bytes > l.peak.Bytes {
+		l.peak = block
+	}
+}
+
+// BeginTx starts enforcing the limit on the writes of a tx.
+func (l *Limiter) BeginTx() {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.inTx = true
+}
+
+// EndTx stops enforcing the limit, until the next tx begins.
+func (l *Limiter) EndTx() {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.inTx = false
+}
+
+// Stats returns the metrics gathered so far.
+func (l *Limiter) Stats() Stats {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	stats := Stats{
+		Limit:     l.limit,
+		Modules:   make([]ModuleUsage, 0, len(l.totals)),
+		LastBlock: l.last,
+		PeakBlock: l.peak,
+		Rejected:  l.rejects,
+	}
+	for _, total := range l.totals {
+		stats.Modules = append(stats.Modules, *total)
+	}
+	sort.Slice(stats.Modules, func(i, j int) bool { return stats.Modules[i].Module < stats.Modules[j].Module })
+
+	return stats
+}
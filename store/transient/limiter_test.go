@@ -0,0 +1,49 @@
+package transient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+func TestLimitedStore(t *testing.T) {
+	limiter := NewLimiter()
+	tstore := NewLimitedStore(NewStore(), "tkey", limiter)
+
+	// writes outside txs are counted but never rejected
+	limiter.BeginBlock(1, 15)
+	tstore.Set(k, v)
+	tstore.Delete(k)
+	limiter.EndBlock()
+	require.Equal(t, BlockUsage{
+		Height: 1,
+		Bytes:  15,
+		Stores: []Usage{{Store: "tkey", Module: "tkey", Writes: 2, Bytes: 15}},
+	}, limiter.Stats().LastBlock)
+
+	limiter.BeginBlock(2, 15)
+	limiter.BeginTx()
+	tstore.Set(k, v)
+	require.Equal(t, v, tstore.Get(k))
+
+	// the rejected write is not applied
+	require.PanicsWithValue(t, types.ErrorTransientStoreLimit{Store: "tkey", Limit: 15, Used: 10}, func() {
+		tstore.Set([]byte("key"), []byte("value"))
+	})
+	require.False(t, tstore.Has([]byte("key")))
+
+	tstore.Delete(k)
+	limiter.EndTx()
+	limiter.EndBlock()
+
+	stats := limiter.Stats()
+	require.Equal(t, uint64(1), stats.Rejected)
+	require.Equal(t, BlockUsage{
+		Height: 2,
+		Bytes:  15,
+		Stores: []Usage{{Store: "tkey", Module: "tkey", Writes: 2, Bytes: 15}},
+	}, stats.LastBlock)
+	require.Equal(t, []ModuleUsage{{Module: "tkey", Writes: 4, Bytes: 30}}, stats.Modules)
+}
@@ -28,6 +28,15 @@ type ErrorGasOverflow struct {
 	Descriptor string
 }
 
+// ErrorTransientStoreLimit defines an error thrown when a write to a transient
+// store would exceed the bytes allowed to be written to the transient stores
+// in a block.
+type ErrorTransientStoreLimit struct {
+	Store string
+	Limit uint64
+	Used  uint64
+}
+
 // GasMeter interface to track gas consumption
 type GasMeter interface {
 	GasConsumed() Gas
@@ -162,8 +171,20 @@ func KVGasConfig() GasConfig {
 	}
 }
 
-// TransientGasConfig returns a default gas config for TransientStores.
+// TransientGasConfig returns a default gas config for TransientStores. Their
+// data lives in memory until the end of the block and is neither persisted nor
+// merklized, so their operations cost a tenth of the ones of KVStores. As the
+// gas of txs is part of the consensus, a Context charges these costs for the
+// transient stores only once given them with WithTransientGasConfig, and the
+// ones of KVGasConfig otherwise.
 func TransientGasConfig() GasConfig {
-	// TODO: define gasconfig for transient stores
-	return KVGasConfig()
+	return GasConfig{
+		HasCost:          100,
+		DeleteCost:       100,
+		ReadCostFlat:     100,
+		ReadCostPerByte:  0,
+		WriteCostFlat:    200,
+		WriteCostPerByte: 3,
+		IterNextCostFlat: 3,
+	}
 }
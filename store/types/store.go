@@ -339,12 +339,52 @@ func (key *TransientStoreKey) String() string {
 // key-value result for iterator queries
 type KVPair tmkv.Pair
 
+// KVDiff is a key whose value differs between two versions of a store. Old is
+// nil if the key was added, and New is nil if it was removed.
+type KVDiff struct {
+	Key []byte `json:"key"`
+	Old []byte `json:"old"`
+	New []byte `json:"new"`
+}
+
+// StoreDiff holds the keys added, changed and removed between two versions of
+// a store, each sorted by key.
+type StoreDiff struct {
+	Store   string   `json:"store"`
+	From    int64    `json:"from"`
+	To      int64    `json:"to"`
+	Added   []KVDiff `json:"added"`
+	Changed []KVDiff `json:"changed"`
+	Removed []KVDiff `json:"removed"`
+}
+
+// IsEmpty returns true if no key differs between the two versions.
+func (d StoreDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
 //----------------------------------------
 
 // TraceContext contains TraceKVStore context data. It will be written with
 // every trace operation.
 type TraceContext map[string]interface{}
 
+// WriteListener is notified of the writes to the KVStores it listens to. The
+// key and value must be copied if they are retained.
+type WriteListener interface {
+	// OnWrite is called on each Set, with delete false, and each Delete, with
+	// delete true and a nil value.
+	OnWrite(storeKey StoreKey, key, value []byte, delete bool)
+}
+
+// StoreKVPair is a write to a KVStore: the key set to the value, or deleted.
+type StoreKVPair struct {
+	StoreKey string `json:"store_key"`
+	Delete   bool   `json:"delete"`
+	Key      []byte `json:"key"`
+	Value    []byte `json:"value"`
+}
+
 // MultiStorePersistentCache defines an interface which provides inter-block
 // (persistent) caching capabilities for multiple CommitKVStores based on StoreKeys.
 type MultiStorePersistentCache interface {
@@ -35,6 +35,8 @@ type Context struct {
 	minGasPrice   DecCoins
 	consParams    *abci.ConsensusParams
 	eventManager  *EventManager
+
+	transientGasConfig *stypes.GasConfig
 }
 
 // Proposed rename, not done to avoid API breakage
@@ -66,6 +68,15 @@ func (c Context) ConsensusParams() *abci.ConsensusParams {
 	return proto.Clone(c.consParams).(*abci.ConsensusParams)
 }
 
+// TransientGasConfig returns the gas costs charged by the transient stores,
+// which are the ones of the KVStores unless set with WithTransientGasConfig.
+func (c Context) TransientGasConfig() stypes.GasConfig {
+	if c.transientGasConfig == nil {
+		return stypes.KVGasConfig()
+	}
+	return *c.transientGasConfig
+}
+
 // create a new context
 func NewContext(ms MultiStore, header abci.Header, isCheckTx bool, logger log.Logger) Context {
 	// https://github.com/gogo/protobuf/issues/519
@@ -179,6 +190,13 @@ func (c Context) WithEventManager(em *EventManager) Context {
 	return c
 }
 
+// WithTransientGasConfig returns a Context whose transient stores charge the
+// given gas costs.
+func (c Context) WithTransientGasConfig(config stypes.GasConfig) Context {
+	c.transientGasConfig = &config
+	return c
+}
+
 // TODO: remove???
 func (c Context) IsZero() bool {
 	return c.ms == nil
@@ -214,7 +232,7 @@ func (c Context) KVStore(key StoreKey) KVStore {
 
 // TransientStore fetches a TransientStore from the MultiStore.
 func (c Context) TransientStore(key StoreKey) KVStore {
-	return gaskv.NewStore(c.MultiStore().GetKVStore(key), c.GasMeter(), stypes.TransientGasConfig())
+	return gaskv.NewStore(c.MultiStore().GetKVStore(key), c.GasMeter(), c.TransientGasConfig())
 }
 
 // CacheContext returns a new Context with the multi-store cached and a new
@@ -82,6 +82,12 @@ var (
 	// ErrTxTooLarge defines an ABCI typed error where tx is too large.
 	ErrTxTooLarge = Register(RootCodespace, 21, "tx too large")
 
+	// ErrTransientStoreLimit defines an ABCI typed error where a tx exceeds the
+	// bytes allowed to be written to the transient stores in a block. Code 22
+	// is taken by the gas overflow error of the types package, which registers
+	// its internal error with code 23 in the undefined codespace.
+	ErrTransientStoreLimit = Register(RootCodespace, 24, "transient store limit exceeded")
+
 	// ErrPanic is only set when we recover from a panic, so we know to
 	// redact potentially sensitive system info
 	ErrPanic = Register(UndefinedCodespace, 111222, "panic")
@@ -0,0 +1,82 @@
+package module
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MigrationHandler is the migration function that each module registers to
+// migrate its store from one consensus version to the next.
+type MigrationHandler func(sdk.Context) error
+
+// VersionMap is a map of module name to its consensus version.
+type VersionMap map[string]uint64
+
+// Configurator provides the hooks that allow modules to register their store
+// migrations with the Manager.
+type Configurator interface {
+	// RegisterMigration registers a handler migrating the store of the given
+	// module from fromVersion to fromVersion+1.
+	RegisterMigration(moduleName string, fromVersion uint64, handler MigrationHandler) error
+}
+
+type configurator struct {
+	// migrations is a map of moduleName -> fromVersion -> migration handler
+	migrations map[string]map[uint64]MigrationHandler
+}
+
+var _ Configurator = configurator{}
+
+// NewConfigurator returns a new, empty Configurator.
+func NewConfigurator() Configurator {
+	return configurator{
+		migrations: make(map[string]map[uint64]MigrationHandler),
+	}
+}
+
+// RegisterMigration implements the Configurator.RegisterMigration method.
+func (c configurator) RegisterMigration(moduleName string, fromVersion uint64, handler MigrationHandler) error {
+	if fromVersion == 0 {
+		return fmt.Errorf("module %s: migrations must start from consensus version 1", moduleName)
+	}
+
+	if c.migrations[moduleName] == nil {
+		c.migrations[moduleName] = make(map[uint64]MigrationHandler)
+	}
+
+	if c.migrations[moduleName][fromVersion] != nil {
+		return fmt.Errorf("module %s: migration from consensus version %d already registered", moduleName, fromVersion)
+	}
+
+	c.migrations[moduleName][fromVersion] = handler
+	return nil
+}
+
+// runModuleMigrations runs all in-place store migrations of the given module
+// from fromVersion up to toVersion.
+func (c configurator) runModuleMigrations(ctx sdk.Context, moduleName string, fromVersion, toVersion uint64) error {
+	// no-op if the module is already at the latest version
+	if toVersion <= 1 || fromVersion == toVersion {
+		return nil
+	}
+
+	moduleMigrations, found := c.migrations[moduleName]
+	if !found {
+		return fmt.Errorf("no migrations found for module %s", moduleName)
+	}
+
+	// run all migrations in sequence from fromVersion to toVersion-1
+	for i := fromVersion; i < toVersion; i++ {
+		migrateFn, found := moduleMigrations[i]
+		if !found {
+			return fmt.Errorf("no migration found for module %s from version %d to version %d", moduleName, i, i+1)
+		}
+
+		if err := migrateFn(ctx); err != nil {
+			return fmt.Errorf("module %s: migration from version %d failed: %w", moduleName, i, err)
+		}
+	}
+
+	return nil
+}
@@ -30,6 +30,8 @@ package module
 
 import (
 	"encoding/json"
+	"fmt"
+	"sort"
 
 	"github.com/gorilla/mux"
 	"github.com/spf13/cobra"
@@ -148,6 +150,31 @@ type AppModule interface {
 	EndBlock(sdk.Context, abci.RequestEndBlock) []abci.ValidatorUpdate
 }
 
+// HasConsensusVersion is implemented by modules that have a consensus version
+// other than the initial one, 1.
+type HasConsensusVersion interface {
+	// ConsensusVersion is a sequence number for state-breaking changes of the
+	// module. It should be incremented on each consensus-breaking change
+	// introduced by the module, along with a migration registered from the
+	// previous version. Versions start at 1.
+	ConsensusVersion() uint64
+}
+
+// consensusVersion returns the consensus version of a module, which is 1 if
+// it doesn't implement HasConsensusVersion.
+func consensusVersion(module AppModule) uint64 {
+	if m, ok := module.(HasConsensusVersion); ok {
+		return m.ConsensusVersion()
+	}
+	return 1
+}
+
+// AppModuleMigration is implemented by modules that have registered store
+// migrations between their consensus versions.
+type AppModuleMigration interface {
+	RegisterMigrations(Configurator)
+}
+
 //___________________________
 
 // GenesisOnlyAppModule is an AppModule that only has import/export functionality
@@ -328,3 +355,136 @@ func (m *Manager) EndBlock(ctx sdk.Context, req abci.RequestEndBlock) abci.Respo
 		Events:           ctx.EventManager().ABCIEvents(),
 	}
 }
+
+// RegisterMigrations registers the store migrations of all modules
+// implementing AppModuleMigration with the given Configurator.
+func (m *Manager) RegisterMigrations(cfg Configurator) {
+	for _, module := range m.Modules {
+		if module, ok := module.(AppModuleMigration); ok {
+			module.RegisterMigrations(cfg)
+		}
+	}
+}
+
+// GetVersionMap gets the consensus versions of all modules.
+func (m *Manager) GetVersionMap() VersionMap {
+	vm := make(VersionMap, len(m.Modules))
+	for name, module := range m.Modules {
+		vm[name] = consensusVersion(module)
+	}
+	return vm
+}
+
+// RunMigrations performs the in-place store migrations of all modules, in the
+// init genesis order of the Manager followed by the remaining modules sorted by
+// name, from the consensus versions in fromVM to the current versions of the
+// modules. Modules that are not present in fromVM are at version 1, as on
+// chains started before the versions were stored, whose fromVM is empty.
+//
+// Only the modules named in added, i.e. whose stores are added by the store
+// upgrades of the upgrade, are new: they get their default genesis initialized
+// instead of being migrated. It returns the updated version map, which should
+// be persisted by the caller.
+//
+// The configurator must be the one the modules registered their migrations
+// with, see RegisterMigrations.
+func (m *Manager) RunMigrations(ctx sdk.Context, cfg Configurator, fromVM VersionMap, added []string) (VersionMap, error) {
+	c, ok := cfg.(configurator)
+	if !ok {
+		return nil, fmt.Errorf("expected configurator created with NewConfigurator, got %T", cfg)
+	}
+
+	isAdded := make(map[string]bool, len(added))
+	for _, moduleName := range added {
+		if _, ok := m.Modules[moduleName]; !ok {
+			return nil, fmt.Errorf("added module %s is not registered", moduleName)
+		}
+		isAdded[moduleName] = true
+	}
+
+	updatedVM := make(VersionMap, len(m.Modules))
+	for _, moduleName := range m.migrationOrder() {
+		module := m.Modules[moduleName]
+		toVersion := consensusVersion(module)
+
+		fromVersion, exists := fromVM[moduleName]
+		switch {
+		case isAdded[moduleName] && exists:
+			return nil, fmt.Errorf("added module %s already exists at version %d", moduleName, fromVersion)
+
+		case isAdded[moduleName]:
+			ctx.Logger().Info(fmt.Sprintf("adding a new module: %s", moduleName))
+			valUpdates := module.InitGenesis(ctx, module.DefaultGenesis())
+			if len(valUpdates) > 0 {
+				return nil, fmt.Errorf("new module %s returned validator updates on init genesis", moduleName)
+			}
+
+		default:
+			if !exists {
+				fromVersion = 1
+			}
+			if fromVersion > toVersion {
+				return nil, fmt.Errorf(
+					"module %s cannot be downgraded from version %d to version %d", moduleName, fromVersion, toVersion,
+				)
+			}
+			if err := c.runModuleMigrations(ctx, moduleName, fromVersion, toVersion); err != nil {
+				return nil, err
+			}
+		}
+
+		updatedVM[moduleName] = toVersion
+	}
+
+	return updatedVM, nil
+}
+
+// migrationOrder returns the names of all modules, starting with the ones of
+// the init genesis order.
+func (m *Manager) migrationOrder() []string {
+	order := make([]string, 0, len(m.Modules))
+	ordered := make(map[string]bool, len(m.Modules))
+	for _, moduleName := range m.OrderInitGenesis {
+		if _, ok := m.Modules[moduleName]; ok && !ordered[moduleName] {
+			order = append(order, moduleName)
+			ordered[moduleName] = true
+		}
+	}
+
+	rest := make([]string, 0, len(m.Modules)-len(order))
+	for moduleName := range m.Modules {
+		if !ordered[moduleName] {
+			rest = append(rest, moduleName)
+		}
+	}
+	sort.Strings(rest)
+
+	return append(order, rest...)
+}
+
+// ValidateVersionMap returns an error if the given consensus versions, e.g.
+// the ones a genesis file was exported at, differ from the current versions of
+// the modules. State produced at other versions has to be migrated first.
+func (m *Manager) ValidateVersionMap(vm VersionMap) error {
+	current := m.GetVersionMap()
+
+	names := make([]string, 0, len(vm))
+	for name := range vm {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		version, ok := current[name]
+		if !ok {
+			return fmt.Errorf("unknown module %s at consensus version %d", name, vm[name])
+		}
+		if version != vm[name] {
+			return fmt.Errorf(
+				"module %s is at consensus version %d, expected version %d", name, vm[name], version,
+			)
+		}
+	}
+
+	return nil
+}
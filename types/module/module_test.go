@@ -1,10 +1,15 @@
 package module
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 func TestSetOrderBeginBlockers(t *testing.T) {
@@ -14,3 +19,128 @@ func TestSetOrderBeginBlockers(t *testing.T) {
 	require.Equal(t, 3, len(obb))
 	assert.Equal(t, []string{"a", "b", "c"}, obb)
 }
+
+// testModule overrides the AppModule methods used by the migrations; calling
+// any other method panics.
+type testModule struct {
+	AppModule
+	name       string
+	version    uint64
+	migrations map[uint64]MigrationHandler
+	initCalled *bool
+}
+
+func (tm testModule) Name() string                    { return tm.name }
+func (tm testModule) ConsensusVersion() uint64        { return tm.version }
+func (tm testModule) DefaultGenesis() json.RawMessage { return json.RawMessage("{}") }
+
+func (tm testModule) InitGenesis(_ sdk.Context, _ json.RawMessage) []abci.ValidatorUpdate {
+	*tm.initCalled = true
+	return nil
+}
+
+// unversionedModule is a module at the initial consensus version, which
+// doesn't implement HasConsensusVersion.
+type unversionedModule struct {
+	AppModule
+	name string
+}
+
+func (um unversionedModule) Name() string { return um.name }
+
+func (tm testModule) RegisterMigrations(cfg Configurator) {
+	for from, handler := range tm.migrations {
+		if err := cfg.RegisterMigration(tm.name, from, handler); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func TestRunMigrations(t *testing.T) {
+	var calls []string
+	migration := func(name string) MigrationHandler {
+		return func(sdk.Context) error {
+			calls = append(calls, name)
+			return nil
+		}
+	}
+
+	initCalled := false
+	mm := NewManager(
+		testModule{name: "a", version: 3, initCalled: new(bool), migrations: map[uint64]MigrationHandler{
+			1: migration("a1"), 2: migration("a2"),
+		}},
+		testModule{name: "b", version: 2, initCalled: new(bool), migrations: map[uint64]MigrationHandler{
+			1: migration("b1"),
+		}},
+		testModule{name: "c", version: 1, initCalled: &initCalled},
+	)
+	mm.SetOrderInitGenesis("b", "a")
+
+	cfg := NewConfigurator()
+	mm.RegisterMigrations(cfg)
+	require.Error(t, cfg.RegisterMigration("a", 1, migration("dup")))
+	require.Error(t, cfg.RegisterMigration("a", 0, migration("zero")))
+
+	ctx := sdk.Context{}.WithLogger(log.NewNopLogger())
+	vm, err := mm.RunMigrations(ctx, cfg, VersionMap{"a": 1, "b": 1}, []string{"c"})
+	require.NoError(t, err)
+	require.Equal(t, VersionMap{"a": 3, "b": 2, "c": 1}, vm)
+	require.Equal(t, []string{"b1", "a1", "a2"}, calls)
+	require.True(t, initCalled)
+	require.Equal(t, mm.GetVersionMap(), vm)
+
+	// running again is a no-op
+	calls = nil
+	_, err = mm.RunMigrations(ctx, cfg, vm, nil)
+	require.NoError(t, err)
+	require.Empty(t, calls)
+
+	// downgrades, missing migrations and added modules which exist fail
+	_, err = mm.RunMigrations(ctx, cfg, VersionMap{"a": 4, "b": 2, "c": 1}, nil)
+	require.Error(t, err)
+	_, err = mm.RunMigrations(ctx, NewConfigurator(), VersionMap{"a": 1, "b": 2, "c": 1}, nil)
+	require.Error(t, err)
+	_, err = mm.RunMigrations(ctx, cfg, VersionMap{"a": 1, "b": 1, "c": 1}, []string{"c"})
+	require.Error(t, err)
+	_, err = mm.RunMigrations(ctx, cfg, VersionMap{"a": 1, "b": 1}, []string{"d"})
+	require.Error(t, err)
+}
+
+func TestRunMigrationsWithoutVersions(t *testing.T) {
+	var calls []string
+	initCalled := false
+	mm := NewManager(
+		testModule{name: "a", version: 2, initCalled: &initCalled, migrations: map[uint64]MigrationHandler{
+			1: func(sdk.Context) error {
+				calls = append(calls, "a1")
+				return nil
+			},
+		}},
+		unversionedModule{name: "b"},
+	)
+
+	cfg := NewConfigurator()
+	mm.RegisterMigrations(cfg)
+
+	// chains started before the versions were stored have all modules at
+	// version 1, none of which is initialized again
+	ctx := sdk.Context{}.WithLogger(log.NewNopLogger())
+	vm, err := mm.RunMigrations(ctx, cfg, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, VersionMap{"a": 2, "b": 1}, vm)
+	require.Equal(t, []string{"a1"}, calls)
+	require.False(t, initCalled)
+}
+
+func TestValidateVersionMap(t *testing.T) {
+	mm := NewManager(
+		testModule{name: "a", version: 2},
+		unversionedModule{name: "b"},
+	)
+
+	require.NoError(t, mm.ValidateVersionMap(nil))
+	require.NoError(t, mm.ValidateVersionMap(VersionMap{"a": 2, "b": 1}))
+	require.Error(t, mm.ValidateVersionMap(VersionMap{"a": 1, "b": 1}))
+	require.Error(t, mm.ValidateVersionMap(VersionMap{"c": 1}))
+}
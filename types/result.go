@@ -85,6 +85,7 @@ type TxResponse struct {
 	Data      string          `json:"data,omitempty"`
 	RawLog    string          `json:"raw_log,omitempty"`
 	Logs      ABCIMessageLogs `json:"logs,omitempty"`
+	Events    StringEvents    `json:"events,omitempty"`
 	Info      string          `json:"info,omitempty"`
 	GasWanted int64           `json:"gas_wanted,omitempty"`
 	GasUsed   int64           `json:"gas_used,omitempty"`
@@ -108,6 +109,7 @@ func NewResponseResultTx(res *ctypes.ResultTx, tx Tx, timestamp string) TxRespon
 		Data:      strings.ToUpper(hex.EncodeToString(res.TxResult.Data)),
 		RawLog:    res.TxResult.Log,
 		Logs:      parsedLogs,
+		Events:    StringifyEvents(res.TxResult.Events),
 		Info:      res.TxResult.Info,
 		GasWanted: res.TxResult.GasWanted,
 		GasUsed:   res.TxResult.GasUsed,
@@ -191,11 +193,12 @@ func NewResponseFormatBroadcastTx(res *ctypes.ResultBroadcastTx) TxResponse {
 	parsedLogs, _ := ParseABCILogs(res.Log)
 
 	return TxResponse{
-		Code:   res.Code,
-		Data:   res.Data.String(),
-		RawLog: res.Log,
-		Logs:   parsedLogs,
-		TxHash: res.Hash.String(),
+		Code:      res.Code,
+		Codespace: res.Codespace,
+		Data:      res.Data.String(),
+		RawLog:    res.Log,
+		Logs:      parsedLogs,
+		TxHash:    res.Hash.String(),
 	}
 }
 
@@ -221,6 +224,9 @@ func (r TxResponse) String() string {
 	if r.Logs != nil {
 		sb.WriteString(fmt.Sprintf("  Logs: %s\n", r.Logs))
 	}
+	if len(r.Events) > 0 {
+		sb.WriteString(fmt.Sprintf("  Events: %s\n", r.Events))
+	}
 	if r.Info != "" {
 		sb.WriteString(fmt.Sprintf("  Info: %s\n", r.Info))
 	}
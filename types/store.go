@@ -24,6 +24,10 @@ type (
 	MultiStorePersistentCache = types.MultiStorePersistentCache
 	KVStore                   = types.KVStore
 	Iterator                  = types.Iterator
+	KVDiff                    = types.KVDiff
+	StoreDiff                 = types.StoreDiff
+	WriteListener             = types.WriteListener
+	StoreKVPair               = types.StoreKVPair
 )
 
 // StoreDecoderRegistry defines each of the modules store decoders. Used for ImportExport
@@ -157,8 +161,9 @@ func NewGasMeter(limit Gas) GasMeter {
 
 // nolint - reexport
 type (
-	ErrorOutOfGas    = types.ErrorOutOfGas
-	ErrorGasOverflow = types.ErrorGasOverflow
+	ErrorOutOfGas            = types.ErrorOutOfGas
+	ErrorGasOverflow         = types.ErrorGasOverflow
+	ErrorTransientStoreLimit = types.ErrorTransientStoreLimit
 )
 
 // nolint - reexport
@@ -24,6 +24,10 @@ const (
 	DefaultSigVerifyCostED25519   = types.DefaultSigVerifyCostED25519
 	DefaultSigVerifyCostSecp256k1 = types.DefaultSigVerifyCostSecp256k1
 	QueryAccount                  = types.QueryAccount
+	SignModeDefault               = types.SignModeDefault
+	SignModeEIP712                = types.SignModeEIP712
+	EIP712DomainName              = types.EIP712DomainName
+	EIP712DomainVersion           = types.EIP712DomainVersion
 )
 
 var (
@@ -59,6 +63,12 @@ var (
 	NewTxBuilder                      = types.NewTxBuilder
 	NewTxBuilderFromCLI               = types.NewTxBuilderFromCLI
 	MakeSignature                     = types.MakeSignature
+	MakeSignatureWithSignMode         = types.MakeSignatureWithSignMode
+	SignModeFromString                = types.SignModeFromString
+	StdSignTypedData                  = types.StdSignTypedData
+	StdSignTypedDataBytes             = types.StdSignTypedDataBytes
+	NewSigningSession                 = types.NewSigningSession
+	NewSessionSigner                  = types.NewSessionSigner
 	ValidateGenAccounts               = types.ValidateGenAccounts
 	GetGenesisStateFromAppState       = types.GetGenesisStateFromAppState
 
@@ -75,6 +85,7 @@ var (
 
 type (
 	SignatureVerificationGasConsumer = ante.SignatureVerificationGasConsumer
+	SignModeTx                       = ante.SignModeTx
 	AccountKeeper                    = keeper.AccountKeeper
 	BaseAccount                      = types.BaseAccount
 	NodeQuerier                      = types.NodeQuerier
@@ -87,6 +98,11 @@ type (
 	StdFee                           = types.StdFee
 	StdSignDoc                       = types.StdSignDoc
 	StdSignature                     = types.StdSignature
+	SignMode                         = types.SignMode
+	TypedData                        = types.TypedData
+	TypedDataField                   = types.TypedDataField
 	TxBuilder                        = types.TxBuilder
+	SigningSession                   = types.SigningSession
+	SessionSigner                    = types.SessionSigner
 	GenesisAccountIterator           = types.GenesisAccountIterator
 )
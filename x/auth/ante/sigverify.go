@@ -23,6 +23,7 @@ var (
 	simSecp256k1Sig    [64]byte
 
 	_ SigVerifiableTx = (*types.StdTx)(nil) // assert StdTx implements SigVerifiableTx
+	_ SignModeTx      = (*types.StdTx)(nil) // assert StdTx implements SignModeTx
 )
 
 func init() {
@@ -45,6 +46,14 @@ type SigVerifiableTx interface {
 	GetSignBytes(ctx sdk.Context, acc exported.Account) []byte
 }
 
+// SignModeTx defines a SigVerifiableTx whose signatures may be produced over
+// an alternative payload, such as the EIP-712 typed data of the sign doc.
+type SignModeTx interface {
+	SigVerifiableTx
+	GetSignModes() []types.SignMode
+	GetTypedDataSignBytes(ctx sdk.Context, acc exported.Account) ([]byte, error)
+}
+
 // SetPubKeyDecorator sets PubKeys in context for any signer which does not already have pubkey set
 // PubKeys must be set in context for all signers before any other sigverify decorators run
 // CONTRACT: Tx must implement SigVerifiableTx interface
@@ -155,6 +164,8 @@ func (sgcd SigGasConsumeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 
 // Verify all signatures for a tx and return an error if any are invalid. Note,
 // the SigVerificationDecorator decorator will not get executed on ReCheck.
+// Signatures of a SignModeTx in the EIP-712 sign mode are verified against the
+// typed data sign bytes instead of the default ones.
 //
 // CONTRACT: Pubkeys are set in context for all signers before this decorator runs
 // CONTRACT: Tx must implement SigVerifiableTx interface
@@ -192,6 +203,12 @@ func (svd SigVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simul
 		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "invalid number of signer;  expected: %d, got %d", len(signerAddrs), len(sigs))
 	}
 
+	var signModes []types.SignMode
+	modeTx, ok := tx.(SignModeTx)
+	if ok {
+		signModes = modeTx.GetSignModes()
+	}
+
 	for i, sig := range sigs {
 		signerAccs[i], err = GetSignerAcc(ctx, svd.ak, signerAddrs[i])
 		if err != nil {
@@ -199,7 +216,15 @@ func (svd SigVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simul
 		}
 
 		// retrieve signBytes of tx
-		signBytes := sigTx.GetSignBytes(ctx, signerAccs[i])
+		var signBytes []byte
+		if i < len(signModes) && signModes[i] == types.SignModeEIP712 {
+			signBytes, err = modeTx.GetTypedDataSignBytes(ctx, signerAccs[i])
+			if err != nil {
+				return ctx, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, err.Error())
+			}
+		} else {
+			signBytes = sigTx.GetSignBytes(ctx, signerAccs[i])
+		}
 
 		// retrieve pubkey
 		pubKey := signerAccs[i].GetPubKey()
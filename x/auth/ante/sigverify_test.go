@@ -158,6 +158,50 @@ func TestSigVerification(t *testing.T) {
 	}
 }
 
+func TestSigVerificationEIP712(t *testing.T) {
+	// setup
+	app, ctx := createTestApp(true)
+	ctx = ctx.WithBlockHeight(1)
+
+	priv1, _, addr1 := types.KeyTestPubAddr()
+	priv2, _, addr2 := types.KeyTestPubAddr()
+
+	addrs := []sdk.AccAddress{addr1, addr2}
+	msgs := make([]sdk.Msg, len(addrs))
+	for i, addr := range addrs {
+		acc := app.AccountKeeper.NewAccountWithAddress(ctx, addr)
+		require.NoError(t, acc.SetAccountNumber(uint64(i)))
+		app.AccountKeeper.SetAccount(ctx, acc)
+		msgs[i] = types.NewTestMsg(addr)
+	}
+
+	fee := types.NewTestStdFee()
+	privs, accNums, seqs := []crypto.PrivKey{priv1, priv2}, []uint64{0, 1}, []uint64{0, 0}
+
+	antehandler := sdk.ChainAnteDecorators(ante.NewSetPubKeyDecorator(app.AccountKeeper), ante.NewSigVerificationDecorator(app.AccountKeeper))
+
+	// signatures over the typed data verify
+	tx := types.NewTestTxWithSignMode(ctx, msgs, privs, accNums, seqs, fee, types.SignModeEIP712)
+	_, err := antehandler(ctx, tx, false)
+	require.NoError(t, err)
+
+	// sign modes can be mixed between signers
+	stdTx := tx.(types.StdTx)
+	defaultTx := types.NewTestTx(ctx, msgs, privs, accNums, seqs, fee).(types.StdTx)
+	stdTx.Signatures[1] = defaultTx.Signatures[1]
+	_, err = antehandler(ctx, stdTx, false)
+	require.NoError(t, err)
+
+	// a signature is only valid in the sign mode it was made in
+	stdTx.Signatures[0].SignMode = types.SignModeDefault
+	_, err = antehandler(ctx, stdTx, false)
+	require.Error(t, err)
+
+	defaultTx.Signatures[0].SignMode = types.SignModeEIP712
+	_, err = antehandler(ctx, defaultTx, false)
+	require.Error(t, err)
+}
+
 func TestSigIntegration(t *testing.T) {
 	// generate private keys
 	privs := []crypto.PrivKey{secp256k1.GenPrivKey(), secp256k1.GenPrivKey(), secp256k1.GenPrivKey()}
@@ -21,16 +21,25 @@ flag and signed with the sign command. Read a transaction from [file_path] and
 broadcast it to a node. If you supply a dash (-) argument in place of an input
 filename, the command reads from standard input.
 
+[file_path] may also be a signing session created with the create-session command,
+in which case it is only broadcasted if every signer has signed.
+
 $ <appcli> tx broadcast ./mytxn.json
 `),
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
-			stdTx, err := utils.ReadStdTxFromFile(cliCtx.Codec, args[0])
+			stdTx, session, err := utils.ReadStdTxOrSigningSessionFromFile(cliCtx.Codec, args[0])
 			if err != nil {
 				return
 			}
 
+			if session != nil {
+				if stdTx, err = session.SignedTx(); err != nil {
+					return
+				}
+			}
+
 			txBytes, err := cliCtx.Codec.MarshalBinaryLengthPrefixed(stdTx)
 			if err != nil {
 				return
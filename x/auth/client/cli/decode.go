@@ -3,6 +3,7 @@ package cli
 import (
 	"encoding/base64"
 	"encoding/hex"
+	"fmt"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -10,10 +11,14 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 )
 
-const flagHex = "hex"
+const (
+	flagHex     = "hex"
+	flagPreview = "preview"
+)
 
 // GetDecodeCommand returns the decode command to take Amino-serialized bytes
 // and turn it into a JSONified transaction.
@@ -26,6 +31,7 @@ func GetDecodeCommand(codec *amino.Codec) *cobra.Command {
 	}
 
 	cmd.Flags().BoolP(flagHex, "x", false, "Treat input as hexadecimal instead of base64")
+	cmd.Flags().Bool(flagPreview, false, "Print a human readable summary of the transaction instead of its JSON encoding")
 	return flags.PostCommands(cmd)[0]
 }
 
@@ -49,6 +55,12 @@ func runDecodeTxString(codec *amino.Codec) func(cmd *cobra.Command, args []strin
 			return err
 		}
 
+		if viper.GetBool(flagPreview) {
+			preview := utils.NewTxPreview(stdTx.Fee, stdTx.GetMsgs(), stdTx.GetMemo())
+			_, err = fmt.Fprintln(cliCtx.Output, preview)
+			return err
+		}
+
 		return cliCtx.PrintOutput(stdTx)
 	}
 }
@@ -0,0 +1,260 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+const flagComplete = "complete"
+
+// GetCreateSessionCommand returns the command creating a signing session.
+func GetCreateSessionCommand(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-session [file]",
+		Short: "Create a signing session for a transaction generated offline",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Create a signing session for a transaction created with the --generate-only flag.
+
+A signing session carries the unsigned transaction along with the chain ID, the
+account number and sequence of each of its signers, and the signatures collected
+so far. The sign, multisign, inspect and broadcast commands accept it in place of
+a transaction file, so that signers on offline machines need neither a node nor
+the --offline, --account-number and --sequence flags.
+
+The account numbers and sequences are queried from a full node. If the --offline
+flag is set, they are taken from the --account-number and --sequence flags
+instead, which is only possible for transactions with a single signer.
+
+Example:
+$ %s tx create-session unsigned.json --output-document session.json
+$ %s tx sign session.json --from alice --output-document session.json
+$ %s tx broadcast session.json
+`,
+				version.ClientName, version.ClientName, version.ClientName,
+			),
+		),
+		RunE: makeCreateSessionCmd(cdc),
+		Args: cobra.ExactArgs(1),
+	}
+
+	cmd.Flags().Bool(flagOffline, false, "Offline mode; Do not query a full node. --account-number and --sequence are required")
+	cmd.Flags().String(flagOutfile, "", "The document will be written to the given file instead of STDOUT")
+
+	return flags.PostCommands(cmd)[0]
+}
+
+func makeCreateSessionCmd(cdc *codec.Codec) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		stdTx, err := utils.ReadStdTxFromFile(cdc, args[0])
+		if err != nil {
+			return err
+		}
+
+		inBuf := bufio.NewReader(cmd.InOrStdin())
+		cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+		chainID := viper.GetString(flags.FlagChainID)
+
+		var session types.SigningSession
+		if viper.GetBool(flagOffline) {
+			signers := stdTx.GetSigners()
+			if len(signers) != 1 {
+				return fmt.Errorf("offline mode requires a transaction with a single signer, got %d", len(signers))
+			}
+
+			session, err = types.NewSigningSession(chainID, stdTx, []types.SessionSigner{
+				types.NewSessionSigner(signers[0], viper.GetUint64(flags.FlagAccountNumber), viper.GetUint64(flags.FlagSequence)),
+			})
+		} else {
+			session, err = utils.NewSigningSessionFromState(cliCtx, chainID, stdTx)
+		}
+		if err != nil {
+			return err
+		}
+
+		return writeJSONOutput(cdc, session, cliCtx.Indent)
+	}
+}
+
+// GetInspectCommand returns the command inspecting a signing session.
+func GetInspectCommand(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect [file]",
+		Short: "Print the signers and signatures of a signing session or transaction",
+		Long: `Print the chain ID, fee and messages of a signing session, and its signers
+with their account number, sequence and signature status. Every signature collected
+so far is verified, and the command fails if any is invalid. Multisig signers list
+the partial signatures collected from their members until they are combined with
+the multisign command.
+
+If the --complete flag is set, the command also fails if any signature is missing.
+
+A transaction file is inspected like with 'sign --validate-signatures --offline'.
+`,
+		RunE: makeInspectCmd(cdc),
+		Args: cobra.ExactArgs(1),
+	}
+
+	cmd.Flags().Bool(flagComplete, false, "Fail if any signature is missing")
+
+	return flags.GetCommands(cmd)[0]
+}
+
+func makeInspectCmd(cdc *codec.Codec) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		stdTx, session, err := utils.ReadStdTxOrSigningSessionFromFile(cdc, args[0])
+		if err != nil {
+			return err
+		}
+
+		if session == nil {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			if !printAndValidateSigs(cliCtx, "", stdTx, true) {
+				return fmt.Errorf("signatures validation failed")
+			}
+			return nil
+		}
+
+		printSigningSession(cmd.OutOrStdout(), *session)
+
+		if missing := session.MissingSigners(); len(missing) > 0 && viper.GetBool(flagComplete) {
+			return fmt.Errorf("%d of %d signatures are missing", len(missing), len(session.Signers))
+		}
+
+		return nil
+	}
+}
+
+func printSigningSession(w io.Writer, session types.SigningSession) {
+	fmt.Fprintf(w, "Chain ID: %s\n", session.ChainID)
+	fmt.Fprintf(w, "Fee: %s (gas %d)\n", session.Tx.Fee.Amount, session.Tx.Fee.Gas)
+	if memo := session.Tx.GetMemo(); memo != "" {
+		fmt.Fprintf(w, "Memo: %s\n", memo)
+	}
+
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Messages:")
+	for i, msg := range session.Tx.GetMsgs() {
+		fmt.Fprintf(w, "  %d: %s/%s\n", i, msg.Route(), msg.Type())
+	}
+
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Signers:")
+	for i, signer := range session.Signers {
+		status := "missing"
+		switch {
+		case signer.Signature != nil:
+			status = "signed"
+		case len(signer.PartialSignatures) > 0:
+			status = fmt.Sprintf("%d partial signatures", len(signer.PartialSignatures))
+		}
+
+		fmt.Fprintf(w, "  %d: %s (account %d, sequence %d)\t\t\t[%s]\n",
+			i, signer.Address, signer.AccountNumber, signer.Sequence, status)
+		for _, sig := range signer.PartialSignatures {
+			fmt.Fprintf(w, "    %s\n", sdk.AccAddress(sig.PubKey.Address()))
+		}
+	}
+
+	fmt.Fprintln(w, "")
+	if missing := session.MissingSigners(); len(missing) > 0 {
+		fmt.Fprintf(w, "Status: incomplete, %d of %d signatures missing\n", len(missing), len(session.Signers))
+	} else {
+		fmt.Fprintln(w, "Status: complete")
+	}
+}
+
+// signSigningSession adds the signature of the --from key to a signing
+// session, as a member of the --multisig signer if set, and prints the session.
+func signSigningSession(
+	cdc *codec.Codec, cliCtx context.CLIContext, txBldr types.TxBuilder, session types.SigningSession,
+) error {
+
+	var signerAddr sdk.AccAddress
+	if multisigAddrStr := viper.GetString(flagMultisig); multisigAddrStr != "" {
+		var err error
+		signerAddr, err = sdk.AccAddressFromBech32(multisigAddrStr)
+		if err != nil {
+			return err
+		}
+	}
+
+	if viper.GetBool(flagTypedData) {
+		addr := signerAddr
+		if addr.Empty() {
+			addr = cliCtx.GetFromAddress()
+		}
+
+		i, err := session.Signer(addr)
+		if err != nil {
+			return err
+		}
+
+		typedData, err := session.SignMsg(session.Signers[i]).TypedData()
+		if err != nil {
+			return err
+		}
+
+		bz, err := json.MarshalIndent(typedData, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s\n", bz)
+		return nil
+	}
+
+	if viper.GetBool(flagValidateSigs) {
+		printSigningSession(cliCtx.Output, session)
+		if len(session.MissingSigners()) > 0 {
+			return fmt.Errorf("signatures validation failed")
+		}
+
+		return nil
+	}
+
+	session, err := utils.SignSigningSession(txBldr, cliCtx.GetFromName(), session, signerAddr)
+	if err != nil {
+		return err
+	}
+
+	return writeJSONOutput(cdc, session, cliCtx.Indent)
+}
+
+// writeJSONOutput prints the JSON encoding of a document, or writes it to the
+// file given by --output-document.
+func writeJSONOutput(cdc *codec.Codec, v interface{}, indent bool) error {
+	var (
+		bz  []byte
+		err error
+	)
+	if indent {
+		bz, err = cdc.MarshalJSONIndent(v, "", "  ")
+	} else {
+		bz, err = cdc.MarshalJSON(v)
+	}
+	if err != nil {
+		return err
+	}
+
+	if viper.GetString(flagOutfile) == "" {
+		fmt.Printf("%s\n", bz)
+		return nil
+	}
+
+	return ioutil.WriteFile(viper.GetString(flagOutfile), append(bz, '\n'), 0644)
+}
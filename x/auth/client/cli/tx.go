@@ -20,6 +20,8 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 	txCmd.AddCommand(
 		GetMultiSignCommand(cdc),
 		GetSignCommand(cdc),
+		GetCreateSessionCommand(cdc),
+		GetInspectCommand(cdc),
 	)
 	return txCmd
 }
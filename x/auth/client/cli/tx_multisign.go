@@ -42,12 +42,17 @@ of the generated signature only.
 The --offline flag makes sure that the client will not reach out to an external node.
 Thus account number or sequence number lookups will not be performed and it is
 recommended to set such parameters manually.
+
+If [file] is a signing session, the partial signatures collected in the session
+for the multisig key [name], plus those read from the [signature] files if any,
+are combined and the updated session is printed. The account number and sequence
+of the session are used, so no node is queried.
 `,
 				version.ClientName,
 			),
 		),
 		RunE: makeMultiSignCmd(cdc),
-		Args: cobra.MinimumNArgs(3),
+		Args: cobra.MinimumNArgs(2),
 	}
 
 	cmd.Flags().Bool(flagSigOnly, false, "Print only the generated signature, then exit")
@@ -60,7 +65,7 @@ recommended to set such parameters manually.
 
 func makeMultiSignCmd(cdc *codec.Codec) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) (err error) {
-		stdTx, err := utils.ReadStdTxFromFile(cdc, args[0])
+		stdTx, session, err := utils.ReadStdTxOrSigningSessionFromFile(cdc, args[0])
 		if err != nil {
 			return
 		}
@@ -85,6 +90,13 @@ func makeMultiSignCmd(cdc *codec.Codec) func(cmd *cobra.Command, args []string)
 		cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
 		txBldr := types.NewTxBuilderFromCLI(inBuf)
 
+		if session != nil {
+			return multiSignSigningSession(cdc, cliCtx, *session, multisigInfo.GetAddress(), multisigPub, args[2:])
+		}
+		if len(args) < 3 {
+			return fmt.Errorf("at least one signature file is required")
+		}
+
 		if !viper.GetBool(flagOffline) {
 			accnum, seq, err := types.NewAccountRetriever(cliCtx).GetAccountNumberSequence(multisigInfo.GetAddress())
 			if err != nil {
@@ -162,3 +174,36 @@ func readAndUnmarshalStdSignature(cdc *codec.Codec, filename string) (stdSig typ
 	}
 	return
 }
+
+// multiSignSigningSession combines the partial signatures of a multisig signer
+// of a session, along with those read from the given files, and prints the
+// session.
+func multiSignSigningSession(
+	cdc *codec.Codec, cliCtx context.CLIContext, session types.SigningSession,
+	addr sdk.AccAddress, multisigPub multisig.PubKeyMultisigThreshold, sigFiles []string,
+) error {
+
+	for _, file := range sigFiles {
+		stdSig, err := readAndUnmarshalStdSignature(cdc, file)
+		if err != nil {
+			return err
+		}
+		if err := session.AddSignature(addr, stdSig); err != nil {
+			return err
+		}
+	}
+
+	if err := session.CombineMultisig(addr, multisigPub); err != nil {
+		return err
+	}
+
+	if viper.GetBool(flagSigOnly) {
+		i, err := session.Signer(addr)
+		if err != nil {
+			return err
+		}
+		return writeJSONOutput(cdc, *session.Signers[i].Signature, cliCtx.Indent)
+	}
+
+	return writeJSONOutput(cdc, session, cliCtx.Indent)
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -25,6 +26,8 @@ const (
 	flagOffline      = "offline"
 	flagSigOnly      = "signature-only"
 	flagOutfile      = "output-document"
+	flagSignMode     = "sign-mode"
+	flagTypedData    = "typed-data"
 )
 
 // GetSignCommand returns the transaction sign command.
@@ -52,6 +55,16 @@ the transaction to fail.
 The --multisig=<multisig_key> flag generates a signature on behalf of a multisig account
 key. It implies --signature-only. Full multisig signed transactions may eventually
 be generated via the 'multisign' command.
+
+The --sign-mode=eip712 flag signs the EIP-712 typed data rendering of the transaction
+instead of its amino JSON sign bytes, as Ethereum wallets do. The --typed-data flag
+prints that typed data for the --from signer, in the eth_signTypedData_v4 format,
+so that it can be displayed and signed by an external or hardware wallet.
+
+If [file] is a signing session created with the create-session command, its chain ID,
+account numbers and sequences are used, so neither a node nor the --offline flag is
+needed. The signature is added to the session, as a partial signature if --multisig
+is set, and the updated session is printed.
 `,
 		PreRun: preSignCmd,
 		RunE:   makeSignCmd(codec),
@@ -76,6 +89,8 @@ be generated via the 'multisign' command.
 		"Offline mode; Do not query a full node. --account and --sequence options would be required if offline is set",
 	)
 	cmd.Flags().String(flagOutfile, "", "The document will be written to the given file instead of STDOUT")
+	cmd.Flags().String(flagSignMode, types.SignModeDefault.String(), "Sign over the amino JSON sign bytes or the EIP-712 typed data of the transaction (default|eip712)")
+	cmd.Flags().Bool(flagTypedData, false, "Print the EIP-712 typed data to sign for the signer given by --from, then exit")
 
 	cmd = flags.PostCommands(cmd)[0]
 	cmd.MarkFlagRequired(flags.FlagFrom)
@@ -94,7 +109,7 @@ func preSignCmd(cmd *cobra.Command, _ []string) {
 
 func makeSignCmd(cdc *codec.Codec) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
-		stdTx, err := utils.ReadStdTxFromFile(cdc, args[0])
+		stdTx, session, err := utils.ReadStdTxOrSigningSessionFromFile(cdc, args[0])
 		if err != nil {
 			return err
 		}
@@ -104,6 +119,31 @@ func makeSignCmd(cdc *codec.Codec) func(cmd *cobra.Command, args []string) error
 		cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
 		txBldr := types.NewTxBuilderFromCLI(inBuf)
 
+		signMode, err := types.SignModeFromString(viper.GetString(flagSignMode))
+		if err != nil {
+			return err
+		}
+		txBldr = txBldr.WithSignMode(signMode)
+
+		if session != nil {
+			return signSigningSession(cdc, cliCtx, txBldr, *session)
+		}
+
+		if viper.GetBool(flagTypedData) {
+			typedData, err := utils.GetStdTxTypedData(txBldr, cliCtx, cliCtx.GetFromAddress(), stdTx, offline)
+			if err != nil {
+				return err
+			}
+
+			bz, err := json.MarshalIndent(typedData, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("%s\n", bz)
+			return nil
+		}
+
 		if viper.GetBool(flagValidateSigs) {
 			if !printAndValidateSigs(cliCtx, txBldr.ChainID(), stdTx, offline) {
 				return fmt.Errorf("signatures validation failed")
@@ -230,12 +270,16 @@ func printAndValidateSigs(
 				return false
 			}
 
-			sigBytes := types.StdSignBytes(
-				chainID, acc.GetAccountNumber(), acc.GetSequence(),
-				stdTx.Fee, stdTx.GetMsgs(), stdTx.GetMemo(),
-			)
+			sigBytes, err := types.StdSignMsg{
+				ChainID:       chainID,
+				AccountNumber: acc.GetAccountNumber(),
+				Sequence:      acc.GetSequence(),
+				Fee:           stdTx.Fee,
+				Msgs:          stdTx.GetMsgs(),
+				Memo:          stdTx.GetMemo(),
+			}.BytesForSignMode(sig.SignMode)
 
-			if ok := sig.VerifyBytes(sigBytes, sig.Signature); !ok {
+			if err != nil || !sig.VerifyBytes(sigBytes, sig.Signature) {
 				sigSanity = "ERROR: signature invalid"
 				success = false
 			}
@@ -0,0 +1,206 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+// MsgPreview is the human readable rendering of a msg. Recipients are the
+// addresses receiving funds, which are checked against the blacklist of the
+// TxPreviewConfig.
+type MsgPreview struct {
+	Summary    string
+	Recipients []sdk.AccAddress
+}
+
+// MsgRenderer renders a msg of the type it is registered for, e.g.
+// "Send 10.5okt from X to Y".
+type MsgRenderer func(msg sdk.Msg) (MsgPreview, error)
+
+// TxPreviewConfig sets the thresholds above which tx previews warn about
+// unusual values. Fees of the denominations missing from MaxFees are not
+// checked, nor is gas if MaxGas is zero.
+type TxPreviewConfig struct {
+	MaxFees   sdk.Coins
+	MaxGas    uint64
+	Blacklist []sdk.AccAddress
+}
+
+// DefaultTxPreviewConfig returns the default TxPreviewConfig, which warns about
+// fees above 1 unit of the bond denomination and gas limits ten times higher
+// than the default one.
+func DefaultTxPreviewConfig() TxPreviewConfig {
+	return TxPreviewConfig{
+		MaxFees: sdk.NewCoins(sdk.NewDecCoinFromDec(sdk.DefaultBondDenom, sdk.OneDec())),
+		MaxGas:  10 * flags.DefaultGasLimit,
+	}
+}
+
+var (
+	previewMtx    sync.RWMutex
+	msgRenderers  = make(map[string]MsgRenderer)
+	previewConfig = DefaultTxPreviewConfig()
+)
+
+// RegisterMsgRenderer registers the renderer of the msgs of the given route and
+// type. Modules register the renderers of their msgs from their client package.
+// It panics if a renderer is already registered for the msg type.
+func RegisterMsgRenderer(route, msgType string, renderer MsgRenderer) {
+	previewMtx.Lock()
+	defer previewMtx.Unlock()
+
+	key := route + "/" + msgType
+	if _, ok := msgRenderers[key]; ok {
+		panic(fmt.Sprintf("msg renderer already registered for %s", key))
+	}
+	msgRenderers[key] = renderer
+}
+
+// SetTxPreviewConfig sets the thresholds of the warnings of tx previews, e.g.
+// to blacklist the module accounts of an application.
+func SetTxPreviewConfig(config TxPreviewConfig) {
+	previewMtx.Lock()
+	defer previewMtx.Unlock()
+
+	previewConfig = config
+}
+
+// RenderMsg returns the preview of a msg given by its registered renderer, or
+// its sign bytes if there is none.
+func RenderMsg(msg sdk.Msg) MsgPreview {
+	previewMtx.RLock()
+	renderer, ok := msgRenderers[msg.Route()+"/"+msg.Type()]
+	previewMtx.RUnlock()
+
+	if ok {
+		if preview, err := renderer(msg); err == nil {
+			return preview
+		}
+	}
+
+	return MsgPreview{Summary: fmt.Sprintf("%s/%s %s", msg.Route(), msg.Type(), msg.GetSignBytes())}
+}
+
+// TxPreview is the human readable rendering of a tx which is shown to users
+// before they sign it.
+type TxPreview struct {
+	Msgs     []string
+	Fees     string
+	Gas      uint64
+	Memo     string
+	Warnings []string
+
+	// the signing details, shown if the chain id is set
+	ChainID       string
+	AccountNumber uint64
+	Sequence      uint64
+}
+
+// NewTxPreview renders the msgs, fee and memo of a tx, and warns about high
+// fees or gas and blacklisted recipients.
+func NewTxPreview(fee authtypes.StdFee, msgs []sdk.Msg, memo string) TxPreview {
+	previewMtx.RLock()
+	config := previewConfig
+	previewMtx.RUnlock()
+
+	preview := TxPreview{Fees: FormatCoins(fee.Amount), Gas: fee.Gas, Memo: memo}
+
+	for _, msg := range msgs {
+		msgPreview := RenderMsg(msg)
+		preview.Msgs = append(preview.Msgs, msgPreview.Summary)
+
+		for _, recipient := range msgPreview.Recipients {
+			for _, addr := range config.Blacklist {
+				if recipient.Equals(addr) {
+					preview.Warnings = append(preview.Warnings,
+						fmt.Sprintf("recipient %s is blacklisted, funds sent to it may be lost", recipient))
+				}
+			}
+		}
+	}
+
+	for _, coin := range fee.Amount {
+		max := config.MaxFees.AmountOf(coin.Denom)
+		if max.IsPositive() && coin.Amount.GT(max) {
+			preview.Warnings = append(preview.Warnings,
+				fmt.Sprintf("fees of %s are higher than %s", FormatCoin(coin), FormatCoin(sdk.NewDecCoinFromDec(coin.Denom, max))))
+		}
+	}
+
+	if config.MaxGas > 0 && fee.Gas > config.MaxGas {
+		preview.Warnings = append(preview.Warnings,
+			fmt.Sprintf("gas limit of %d is higher than %d", fee.Gas, config.MaxGas))
+	}
+
+	return preview
+}
+
+// NewSignMsgPreview renders a tx to sign like NewTxPreview, along with the
+// chain id, account number and sequence it is signed for.
+func NewSignMsgPreview(msg authtypes.StdSignMsg) TxPreview {
+	preview := NewTxPreview(msg.Fee, msg.Msgs, msg.Memo)
+	preview.ChainID = msg.ChainID
+	preview.AccountNumber = msg.AccountNumber
+	preview.Sequence = msg.Sequence
+
+	return preview
+}
+
+// String implements fmt.Stringer.
+func (p TxPreview) String() string {
+	var sb strings.Builder
+
+	if len(p.Msgs) == 1 {
+		sb.WriteString(p.Msgs[0] + "\n")
+	} else {
+		for i, msg := range p.Msgs {
+			sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, msg))
+		}
+	}
+
+	fees := p.Fees
+	if fees == "" {
+		fees = "none"
+	}
+	sb.WriteString(fmt.Sprintf("Fees: %s (gas limit %d)\n", fees, p.Gas))
+
+	if p.Memo != "" {
+		sb.WriteString(fmt.Sprintf("Memo: %s\n", p.Memo))
+	}
+
+	if p.ChainID != "" {
+		sb.WriteString(fmt.Sprintf("Chain ID: %s, account number: %d, sequence: %d\n", p.ChainID, p.AccountNumber, p.Sequence))
+	}
+
+	for _, warning := range p.Warnings {
+		sb.WriteString(fmt.Sprintf("WARNING: %s\n", warning))
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+// FormatCoin formats a coin without the trailing zeros of its amount, e.g.
+// 10.5okt instead of 10.500000000000000000okt.
+func FormatCoin(coin sdk.Coin) string {
+	amount := coin.Amount.String()
+	if strings.Contains(amount, ".") {
+		amount = strings.TrimRight(strings.TrimRight(amount, "0"), ".")
+	}
+
+	return amount + coin.Denom
+}
+
+// FormatCoins formats coins like FormatCoin, separated by commas.
+func FormatCoins(coins sdk.Coins) string {
+	formatted := make([]string, len(coins))
+	for i, coin := range coins {
+		formatted[i] = FormatCoin(coin)
+	}
+
+	return strings.Join(formatted, ",")
+}
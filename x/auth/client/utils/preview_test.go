@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+func TestTxPreview(t *testing.T) {
+	from := sdk.AccAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	to := sdk.AccAddress(bytes.Repeat([]byte{2}, sdk.AddrLen))
+
+	RegisterMsgRenderer("TestMsg", "Test message", func(msg sdk.Msg) (MsgPreview, error) {
+		return MsgPreview{Summary: "Send 10.5okt from X to Y", Recipients: []sdk.AccAddress{to}}, nil
+	})
+	require.Panics(t, func() {
+		RegisterMsgRenderer("TestMsg", "Test message", nil)
+	})
+
+	defer SetTxPreviewConfig(DefaultTxPreviewConfig())
+	SetTxPreviewConfig(TxPreviewConfig{
+		MaxFees:   sdk.NewCoins(sdk.NewDecCoinFromDec("okt", sdk.OneDec())),
+		MaxGas:    1000000,
+		Blacklist: []sdk.AccAddress{to},
+	})
+
+	fees, err := sdk.ParseCoins("0.0005okt")
+	require.NoError(t, err)
+	msgs := []sdk.Msg{sdk.NewTestMsg(from)}
+
+	preview := NewTxPreview(authtypes.NewStdFee(200000, fees), msgs, "memo")
+	require.Equal(t, []string{"Send 10.5okt from X to Y"}, preview.Msgs)
+	require.Equal(t, "0.0005okt", preview.Fees)
+	require.Len(t, preview.Warnings, 1)
+	require.Equal(t, `Send 10.5okt from X to Y
+Fees: 0.0005okt (gas limit 200000)
+Memo: memo
+WARNING: recipient `+to.String()+` is blacklisted, funds sent to it may be lost`, preview.String())
+
+	signMsg := authtypes.StdSignMsg{
+		ChainID: "okexchain", AccountNumber: 3, Sequence: 7, Fee: authtypes.NewStdFee(200000, fees), Msgs: msgs,
+	}
+	require.Equal(t, `Send 10.5okt from X to Y
+Fees: 0.0005okt (gas limit 200000)
+Chain ID: okexchain, account number: 3, sequence: 7
+WARNING: recipient `+to.String()+` is blacklisted, funds sent to it may be lost`, NewSignMsgPreview(signMsg).String())
+
+	fees, err = sdk.ParseCoins("2okt,3usdk")
+	require.NoError(t, err)
+	preview = NewTxPreview(authtypes.NewStdFee(2000000, fees), append(msgs, msgs...), "")
+	require.Len(t, preview.Msgs, 2)
+	require.Len(t, preview.Warnings, 4)
+	require.Contains(t, preview.Warnings, "fees of 2okt are higher than 1okt")
+	require.Contains(t, preview.Warnings, "gas limit of 2000000 is higher than 1000000")
+}
+
+func TestFormatCoins(t *testing.T) {
+	coins, err := sdk.ParseCoins("10.5okt,3usdk,0.000001xyz")
+	require.NoError(t, err)
+	require.Equal(t, "10.5okt,3usdk,0.000001xyz", FormatCoins(coins))
+	require.Equal(t, "", FormatCoins(nil))
+}
@@ -0,0 +1,183 @@
+package utils
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+// SequenceManager caches the account number and the next sequence of accounts
+// so that many txs can be signed per block without querying the node for each
+// of them. Sequences are handed out atomically, so a SequenceManager can be
+// shared by concurrent senders.
+//
+// The sequences handed out for an account which have been neither committed
+// nor released are pending. With a non-zero window, at most window sequences
+// of an account may be pending at a time; once the window is full, the node is
+// queried for the txs committed meanwhile.
+type SequenceManager struct {
+	mtx      sync.Mutex
+	accounts map[string]*accountSequence
+	window   uint64
+	fetch    func(addr sdk.AccAddress) (accNum, seq uint64, err error)
+}
+
+type accountSequence struct {
+	accNum    uint64
+	committed uint64 // lowest sequence not known to be committed
+	next      uint64 // next sequence to hand out
+}
+
+// NewSequenceManager returns a SequenceManager querying account numbers and
+// sequences through the given context, with a pending tx window of the given
+// size, or no window if zero.
+func NewSequenceManager(cliCtx context.CLIContext, window uint64) *SequenceManager {
+	return NewSequenceManagerWithFetcher(authtypes.NewAccountRetriever(cliCtx).GetAccountNumberSequence, window)
+}
+
+// NewSequenceManagerWithFetcher returns a SequenceManager getting account
+// numbers and sequences from the given function.
+func NewSequenceManagerWithFetcher(
+	fetch func(addr sdk.AccAddress) (accNum, seq uint64, err error), window uint64,
+) *SequenceManager {
+
+	return &SequenceManager{
+		accounts: make(map[string]*accountSequence),
+		window:   window,
+		fetch:    fetch,
+	}
+}
+
+// Next returns the account number of an account and reserves its next
+// sequence. It returns an error if the pending tx window of the account is
+// full.
+func (m *SequenceManager) Next(addr sdk.AccAddress) (accNum, seq uint64, err error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	acc, err := m.account(addr)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if m.window > 0 && acc.next-acc.committed >= m.window {
+		// the node may have committed pending txs meanwhile
+		if _, committed, err := m.fetch(addr); err == nil && committed > acc.committed {
+			acc.committed = committed
+			if acc.next < committed {
+				acc.next = committed
+			}
+		}
+	}
+	if m.window > 0 && acc.next-acc.committed >= m.window {
+		return 0, 0, fmt.Errorf("%d txs of %s are pending, wait for them to be committed", acc.next-acc.committed, addr)
+	}
+
+	seq = acc.next
+	acc.next++
+	return acc.accNum, seq, nil
+}
+
+// Release gives back a sequence which has not been used, e.g. because signing
+// or CheckTx failed. If later sequences have been handed out meanwhile, the
+// account is resynced with the node on its next use.
+func (m *SequenceManager) Release(addr sdk.AccAddress, seq uint64) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	acc, ok := m.accounts[addr.String()]
+	if !ok || seq < acc.committed || seq >= acc.next {
+		return
+	}
+
+	if seq == acc.next-1 {
+		acc.next--
+		return
+	}
+
+	delete(m.accounts, addr.String())
+}
+
+// Commit records that the tx of the given sequence has been committed, which
+// frees its slot and the slots of earlier sequences in the pending window.
+func (m *SequenceManager) Commit(addr sdk.AccAddress, seq uint64) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	acc, ok := m.accounts[addr.String()]
+	if !ok || seq < acc.committed {
+		return
+	}
+
+	acc.committed = seq + 1
+	if acc.next < acc.committed {
+		acc.next = acc.committed
+	}
+}
+
+// Resync drops the cached sequence of an account and queries it again.
+func (m *SequenceManager) Resync(addr sdk.AccAddress) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.accounts, addr.String())
+	_, err := m.account(addr)
+	return err
+}
+
+// HandleBroadcast updates the sequences of an account given the outcome of
+// broadcasting its tx of the given sequence: the account is resynced on
+// sequence errors, and the sequence is released if the tx was rejected before
+// entering the mempool. A tx committed in block mode is recorded as committed.
+//
+// A tx signed with a stale sequence fails the signature verification of
+// CheckTx with ErrUnauthorized, so such rejections resync the account too:
+// releasing the sequence would keep handing out the stale one.
+func (m *SequenceManager) HandleBroadcast(addr sdk.AccAddress, seq uint64, res sdk.TxResponse, err error) {
+	switch {
+	case context.IsSequenceError(res, err):
+		m.mtx.Lock()
+		delete(m.accounts, addr.String())
+		m.mtx.Unlock()
+
+	case isUnauthorizedRejection(res, err):
+		// a failed query leaves the account uncached, to be queried on next use
+		_ = m.Resync(addr)
+
+	case err != nil || (res.Code != 0 && res.Height == 0):
+		m.Release(addr, seq)
+
+	case res.Height > 0:
+		m.Commit(addr, seq)
+	}
+}
+
+// isUnauthorizedRejection returns true if a tx was rejected by CheckTx with
+// ErrUnauthorized, e.g. because it was signed with a stale sequence.
+func isUnauthorizedRejection(res sdk.TxResponse, err error) bool {
+	if err != nil {
+		return sdkerrors.ErrUnauthorized.Is(err)
+	}
+
+	return res.Height == 0 && res.Codespace == sdkerrors.RootCodespace && res.Code == sdkerrors.ErrUnauthorized.ABCICode()
+}
+
+// account returns the cached sequence of an account, querying it if needed.
+func (m *SequenceManager) account(addr sdk.AccAddress) (*accountSequence, error) {
+	if acc, ok := m.accounts[addr.String()]; ok {
+		return acc, nil
+	}
+
+	accNum, seq, err := m.fetch(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	acc := &accountSequence{accNum: accNum, committed: seq, next: seq}
+	m.accounts[addr.String()] = acc
+	return acc, nil
+}
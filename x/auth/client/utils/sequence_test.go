@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+func TestSequenceManager(t *testing.T) {
+	var fetches int
+	chainSeq := uint64(5)
+	m := NewSequenceManagerWithFetcher(func(sdk.AccAddress) (uint64, uint64, error) {
+		fetches++
+		return 7, chainSeq, nil
+	}, 0)
+
+	// concurrent senders get distinct consecutive sequences
+	var wg sync.WaitGroup
+	var mtx sync.Mutex
+	seen := make(map[uint64]bool)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			accNum, seq, err := m.Next(addr)
+			require.NoError(t, err)
+			require.Equal(t, uint64(7), accNum)
+			mtx.Lock()
+			seen[seq] = true
+			mtx.Unlock()
+		}()
+	}
+	wg.Wait()
+	require.Len(t, seen, 20)
+	for seq := uint64(5); seq < 25; seq++ {
+		require.True(t, seen[seq])
+	}
+	require.Equal(t, 1, fetches)
+
+	// the last sequence can be given back
+	m.Release(addr, 24)
+	_, seq, err := m.Next(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(24), seq)
+
+	// rejected txs give back their sequence
+	m.HandleBroadcast(addr, 24, sdk.TxResponse{Code: sdkerrors.ErrInsufficientFee.ABCICode()}, nil)
+	_, seq, err = m.Next(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(24), seq)
+
+	// sequence errors resync with the node
+	chainSeq = 30
+	m.HandleBroadcast(addr, 24, sdk.TxResponse{
+		Codespace: sdkerrors.RootCodespace, Code: sdkerrors.ErrInvalidSequence.ABCICode(),
+	}, nil)
+	_, seq, err = m.Next(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), seq)
+	require.Equal(t, 2, fetches)
+
+	chainSeq = 40
+	m.HandleBroadcast(addr, 30, sdk.TxResponse{}, errors.New("incorrect account sequence"))
+	_, seq, err = m.Next(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(40), seq)
+	require.Equal(t, 3, fetches)
+}
+
+func TestSequenceManagerStaleSequence(t *testing.T) {
+	var fetches int
+	chainSeq := uint64(3)
+	m := NewSequenceManagerWithFetcher(func(sdk.AccAddress) (uint64, uint64, error) {
+		fetches++
+		return 1, chainSeq, nil
+	}, 0)
+
+	_, seq, err := m.Next(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), seq)
+	m.Commit(addr, 3)
+
+	// another client sends txs of the account, so the cached sequence is stale
+	chainSeq = 10
+	unauthorized := sdk.TxResponse{
+		Codespace: sdkerrors.RootCodespace,
+		Code:      sdkerrors.ErrUnauthorized.ABCICode(),
+		RawLog:    "signature verification failed; verify correct account sequence and chain-id",
+	}
+	_, seq, err = m.Next(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), seq)
+
+	// the rejection resyncs the account instead of giving back the stale sequence
+	m.HandleBroadcast(addr, seq, unauthorized, nil)
+	require.Equal(t, 2, fetches)
+	_, seq, err = m.Next(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), seq)
+
+	// so do ErrUnauthorized errors of the broadcast
+	chainSeq = 12
+	m.HandleBroadcast(addr, seq, sdk.TxResponse{}, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "signature verification failed"))
+	_, seq, err = m.Next(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), seq)
+	require.Equal(t, 3, fetches)
+}
+
+func TestSequenceManagerWindow(t *testing.T) {
+	chainSeq := uint64(0)
+	m := NewSequenceManagerWithFetcher(func(sdk.AccAddress) (uint64, uint64, error) {
+		return 1, chainSeq, nil
+	}, 2)
+
+	_, _, err := m.Next(addr)
+	require.NoError(t, err)
+	_, _, err = m.Next(addr)
+	require.NoError(t, err)
+	_, _, err = m.Next(addr)
+	require.Error(t, err)
+
+	// committing frees the slots of the sequences up to the committed one
+	m.Commit(addr, 0)
+	_, seq, err := m.Next(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), seq)
+	_, _, err = m.Next(addr)
+	require.Error(t, err)
+
+	// txs committed meanwhile are picked up from the node when the window is full
+	chainSeq = 3
+	_, seq, err = m.Next(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), seq)
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+// ReadStdTxOrSigningSessionFromFile reads and decodes either a StdTx or a
+// SigningSession from the given filename; the session is nil if the file holds
+// a StdTx. Can pass "-" to read from stdin.
+func ReadStdTxOrSigningSessionFromFile(cdc *codec.Codec, filename string) (
+	stdTx authtypes.StdTx, session *authtypes.SigningSession, err error,
+) {
+
+	bytes, err := readTxFile(filename)
+	if err != nil {
+		return
+	}
+
+	var s authtypes.SigningSession
+	if cdc.UnmarshalJSON(bytes, &s) == nil {
+		if err = s.ValidateBasic(); err != nil {
+			return
+		}
+		return s.Tx, &s, nil
+	}
+
+	err = cdc.UnmarshalJSON(bytes, &stdTx)
+	return
+}
+
+// NewSigningSessionFromState returns a SigningSession for a tx, querying the
+// account number and sequence of each of its signers.
+func NewSigningSessionFromState(
+	cliCtx context.CLIContext, chainID string, stdTx authtypes.StdTx,
+) (authtypes.SigningSession, error) {
+
+	retriever := authtypes.NewAccountRetriever(cliCtx)
+
+	var signers []authtypes.SessionSigner
+	for _, addr := range stdTx.GetSigners() {
+		num, seq, err := retriever.GetAccountNumberSequence(addr)
+		if err != nil {
+			return authtypes.SigningSession{}, err
+		}
+		signers = append(signers, authtypes.NewSessionSigner(addr, num, seq))
+	}
+
+	return authtypes.NewSigningSession(chainID, stdTx, signers)
+}
+
+// SignSigningSession signs the tx of a session with the key of the given name
+// and adds the signature to the session. If signerAddr is not empty, the key
+// signs as a member of that multisig signer, else as a signer itself. The
+// account number and sequence of the session are used, so this never queries
+// a node.
+func SignSigningSession(
+	txBldr authtypes.TxBuilder, name string, session authtypes.SigningSession, signerAddr sdk.AccAddress,
+) (authtypes.SigningSession, error) {
+
+	info, err := txBldr.Keybase().Get(name)
+	if err != nil {
+		return session, err
+	}
+
+	if signerAddr.Empty() {
+		signerAddr = info.GetAddress()
+	}
+
+	i, err := session.Signer(signerAddr)
+	if err != nil {
+		return session, fmt.Errorf("%s: %s", errInvalidSigner, name)
+	}
+
+	sig, err := authtypes.MakeSignatureWithSignMode(
+		txBldr.Keybase(), name, keys.DefaultKeyPass, session.SignMsg(session.Signers[i]), txBldr.SignMode(),
+	)
+	if err != nil {
+		return session, err
+	}
+
+	// don't modify the signers of the given session, nor their partial
+	// signatures, which AddSignature may replace or append to in place
+	signers := make([]authtypes.SessionSigner, len(session.Signers))
+	for j, signer := range session.Signers {
+		signer.PartialSignatures = append([]authtypes.StdSignature(nil), signer.PartialSignatures...)
+		signers[j] = signer
+	}
+	session.Signers = signers
+	if err := session.AddSignature(signerAddr, sig); err != nil {
+		return session, err
+	}
+
+	return session, nil
+}
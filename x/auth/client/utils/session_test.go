@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/multisig"
+
+	clientkeys "github.com/cosmos/cosmos-sdk/client/keys"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+func TestSignSigningSession(t *testing.T) {
+	kb := keys.NewInMemory()
+	pubKeys := make([]crypto.PubKey, 3)
+	for i, name := range []string{"a", "b", "c"} {
+		info, _, err := kb.CreateMnemonic(name, keys.English, clientkeys.DefaultKeyPass, keys.Secp256k1, "")
+		require.NoError(t, err)
+		pubKeys[i] = info.GetPubKey()
+	}
+	multisigAddr := sdk.AccAddress(multisig.NewPubKeyMultisigThreshold(2, pubKeys).Address())
+
+	tx := authtypes.NewStdTx([]sdk.Msg{sdk.NewTestMsg(multisigAddr)}, authtypes.NewTestStdFee(), nil, "")
+	session, err := authtypes.NewSigningSession("test-chain", tx, []authtypes.SessionSigner{
+		authtypes.NewSessionSigner(multisigAddr, 1, 0),
+	})
+	require.NoError(t, err)
+
+	txBldr := authtypes.TxBuilder{}.WithKeybase(kb).WithChainID("test-chain")
+	session, err = SignSigningSession(txBldr, "a", session, multisigAddr)
+	require.NoError(t, err)
+	require.Len(t, session.Signers[0].PartialSignatures, 1)
+
+	// leave room in the partial signatures, so that appending to them in place
+	// would share the new signatures across the sessions signed from session
+	partials := make([]authtypes.StdSignature, 1, 3)
+	copy(partials, session.Signers[0].PartialSignatures)
+	session.Signers[0].PartialSignatures = partials
+
+	signedB, err := SignSigningSession(txBldr, "b", session, multisigAddr)
+	require.NoError(t, err)
+	signedC, err := SignSigningSession(txBldr, "c", session, multisigAddr)
+	require.NoError(t, err)
+
+	require.Len(t, session.Signers[0].PartialSignatures, 1)
+	require.Len(t, signedB.Signers[0].PartialSignatures, 2)
+	require.Len(t, signedC.Signers[0].PartialSignatures, 2)
+	require.True(t, signedB.Signers[0].PartialSignatures[1].PubKey.Equals(pubKeys[1]))
+	require.True(t, signedC.Signers[0].PartialSignatures[1].PubKey.Equals(pubKeys[2]))
+
+	// replacing a partial signature doesn't modify the given session either
+	partials[0].Signature = nil
+	resigned, err := SignSigningSession(txBldr, "a", session, multisigAddr)
+	require.NoError(t, err)
+	require.NotNil(t, resigned.Signers[0].PartialSignatures[0].Signature)
+	require.Nil(t, session.Signers[0].PartialSignatures[0].Signature)
+}
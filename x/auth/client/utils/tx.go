@@ -46,11 +46,34 @@ func GenerateOrBroadcastMsgs(cliCtx context.CLIContext, txBldr authtypes.TxBuild
 // sequence set. In addition, it builds and signs a transaction with the
 // supplied messages. Finally, it broadcasts the signed transaction to a node.
 func CompleteAndBroadcastTxCLI(txBldr authtypes.TxBuilder, cliCtx context.CLIContext, msgs []sdk.Msg) error {
-	txBldr, err := PrepareTxBuilder(txBldr, cliCtx)
+	return CompleteAndBroadcastTxCLIWithSequences(txBldr, cliCtx, msgs, nil)
+}
+
+// CompleteAndBroadcastTxCLIWithSequences is like CompleteAndBroadcastTxCLI, but
+// takes the account number and sequence from a SequenceManager, if not nil,
+// unless the TxBuilder sets them. The SequenceManager is notified of the
+// outcome of the broadcast.
+func CompleteAndBroadcastTxCLIWithSequences(
+	txBldr authtypes.TxBuilder, cliCtx context.CLIContext, msgs []sdk.Msg, seqs *SequenceManager,
+) error {
+
+	reserved := seqs != nil && txBldr.AccountNumber() == 0 && txBldr.Sequence() == 0
+	txBldr, err := PrepareTxBuilderWithSequences(txBldr, cliCtx, seqs)
 	if err != nil {
 		return err
 	}
 
+	from, seq := cliCtx.GetFromAddress(), txBldr.Sequence()
+	broadcasted := false
+	if reserved {
+		// give back the sequence if the tx is not broadcast
+		defer func() {
+			if !broadcasted {
+				seqs.Release(from, seq)
+			}
+		}()
+	}
+
 	fromName := cliCtx.GetFromName()
 
 	if txBldr.SimulateAndExecute() || cliCtx.Simulate {
@@ -73,17 +96,7 @@ func CompleteAndBroadcastTxCLI(txBldr authtypes.TxBuilder, cliCtx context.CLICon
 			return err
 		}
 
-		var json []byte
-		if viper.GetBool(flags.FlagIndentResponse) {
-			json, err = cliCtx.Codec.MarshalJSONIndent(stdSignMsg, "", "  ")
-			if err != nil {
-				panic(err)
-			}
-		} else {
-			json = cliCtx.Codec.MustMarshalJSON(stdSignMsg)
-		}
-
-		_, _ = fmt.Fprintf(os.Stderr, "%s\n\n", json)
+		_, _ = fmt.Fprintf(os.Stderr, "%s\n\n", NewSignMsgPreview(stdSignMsg))
 
 		buf := bufio.NewReader(os.Stdin)
 		ok, err := input.GetConfirmation("confirm transaction before signing and broadcasting", buf)
@@ -101,6 +114,10 @@ func CompleteAndBroadcastTxCLI(txBldr authtypes.TxBuilder, cliCtx context.CLICon
 
 	// broadcast to a Tendermint node
 	res, err := cliCtx.BroadcastTx(txBytes)
+	if reserved {
+		broadcasted = true
+		seqs.HandleBroadcast(from, seq, res, err)
+	}
 	if err != nil {
 		return err
 	}
@@ -217,16 +234,38 @@ func SignStdTxWithSignerAddress(txBldr authtypes.TxBuilder, cliCtx context.CLICo
 	return txBldr.SignStdTx(name, keys.DefaultKeyPass, stdTx, false)
 }
 
-// Read and decode a StdTx from the given filename.  Can pass "-" to read from stdin.
-func ReadStdTxFromFile(cdc *codec.Codec, filename string) (stdTx authtypes.StdTx, err error) {
-	var bytes []byte
+// GetStdTxTypedData returns the EIP-712 typed data a signer signs in the EIP-712
+// sign mode, so that Ethereum and hardware wallets can display and sign it.
+// Don't perform online lookups of the account and sequence numbers if offline
+// is true.
+func GetStdTxTypedData(txBldr authtypes.TxBuilder, cliCtx context.CLIContext,
+	addr sdk.AccAddress, stdTx authtypes.StdTx, offline bool) (authtypes.TypedData, error) {
 
-	if filename == "-" {
-		bytes, err = ioutil.ReadAll(os.Stdin)
-	} else {
-		bytes, err = ioutil.ReadFile(filename)
+	if !isTxSigner(addr, stdTx.GetSigners()) {
+		return authtypes.TypedData{}, fmt.Errorf("%s: %s", errInvalidSigner, addr)
+	}
+
+	if !offline {
+		var err error
+		txBldr, err = populateAccountFromState(txBldr, cliCtx, addr)
+		if err != nil {
+			return authtypes.TypedData{}, err
+		}
 	}
 
+	if txBldr.ChainID() == "" {
+		return authtypes.TypedData{}, fmt.Errorf("chain ID required but not specified")
+	}
+
+	return authtypes.StdSignTypedData(
+		txBldr.ChainID(), txBldr.AccountNumber(), txBldr.Sequence(),
+		stdTx.Fee, stdTx.GetMsgs(), stdTx.GetMemo(),
+	)
+}
+
+// Read and decode a StdTx from the given filename.  Can pass "-" to read from stdin.
+func ReadStdTxFromFile(cdc *codec.Codec, filename string) (stdTx authtypes.StdTx, err error) {
+	bytes, err := readTxFile(filename)
 	if err != nil {
 		return
 	}
@@ -238,6 +277,14 @@ func ReadStdTxFromFile(cdc *codec.Codec, filename string) (stdTx authtypes.StdTx
 	return
 }
 
+func readTxFile(filename string) ([]byte, error) {
+	if filename == "-" {
+		return ioutil.ReadAll(os.Stdin)
+	}
+
+	return ioutil.ReadFile(filename)
+}
+
 func populateAccountFromState(
 	txBldr authtypes.TxBuilder, cliCtx context.CLIContext, addr sdk.AccAddress,
 ) (authtypes.TxBuilder, error) {
@@ -287,8 +334,28 @@ func parseQueryResponse(cdc *codec.Codec, rawRes []byte) (sdk.SimulationResponse
 
 // PrepareTxBuilder populates a TxBuilder in preparation for the build of a Tx.
 func PrepareTxBuilder(txBldr authtypes.TxBuilder, cliCtx context.CLIContext) (authtypes.TxBuilder, error) {
+	return PrepareTxBuilderWithSequences(txBldr, cliCtx, nil)
+}
+
+// PrepareTxBuilderWithSequences is like PrepareTxBuilder, but reserves the
+// next sequence of the account from a SequenceManager, if not nil, when the
+// TxBuilder sets neither the account number nor the sequence. The reserved
+// sequence must be released or reported with HandleBroadcast.
+func PrepareTxBuilderWithSequences(
+	txBldr authtypes.TxBuilder, cliCtx context.CLIContext, seqs *SequenceManager,
+) (authtypes.TxBuilder, error) {
+
 	from := cliCtx.GetFromAddress()
 
+	if seqs != nil && txBldr.AccountNumber() == 0 && txBldr.Sequence() == 0 {
+		num, seq, err := seqs.Next(from)
+		if err != nil {
+			return txBldr, err
+		}
+
+		return txBldr.WithAccountNumber(num).WithSequence(seq), nil
+	}
+
 	accGetter := authtypes.NewAccountRetriever(cliCtx)
 	if err := accGetter.EnsureExists(from); err != nil {
 		return txBldr, err
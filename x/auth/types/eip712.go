@@ -0,0 +1,484 @@
+package types
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SignMode defines the payload a StdSignature was produced over.
+type SignMode byte
+
+const (
+	// SignModeDefault signs the sorted amino JSON sign bytes of the StdSignDoc.
+	SignModeDefault SignMode = 0
+	// SignModeEIP712 signs the EIP-712 typed data rendering of the StdSignDoc,
+	// see StdSignTypedData.
+	SignModeEIP712 SignMode = 1
+)
+
+// SignModeFromString parses a sign mode name.
+func SignModeFromString(s string) (SignMode, error) {
+	switch strings.ToLower(s) {
+	case "", "default":
+		return SignModeDefault, nil
+	case "eip712":
+		return SignModeEIP712, nil
+	default:
+		return SignModeDefault, fmt.Errorf("unknown sign mode %q, expected default or eip712", s)
+	}
+}
+
+// String implements fmt.Stringer.
+func (m SignMode) String() string {
+	switch m {
+	case SignModeDefault:
+		return "default"
+	case SignModeEIP712:
+		return "eip712"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(m))
+	}
+}
+
+// Valid returns true if the sign mode is known.
+func (m SignMode) Valid() bool {
+	return m == SignModeDefault || m == SignModeEIP712
+}
+
+const (
+	// EIP712DomainName is the name of the EIP-712 signing domain of StdSignDocs.
+	EIP712DomainName = "Cosmos SDK"
+	// EIP712DomainVersion is the version of the EIP-712 signing domain of StdSignDocs.
+	EIP712DomainVersion = "1"
+
+	eip712DomainType  = "EIP712Domain"
+	eip712PrimaryType = "Tx"
+)
+
+// TypedDataField is a named and typed member of an EIP-712 struct type.
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedData is an EIP-712 typed data payload, in the JSON layout expected by
+// eth_signTypedData_v4 and hardware wallets.
+type TypedData struct {
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      map[string]interface{}      `json:"domain"`
+	Message     map[string]interface{}      `json:"message"`
+}
+
+// StdSignTypedData renders a StdSignDoc as EIP-712 typed data. The struct types
+// are derived deterministically from the sorted JSON sign bytes of the messages:
+// objects become struct types named after their path, booleans stay booleans and
+// every other value, including numbers and null, is rendered as a string. Arrays
+// must hold values of a single type, hence all the messages of the tx must be of
+// the same type.
+func StdSignTypedData(chainID string, accnum uint64, sequence uint64, fee StdFee, msgs []sdk.Msg, memo string) (TypedData, error) {
+	for i := 1; i < len(msgs); i++ {
+		if msg := msgs[i]; msg.Route() != msgs[0].Route() || msg.Type() != msgs[0].Type() {
+			return TypedData{}, fmt.Errorf(
+				"the EIP-712 sign mode requires the messages of a tx to be of a single type, got %s/%s and %s/%s",
+				msgs[0].Route(), msgs[0].Type(), msg.Route(), msg.Type(),
+			)
+		}
+	}
+
+	var doc map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(StdSignBytes(chainID, accnum, sequence, fee, msgs, memo)))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return TypedData{}, err
+	}
+
+	td := TypedData{
+		Types: map[string][]TypedDataField{
+			eip712DomainType: {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+			},
+		},
+		PrimaryType: eip712PrimaryType,
+		Domain: map[string]interface{}{
+			"name":    EIP712DomainName,
+			"version": EIP712DomainVersion,
+		},
+	}
+
+	_, message, err := td.deriveType(eip712PrimaryType, doc)
+	if err != nil {
+		return TypedData{}, err
+	}
+	td.Message = message.(map[string]interface{})
+
+	return td, nil
+}
+
+// StdSignTypedDataBytes returns the bytes to sign for a transaction in the
+// EIP-712 sign mode, see TypedData.SignBytes.
+func StdSignTypedDataBytes(chainID string, accnum uint64, sequence uint64, fee StdFee, msgs []sdk.Msg, memo string) ([]byte, error) {
+	td, err := StdSignTypedData(chainID, accnum, sequence, fee, msgs, memo)
+	if err != nil {
+		return nil, err
+	}
+
+	return td.SignBytes()
+}
+
+// deriveType returns the EIP-712 type of a decoded JSON value, registering the
+// struct types it needs, along with the value converted to match that type.
+func (td TypedData) deriveType(name string, v interface{}) (string, interface{}, error) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		fields := make([]TypedDataField, 0, len(keys))
+		value := make(map[string]interface{}, len(keys))
+		for _, k := range keys {
+			typ, fieldValue, err := td.deriveType(name+typeNameSuffix(k), v[k])
+			if err != nil {
+				return "", nil, err
+			}
+
+			fields = append(fields, TypedDataField{Name: k, Type: typ})
+			value[k] = fieldValue
+		}
+
+		if existing, ok := td.Types[name]; ok && !equalTypedDataFields(existing, fields) {
+			return "", nil, fmt.Errorf("values of type %s have different fields", name)
+		}
+		td.Types[name] = fields
+
+		return name, value, nil
+
+	case []interface{}:
+		elemType := "string"
+		values := make([]interface{}, len(v))
+		for i, elem := range v {
+			typ, value, err := td.deriveType(name, elem)
+			if err != nil {
+				return "", nil, err
+			}
+			if i > 0 && typ != elemType {
+				return "", nil, fmt.Errorf("array %s holds values of different types %s and %s", name, elemType, typ)
+			}
+
+			elemType = typ
+			values[i] = value
+		}
+
+		return elemType + "[]", values, nil
+
+	case bool:
+		return "bool", v, nil
+
+	case string:
+		return "string", v, nil
+
+	case json.Number:
+		return "string", v.String(), nil
+
+	case nil:
+		return "string", "", nil
+
+	default:
+		return "", nil, fmt.Errorf("unsupported value %v of type %T", v, v)
+	}
+}
+
+// SignBytes returns the EIP-712 encoding of the typed data that is signed, i.e.
+// "\x19\x01" ‖ domainSeparator ‖ hashStruct(message). Keys using Ethereum
+// style signatures hash it with keccak256 before signing, as eth_signTypedData does.
+func (td TypedData) SignBytes() ([]byte, error) {
+	domainSeparator, err := td.HashStruct(eip712DomainType, td.Domain)
+	if err != nil {
+		return nil, err
+	}
+
+	messageHash, err := td.HashStruct(td.PrimaryType, td.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	bz := make([]byte, 0, 2+len(domainSeparator)+len(messageHash))
+	bz = append(bz, 0x19, 0x01)
+	bz = append(bz, domainSeparator...)
+	return append(bz, messageHash...), nil
+}
+
+// Hash returns the keccak256 hash of the sign bytes, which is the digest an
+// Ethereum wallet signs.
+func (td TypedData) Hash() ([]byte, error) {
+	bz, err := td.SignBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return ethcrypto.Keccak256(bz), nil
+}
+
+// HashStruct returns hashStruct(s) = keccak256(typeHash ‖ encodeData(s)).
+func (td TypedData) HashStruct(primaryType string, data map[string]interface{}) ([]byte, error) {
+	bz, err := td.encodeData(primaryType, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return ethcrypto.Keccak256(bz), nil
+}
+
+// TypeHash returns keccak256(encodeType(primaryType)).
+func (td TypedData) TypeHash(primaryType string) []byte {
+	return ethcrypto.Keccak256([]byte(td.EncodeType(primaryType)))
+}
+
+// EncodeType returns the encoding of a struct type followed by the encodings of
+// the struct types it references, sorted by name.
+func (td TypedData) EncodeType(primaryType string) string {
+	deps := td.dependencies(primaryType, map[string]bool{})
+	if len(deps) > 1 {
+		sort.Strings(deps[1:])
+	}
+
+	var b strings.Builder
+	for _, dep := range deps {
+		b.WriteString(dep)
+		b.WriteString("(")
+		for i, field := range td.Types[dep] {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			b.WriteString(field.Type)
+			b.WriteString(" ")
+			b.WriteString(field.Name)
+		}
+		b.WriteString(")")
+	}
+
+	return b.String()
+}
+
+// dependencies returns primaryType followed by every struct type it
+// references, directly or not.
+func (td TypedData) dependencies(primaryType string, found map[string]bool) []string {
+	for strings.HasSuffix(primaryType, "[]") {
+		primaryType = strings.TrimSuffix(primaryType, "[]")
+	}
+	if found[primaryType] {
+		return nil
+	}
+	if _, ok := td.Types[primaryType]; !ok {
+		return nil
+	}
+
+	found[primaryType] = true
+	deps := []string{primaryType}
+	for _, field := range td.Types[primaryType] {
+		deps = append(deps, td.dependencies(field.Type, found)...)
+	}
+
+	return deps
+}
+
+func (td TypedData) encodeData(primaryType string, data map[string]interface{}) ([]byte, error) {
+	fields, ok := td.Types[primaryType]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %s", primaryType)
+	}
+	if len(fields) < len(data) {
+		return nil, fmt.Errorf("data of type %s has extra fields", primaryType)
+	}
+
+	bz := td.TypeHash(primaryType)
+	for _, field := range fields {
+		value, ok := data[field.Name]
+		if !ok {
+			return nil, fmt.Errorf("missing field %s of type %s", field.Name, primaryType)
+		}
+
+		enc, err := td.encodeValue(field.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("field %s of type %s: %w", field.Name, primaryType, err)
+		}
+		bz = append(bz, enc...)
+	}
+
+	return bz, nil
+}
+
+// encodeValue returns the 32 bytes encoding of a single value.
+func (td TypedData) encodeValue(typ string, value interface{}) ([]byte, error) {
+	if strings.HasSuffix(typ, "[]") {
+		values, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected array, got %T", value)
+		}
+
+		var bz []byte
+		for _, elem := range values {
+			enc, err := td.encodeValue(strings.TrimSuffix(typ, "[]"), elem)
+			if err != nil {
+				return nil, err
+			}
+			bz = append(bz, enc...)
+		}
+		return ethcrypto.Keccak256(bz), nil
+	}
+
+	if _, ok := td.Types[typ]; ok {
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected %s struct, got %T", typ, value)
+		}
+		return td.HashStruct(typ, data)
+	}
+
+	switch {
+	case typ == "string":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", value)
+		}
+		return ethcrypto.Keccak256([]byte(s)), nil
+
+	case typ == "bytes":
+		bz, err := hexValue(value)
+		if err != nil {
+			return nil, err
+		}
+		return ethcrypto.Keccak256(bz), nil
+
+	case typ == "bool":
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected bool, got %T", value)
+		}
+		if b {
+			return leftPad32(big.NewInt(1).Bytes()), nil
+		}
+		return make([]byte, 32), nil
+
+	case typ == "address":
+		bz, err := hexValue(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(bz) != 20 {
+			return nil, fmt.Errorf("invalid address length %d", len(bz))
+		}
+		return leftPad32(bz), nil
+
+	case strings.HasPrefix(typ, "bytes"):
+		size, err := strconv.Atoi(strings.TrimPrefix(typ, "bytes"))
+		if err != nil || size < 1 || size > 32 {
+			return nil, fmt.Errorf("unknown type %s", typ)
+		}
+		bz, err := hexValue(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(bz) != size {
+			return nil, fmt.Errorf("invalid %s length %d", typ, len(bz))
+		}
+		return append(bz, make([]byte, 32-size)...), nil
+
+	case strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"):
+		n, err := intValue(value)
+		if err != nil {
+			return nil, err
+		}
+		if n.Sign() < 0 {
+			if strings.HasPrefix(typ, "uint") {
+				return nil, fmt.Errorf("negative value %s for %s", n, typ)
+			}
+			// two's complement
+			n = new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		if n.BitLen() > 256 {
+			return nil, fmt.Errorf("value %s overflows %s", n, typ)
+		}
+		return leftPad32(n.Bytes()), nil
+
+	default:
+		return nil, fmt.Errorf("unknown type %s", typ)
+	}
+}
+
+func typeNameSuffix(key string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(key, func(r rune) bool { return r == '_' || r == '-' }) {
+		b.WriteString(strings.ToUpper(part[:1]))
+		b.WriteString(part[1:])
+	}
+
+	return b.String()
+}
+
+func equalTypedDataFields(a, b []TypedDataField) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func hexValue(value interface{}) ([]byte, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected hex string, got %T", value)
+	}
+
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
+
+func intValue(value interface{}) (*big.Int, error) {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case json.Number:
+		s = v.String()
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	default:
+		return nil, fmt.Errorf("expected integer, got %T", value)
+	}
+
+	n, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %q", s)
+	}
+
+	return n, nil
+}
+
+func leftPad32(bz []byte) []byte {
+	return append(make([]byte, 32-len(bz)), bz...)
+}
@@ -0,0 +1,152 @@
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestTypedDataHash(t *testing.T) {
+	// example from the EIP-712 specification
+	td := TypedData{
+		Types: map[string][]TypedDataField{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"Person": {
+				{Name: "name", Type: "string"},
+				{Name: "wallet", Type: "address"},
+			},
+			"Mail": {
+				{Name: "from", Type: "Person"},
+				{Name: "to", Type: "Person"},
+				{Name: "contents", Type: "string"},
+			},
+		},
+		PrimaryType: "Mail",
+		Domain: map[string]interface{}{
+			"name":              "Ether Mail",
+			"version":           "1",
+			"chainId":           1,
+			"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC",
+		},
+		Message: map[string]interface{}{
+			"from": map[string]interface{}{
+				"name":   "Cow",
+				"wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826",
+			},
+			"to": map[string]interface{}{
+				"name":   "Bob",
+				"wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB",
+			},
+			"contents": "Hello, Bob!",
+		},
+	}
+
+	require.Equal(t, "Mail(Person from,Person to,string contents)Person(string name,address wallet)", td.EncodeType("Mail"))
+
+	domainSeparator, err := td.HashStruct("EIP712Domain", td.Domain)
+	require.NoError(t, err)
+	require.Equal(t, "f2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f", hex.EncodeToString(domainSeparator))
+
+	messageHash, err := td.HashStruct("Mail", td.Message)
+	require.NoError(t, err)
+	require.Equal(t, "c52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e", hex.EncodeToString(messageHash))
+
+	hash, err := td.Hash()
+	require.NoError(t, err)
+	require.Equal(t, "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hex.EncodeToString(hash))
+
+	delete(td.Message, "contents")
+	_, err = td.Hash()
+	require.Error(t, err)
+}
+
+func TestStdSignTypedData(t *testing.T) {
+	priv := secp256k1.GenPrivKey()
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	msgs := []sdk.Msg{NewTestMsg(addr), NewTestMsg(addr, addr)}
+	fee := NewTestStdFee()
+
+	td, err := StdSignTypedData("test-chain", 3, 7, fee, msgs, "memo")
+	require.NoError(t, err)
+	require.Equal(t, "Tx", td.PrimaryType)
+	require.Equal(t, []TypedDataField{
+		{Name: "account_number", Type: "string"},
+		{Name: "chain_id", Type: "string"},
+		{Name: "fee", Type: "TxFee"},
+		{Name: "memo", Type: "string"},
+		{Name: "msgs", Type: "string[][]"},
+		{Name: "sequence", Type: "string"},
+	}, td.Types["Tx"])
+	require.Equal(t, []TypedDataField{
+		{Name: "amount", Type: "TxFeeAmount[]"},
+		{Name: "gas", Type: "string"},
+	}, td.Types["TxFee"])
+	require.Equal(t, "3", td.Message["account_number"])
+	require.Equal(t, "test-chain", td.Message["chain_id"])
+
+	// the rendering is deterministic
+	signBytes, err := td.SignBytes()
+	require.NoError(t, err)
+	signMsg := StdSignMsg{ChainID: "test-chain", AccountNumber: 3, Sequence: 7, Fee: fee, Msgs: msgs, Memo: "memo"}
+	bz, err := signMsg.BytesForSignMode(SignModeEIP712)
+	require.NoError(t, err)
+	require.Equal(t, signBytes, bz)
+	require.Len(t, bz, 66)
+
+	// and binds every field of the sign doc
+	signMsg.Sequence = 8
+	bz, err = signMsg.BytesForSignMode(SignModeEIP712)
+	require.NoError(t, err)
+	require.NotEqual(t, signBytes, bz)
+
+	// arrays must be homogeneous
+	_, err = StdSignTypedData("test-chain", 3, 7, fee, []sdk.Msg{NewTestMsg(addr), NewTestMsg()}, "memo")
+	require.Error(t, err)
+
+	// so are the messages, even if their sign bytes have the same shape
+	_, err = StdSignTypedData("test-chain", 3, 7, fee, []sdk.Msg{NewTestMsg(addr), otherTestMsg{NewTestMsg(addr)}}, "memo")
+	require.EqualError(t, err, "the EIP-712 sign mode requires the messages of a tx to be of a single type, "+
+		"got TestMsg/Test message and TestMsg/Other test message")
+}
+
+// otherTestMsg is a msg type with the sign bytes of sdk.TestMsg
+type otherTestMsg struct {
+	*sdk.TestMsg
+}
+
+func (msg otherTestMsg) Type() string { return "Other test message" }
+
+func TestStdTxSignModes(t *testing.T) {
+	priv := secp256k1.GenPrivKey()
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	msgs := []sdk.Msg{NewTestMsg(addr)}
+	fee := NewTestStdFee()
+
+	tx := NewStdTx(msgs, fee, []StdSignature{{PubKey: priv.PubKey(), SignMode: SignModeEIP712}}, "")
+	require.NoError(t, tx.ValidateBasic())
+	require.Equal(t, []SignMode{SignModeEIP712}, tx.GetSignModes())
+
+	tx.Signatures[0].SignMode = SignMode(7)
+	require.Error(t, tx.ValidateBasic())
+
+	// the default sign mode is omitted from the JSON encoding
+	bz := ModuleCdc.MustMarshalJSON(StdSignature{PubKey: priv.PubKey()})
+	require.NotContains(t, string(bz), "sign_mode")
+	bz = ModuleCdc.MustMarshalJSON(StdSignature{PubKey: priv.PubKey(), SignMode: SignModeEIP712})
+	require.Contains(t, string(bz), "sign_mode")
+
+	mode, err := SignModeFromString("EIP712")
+	require.NoError(t, err)
+	require.Equal(t, SignModeEIP712, mode)
+	_, err = SignModeFromString("direct")
+	require.Error(t, err)
+}
@@ -0,0 +1,232 @@
+package types
+
+import (
+	"github.com/tendermint/tendermint/crypto/multisig"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// SigningSession carries everything needed to sign a tx offline, possibly by
+// several parties on air-gapped machines: the unsigned tx, the chain ID, the
+// account number and sequence of each signer, and the signatures collected so
+// far. Sessions are passed around as JSON files, so that no signer has to
+// query a node or to look up its account number and sequence.
+type SigningSession struct {
+	ChainID string          `json:"chain_id" yaml:"chain_id"`
+	Tx      StdTx           `json:"tx" yaml:"tx"`
+	Signers []SessionSigner `json:"signers" yaml:"signers"`
+}
+
+// SessionSigner is a signer of the tx of a SigningSession. Signature is set
+// once the signer has signed. The partial signatures are the signatures of the
+// members of a multisig signer, collected until they can be combined into its
+// signature.
+type SessionSigner struct {
+	Address           sdk.AccAddress `json:"address" yaml:"address"`
+	AccountNumber     uint64         `json:"account_number" yaml:"account_number"`
+	Sequence          uint64         `json:"sequence" yaml:"sequence"`
+	Signature         *StdSignature  `json:"signature,omitempty" yaml:"signature,omitempty"`
+	PartialSignatures []StdSignature `json:"partial_signatures,omitempty" yaml:"partial_signatures,omitempty"`
+}
+
+// NewSessionSigner returns a SessionSigner which has not signed yet.
+func NewSessionSigner(addr sdk.AccAddress, accNum, seq uint64) SessionSigner {
+	return SessionSigner{Address: addr, AccountNumber: accNum, Sequence: seq}
+}
+
+// NewSigningSession returns a SigningSession for the given tx, stripped of its
+// signatures. The signers must be the signers of the tx, in the same order.
+func NewSigningSession(chainID string, tx StdTx, signers []SessionSigner) (SigningSession, error) {
+	session := SigningSession{
+		ChainID: chainID,
+		Tx:      NewStdTx(tx.GetMsgs(), tx.Fee, nil, tx.GetMemo()),
+		Signers: signers,
+	}
+
+	return session, session.ValidateBasic()
+}
+
+// ValidateBasic checks that the signers of the session are the signers of its
+// tx and that all the signatures collected so far are valid.
+func (s SigningSession) ValidateBasic() error {
+	if s.ChainID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "chain ID required but not specified")
+	}
+	if len(s.Tx.Signatures) != 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "the tx of a signing session must not be signed")
+	}
+
+	txSigners := s.Tx.GetSigners()
+	if len(s.Signers) != len(txSigners) {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized,
+			"wrong number of signers; expected %d, got %d", len(txSigners), len(s.Signers))
+	}
+
+	for i, signer := range s.Signers {
+		if !signer.Address.Equals(txSigners[i]) {
+			return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized,
+				"signer %d is %s, expected %s", i, signer.Address, txSigners[i])
+		}
+		if signer.Signature != nil {
+			if err := s.verifySignature(signer, *signer.Signature, false); err != nil {
+				return err
+			}
+		}
+		for _, sig := range signer.PartialSignatures {
+			if err := s.verifySignature(signer, sig, true); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// SignMsg returns the message the given signer signs.
+func (s SigningSession) SignMsg(signer SessionSigner) StdSignMsg {
+	return StdSignMsg{
+		ChainID:       s.ChainID,
+		AccountNumber: signer.AccountNumber,
+		Sequence:      signer.Sequence,
+		Fee:           s.Tx.Fee,
+		Msgs:          s.Tx.GetMsgs(),
+		Memo:          s.Tx.GetMemo(),
+	}
+}
+
+// Signer returns the index of the signer of the given address.
+func (s SigningSession) Signer(addr sdk.AccAddress) (int, error) {
+	for i, signer := range s.Signers {
+		if signer.Address.Equals(addr) {
+			return i, nil
+		}
+	}
+
+	return 0, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not a signer of the tx", addr)
+}
+
+// AddSignature adds the signature of a signer to the session. A signature made
+// by another key than the signer's is added as a partial signature of a
+// multisig signer, replacing any previous signature of the same key.
+func (s *SigningSession) AddSignature(addr sdk.AccAddress, sig StdSignature) error {
+	i, err := s.Signer(addr)
+	if err != nil {
+		return err
+	}
+
+	signer := &s.Signers[i]
+	if sig.PubKey == nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidPubKey, "signature has no public key")
+	}
+
+	partial := !sdk.AccAddress(sig.PubKey.Address()).Equals(addr)
+	if err := s.verifySignature(*signer, sig, partial); err != nil {
+		return err
+	}
+
+	if !partial {
+		signer.Signature = &sig
+		return nil
+	}
+
+	for j, other := range signer.PartialSignatures {
+		if other.PubKey.Equals(sig.PubKey) {
+			signer.PartialSignatures[j] = sig
+			return nil
+		}
+	}
+	signer.PartialSignatures = append(signer.PartialSignatures, sig)
+	return nil
+}
+
+// CombineMultisig combines the partial signatures of a multisig signer into its
+// signature. It fails if they don't reach the threshold of the multisig key.
+func (s *SigningSession) CombineMultisig(addr sdk.AccAddress, pubKey multisig.PubKeyMultisigThreshold) error {
+	i, err := s.Signer(addr)
+	if err != nil {
+		return err
+	}
+
+	signer := &s.Signers[i]
+	if !sdk.AccAddress(pubKey.Address()).Equals(addr) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidPubKey, "multisig key does not match signer %s", addr)
+	}
+	if len(signer.PartialSignatures) < int(pubKey.K) {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized,
+			"%s has %d of the %d signatures required", addr, len(signer.PartialSignatures), pubKey.K)
+	}
+
+	mode := signer.PartialSignatures[0].SignMode
+	multisigSig := multisig.NewMultisig(len(pubKey.PubKeys))
+	for _, sig := range signer.PartialSignatures {
+		if sig.SignMode != mode {
+			return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "partial signatures must share the same sign mode")
+		}
+		if err := multisigSig.AddSignatureFromPubKey(sig.Signature, sig.PubKey, pubKey.PubKeys); err != nil {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidPubKey, err.Error())
+		}
+	}
+
+	sig := StdSignature{PubKey: pubKey, Signature: ModuleCdc.MustMarshalBinaryBare(multisigSig), SignMode: mode}
+	if err := s.verifySignature(*signer, sig, false); err != nil {
+		return err
+	}
+
+	signer.Signature = &sig
+	signer.PartialSignatures = nil
+	return nil
+}
+
+// MissingSigners returns the signers which have not signed yet.
+func (s SigningSession) MissingSigners() []sdk.AccAddress {
+	var missing []sdk.AccAddress
+	for _, signer := range s.Signers {
+		if signer.Signature == nil {
+			missing = append(missing, signer.Address)
+		}
+	}
+
+	return missing
+}
+
+// SignedTx returns the tx of the session with the signatures of all its
+// signers, or an error if any of them is missing.
+func (s SigningSession) SignedTx() (StdTx, error) {
+	if err := s.ValidateBasic(); err != nil {
+		return StdTx{}, err
+	}
+	if missing := s.MissingSigners(); len(missing) > 0 {
+		return StdTx{}, sdkerrors.Wrapf(sdkerrors.ErrNoSignatures, "missing signatures of %v", missing)
+	}
+
+	sigs := make([]StdSignature, len(s.Signers))
+	for i, signer := range s.Signers {
+		sigs[i] = *signer.Signature
+	}
+
+	return NewStdTx(s.Tx.GetMsgs(), s.Tx.Fee, sigs, s.Tx.GetMemo()), nil
+}
+
+// verifySignature verifies a signature of a signer. Partial signatures are
+// made by a member of a multisig signer, the other ones by the signer's key.
+func (s SigningSession) verifySignature(signer SessionSigner, sig StdSignature, partial bool) error {
+	if sig.PubKey == nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidPubKey, "signature has no public key")
+	}
+
+	signedBy := sdk.AccAddress(sig.PubKey.Address())
+	if partial == signedBy.Equals(signer.Address) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidPubKey, "signature of %s is not a valid signature of %s", signedBy, signer.Address)
+	}
+
+	signBytes, err := s.SignMsg(signer).BytesForSignMode(sig.SignMode)
+	if err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, err.Error())
+	}
+	if !sig.VerifyBytes(signBytes, sig.Signature) {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "invalid signature of %s", signedBy)
+	}
+
+	return nil
+}
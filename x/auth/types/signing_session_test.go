@@ -0,0 +1,60 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/multisig"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestSigningSession(t *testing.T) {
+	priv1, _, addr1 := KeyTestPubAddr()
+	privs := []crypto.PrivKey{secp256k1.GenPrivKey(), secp256k1.GenPrivKey(), secp256k1.GenPrivKey()}
+	multisigPub := multisig.NewPubKeyMultisigThreshold(2, []crypto.PubKey{privs[0].PubKey(), privs[1].PubKey(), privs[2].PubKey()})
+	multisigAddr := sdk.AccAddress(multisigPub.Address())
+
+	tx := NewStdTx([]sdk.Msg{NewTestMsg(addr1, multisigAddr)}, NewTestStdFee(), nil, "memo")
+
+	// the signers must match the tx
+	_, err := NewSigningSession("test-chain", tx, []SessionSigner{NewSessionSigner(addr1, 3, 7)})
+	require.Error(t, err)
+	_, err = NewSigningSession("test-chain", tx, []SessionSigner{NewSessionSigner(multisigAddr, 4, 0), NewSessionSigner(addr1, 3, 7)})
+	require.Error(t, err)
+
+	session, err := NewSigningSession("test-chain", tx, []SessionSigner{NewSessionSigner(addr1, 3, 7), NewSessionSigner(multisigAddr, 4, 0)})
+	require.NoError(t, err)
+	require.Equal(t, []sdk.AccAddress{addr1, multisigAddr}, session.MissingSigners())
+
+	sign := func(priv crypto.PrivKey, signer int) StdSignature {
+		sig, err := priv.Sign(session.SignMsg(session.Signers[signer]).Bytes())
+		require.NoError(t, err)
+		return StdSignature{PubKey: priv.PubKey(), Signature: sig}
+	}
+
+	// signatures must be valid for the account number and sequence of the signer
+	require.Error(t, session.AddSignature(addr1, sign(priv1, 1)))
+	require.NoError(t, session.AddSignature(addr1, sign(priv1, 0)))
+	require.Equal(t, []sdk.AccAddress{multisigAddr}, session.MissingSigners())
+	_, err = session.SignedTx()
+	require.Error(t, err)
+
+	// partial signatures are collected until the threshold is reached
+	require.NoError(t, session.AddSignature(multisigAddr, sign(privs[0], 1)))
+	require.NoError(t, session.AddSignature(multisigAddr, sign(privs[0], 1)))
+	require.Error(t, session.CombineMultisig(multisigAddr, multisigPub.(multisig.PubKeyMultisigThreshold)))
+	require.NoError(t, session.AddSignature(multisigAddr, sign(privs[2], 1)))
+	require.Len(t, session.Signers[1].PartialSignatures, 2)
+
+	require.NoError(t, session.CombineMultisig(multisigAddr, multisigPub.(multisig.PubKeyMultisigThreshold)))
+	require.Empty(t, session.MissingSigners())
+
+	signed, err := session.SignedTx()
+	require.NoError(t, err)
+	require.NoError(t, signed.ValidateBasic())
+	require.Len(t, signed.Signatures, 2)
+	require.True(t, multisigPub.VerifyBytes(session.SignMsg(session.Signers[1]).Bytes(), signed.Signatures[1].Signature))
+}
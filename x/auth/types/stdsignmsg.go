@@ -1,6 +1,8 @@
 package types
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -20,3 +22,20 @@ type StdSignMsg struct {
 func (msg StdSignMsg) Bytes() []byte {
 	return StdSignBytes(msg.ChainID, msg.AccountNumber, msg.Sequence, msg.Fee, msg.Msgs, msg.Memo)
 }
+
+// TypedData returns the EIP-712 typed data rendering of the message.
+func (msg StdSignMsg) TypedData() (TypedData, error) {
+	return StdSignTypedData(msg.ChainID, msg.AccountNumber, msg.Sequence, msg.Fee, msg.Msgs, msg.Memo)
+}
+
+// BytesForSignMode returns the bytes to sign in the given sign mode.
+func (msg StdSignMsg) BytesForSignMode(mode SignMode) ([]byte, error) {
+	switch mode {
+	case SignModeDefault:
+		return msg.Bytes(), nil
+	case SignModeEIP712:
+		return StdSignTypedDataBytes(msg.ChainID, msg.AccountNumber, msg.Sequence, msg.Fee, msg.Msgs, msg.Memo)
+	default:
+		return nil, fmt.Errorf("invalid sign mode %s", mode)
+	}
+}
@@ -69,6 +69,11 @@ func (tx StdTx) ValidateBasic() error {
 			"wrong number of signers; expected %d, got %d", tx.GetSigners(), len(stdSigs),
 		)
 	}
+	for _, sig := range tx.Signatures {
+		if !sig.SignMode.Valid() {
+			return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "invalid sign mode %s", sig.SignMode)
+		}
+	}
 
 	return nil
 }
@@ -149,6 +154,28 @@ func (tx StdTx) GetSignBytes(ctx sdk.Context, acc exported.Account) []byte {
 	)
 }
 
+// GetSignModes returns the sign mode of each signature.
+func (tx StdTx) GetSignModes() []SignMode {
+	modes := make([]SignMode, len(tx.Signatures))
+	for i, stdSig := range tx.Signatures {
+		modes[i] = stdSig.SignMode
+	}
+	return modes
+}
+
+// GetTypedDataSignBytes returns the EIP-712 typed data signBytes of the tx for
+// a given signer.
+func (tx StdTx) GetTypedDataSignBytes(ctx sdk.Context, acc exported.Account) ([]byte, error) {
+	var accNum uint64
+	if ctx.BlockHeight() != 0 {
+		accNum = acc.GetAccountNumber()
+	}
+
+	return StdSignTypedDataBytes(
+		ctx.ChainID(), accNum, acc.GetSequence(), tx.Fee, tx.Msgs, tx.Memo,
+	)
+}
+
 // GetGas returns the Gas in StdFee
 func (tx StdTx) GetGas() uint64 { return tx.Fee.Gas }
 
@@ -266,6 +293,8 @@ func StdSignBytes(chainID string, accnum uint64, sequence uint64, fee StdFee, ms
 type StdSignature struct {
 	crypto.PubKey `json:"pub_key" yaml:"pub_key"` // optional
 	Signature     []byte                          `json:"signature" yaml:"signature"`
+	// SignMode tells which payload the signature is over, see SignMode.
+	SignMode SignMode `json:"sign_mode,omitempty" yaml:"sign_mode,omitempty"`
 }
 
 // DefaultTxDecoder logic for standard transaction decoding
@@ -313,9 +342,11 @@ func (ss StdSignature) MarshalYAML() (interface{}, error) {
 	bz, err = yaml.Marshal(struct {
 		PubKey    string
 		Signature string
+		SignMode  string `yaml:",omitempty"`
 	}{
 		PubKey:    pubkey,
 		Signature: fmt.Sprintf("%s", ss.Signature),
+		SignMode:  signModeYAML(ss.SignMode),
 	})
 	if err != nil {
 		return nil, err
@@ -323,3 +354,10 @@ func (ss StdSignature) MarshalYAML() (interface{}, error) {
 
 	return string(bz), err
 }
+
+func signModeYAML(mode SignMode) string {
+	if mode == SignModeDefault {
+		return ""
+	}
+	return mode.String()
+}
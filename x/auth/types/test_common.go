@@ -80,3 +80,25 @@ func NewTestTxWithSignBytes(msgs []sdk.Msg, privs []crypto.PrivKey, accNums []ui
 	tx := NewStdTx(msgs, fee, sigs, memo)
 	return tx
 }
+
+func NewTestTxWithSignMode(ctx sdk.Context, msgs []sdk.Msg, privs []crypto.PrivKey, accNums []uint64, seqs []uint64, fee StdFee, mode SignMode) sdk.Tx {
+	sigs := make([]StdSignature, len(privs))
+	for i, priv := range privs {
+		signBytes, err := StdSignMsg{
+			ChainID: ctx.ChainID(), AccountNumber: accNums[i], Sequence: seqs[i], Fee: fee, Msgs: msgs,
+		}.BytesForSignMode(mode)
+		if err != nil {
+			panic(err)
+		}
+
+		sig, err := priv.Sign(signBytes)
+		if err != nil {
+			panic(err)
+		}
+
+		sigs[i] = StdSignature{PubKey: priv.PubKey(), Signature: sig, SignMode: mode}
+	}
+
+	tx := NewStdTx(msgs, fee, sigs, "")
+	return tx
+}
@@ -27,6 +27,7 @@ type TxBuilder struct {
 	memo               string
 	fees               sdk.Coins
 	gasPrices          sdk.DecCoins
+	signMode           SignMode
 }
 
 // NewTxBuilder returns a new initialized TxBuilder.
@@ -108,6 +109,9 @@ func (bldr TxBuilder) Fees() sdk.Coins { return bldr.fees }
 // GasPrices returns the gas prices set for the transaction, if any.
 func (bldr TxBuilder) GasPrices() sdk.DecCoins { return bldr.gasPrices }
 
+// SignMode returns the sign mode of the signatures produced by the builder.
+func (bldr TxBuilder) SignMode() SignMode { return bldr.signMode }
+
 // WithTxEncoder returns a copy of the context with an updated codec.
 func (bldr TxBuilder) WithTxEncoder(txEncoder sdk.TxEncoder) TxBuilder {
 	bldr.txEncoder = txEncoder
@@ -172,6 +176,12 @@ func (bldr TxBuilder) WithAccountNumber(accnum uint64) TxBuilder {
 	return bldr
 }
 
+// WithSignMode returns a copy of the context with an updated sign mode.
+func (bldr TxBuilder) WithSignMode(mode SignMode) TxBuilder {
+	bldr.signMode = mode
+	return bldr
+}
+
 // BuildSignMsg builds a single message to be signed from a TxBuilder given a
 // set of messages. It returns an error if a fee is supplied but cannot be
 // parsed.
@@ -210,7 +220,7 @@ func (bldr TxBuilder) BuildSignMsg(msgs []sdk.Msg) (StdSignMsg, error) {
 // Sign signs a transaction given a name, passphrase, and a single message to
 // signed. An error is returned if signing fails.
 func (bldr TxBuilder) Sign(name, passphrase string, msg StdSignMsg) ([]byte, error) {
-	sig, err := MakeSignature(bldr.keybase, name, passphrase, msg)
+	sig, err := MakeSignatureWithSignMode(bldr.keybase, name, passphrase, msg, bldr.signMode)
 	if err != nil {
 		return nil, err
 	}
@@ -249,14 +259,14 @@ func (bldr TxBuilder) SignStdTx(name, passphrase string, stdTx StdTx, appendSig
 		return StdTx{}, fmt.Errorf("chain ID required but not specified")
 	}
 
-	stdSignature, err := MakeSignature(bldr.keybase, name, passphrase, StdSignMsg{
+	stdSignature, err := MakeSignatureWithSignMode(bldr.keybase, name, passphrase, StdSignMsg{
 		ChainID:       bldr.chainID,
 		AccountNumber: bldr.accountNumber,
 		Sequence:      bldr.sequence,
 		Fee:           stdTx.Fee,
 		Msgs:          stdTx.GetMsgs(),
 		Memo:          stdTx.GetMemo(),
-	})
+	}, bldr.signMode)
 	if err != nil {
 		return
 	}
@@ -275,6 +285,19 @@ func (bldr TxBuilder) SignStdTx(name, passphrase string, stdTx StdTx, appendSig
 func MakeSignature(keybase keys.Keybase, name, passphrase string,
 	msg StdSignMsg) (sig StdSignature, err error) {
 
+	return MakeSignatureWithSignMode(keybase, name, passphrase, msg, SignModeDefault)
+}
+
+// MakeSignatureWithSignMode builds a StdSignature over the bytes of a StdSignMsg
+// in the given sign mode.
+func MakeSignatureWithSignMode(keybase keys.Keybase, name, passphrase string,
+	msg StdSignMsg, mode SignMode) (sig StdSignature, err error) {
+
+	signBytes, err := msg.BytesForSignMode(mode)
+	if err != nil {
+		return
+	}
+
 	if keybase == nil {
 		keybase, err = keys.NewKeyring(sdk.KeyringServiceName(), viper.GetString(flags.FlagKeyringBackend), viper.GetString(flags.FlagHome), os.Stdin)
 		if err != nil {
@@ -282,12 +305,13 @@ func MakeSignature(keybase keys.Keybase, name, passphrase string,
 		}
 	}
 
-	sigBytes, pubkey, err := keybase.Sign(name, passphrase, msg.Bytes())
+	sigBytes, pubkey, err := keybase.Sign(name, passphrase, signBytes)
 	if err != nil {
 		return
 	}
 	return StdSignature{
 		PubKey:    pubkey,
 		Signature: sigBytes,
+		SignMode:  mode,
 	}, nil
 }
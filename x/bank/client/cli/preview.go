@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/bank/internal/types"
+)
+
+func init() {
+	utils.RegisterMsgRenderer(types.RouterKey, types.MsgSend{}.Type(), renderMsgSend)
+	utils.RegisterMsgRenderer(types.RouterKey, types.MsgMultiSend{}.Type(), renderMsgMultiSend)
+}
+
+func renderMsgSend(msg sdk.Msg) (utils.MsgPreview, error) {
+	send, ok := msg.(types.MsgSend)
+	if !ok {
+		return utils.MsgPreview{}, fmt.Errorf("unexpected msg type %T", msg)
+	}
+
+	return utils.MsgPreview{
+		Summary: fmt.Sprintf("Send %s from %s to %s",
+			utils.FormatCoins(send.Amount), send.FromAddress, send.ToAddress),
+		Recipients: []sdk.AccAddress{send.ToAddress},
+	}, nil
+}
+
+func renderMsgMultiSend(msg sdk.Msg) (utils.MsgPreview, error) {
+	multiSend, ok := msg.(types.MsgMultiSend)
+	if !ok {
+		return utils.MsgPreview{}, fmt.Errorf("unexpected msg type %T", msg)
+	}
+
+	var (
+		inputs     []string
+		outputs    []string
+		recipients []sdk.AccAddress
+	)
+	for _, in := range multiSend.Inputs {
+		inputs = append(inputs, fmt.Sprintf("%s from %s", utils.FormatCoins(in.Coins), in.Address))
+	}
+	for _, out := range multiSend.Outputs {
+		outputs = append(outputs, fmt.Sprintf("%s to %s", utils.FormatCoins(out.Coins), out.Address))
+		recipients = append(recipients, out.Address)
+	}
+
+	return utils.MsgPreview{
+		Summary:    fmt.Sprintf("Send %s; receive %s", strings.Join(inputs, ", "), strings.Join(outputs, ", ")),
+		Recipients: recipients,
+	}, nil
+}
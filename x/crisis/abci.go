@@ -4,11 +4,16 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
-// check all registered invariants
+// check the registered invariants, a pass over all invariants is started every
+// InvCheckPeriod blocks and spread across blocks within the InvCheckBudget
 func EndBlocker(ctx sdk.Context, k Keeper) {
-	if k.InvCheckPeriod() == 0 || ctx.BlockHeight()%int64(k.InvCheckPeriod()) != 0 {
+	if k.InvCheckPeriod() == 0 {
 		// skip running the invariant check
 		return
 	}
-	k.AssertInvariants(ctx)
+
+	if ctx.BlockHeight()%int64(k.InvCheckPeriod()) == 0 {
+		k.StartInvariantsPass(ctx)
+	}
+	k.CheckInvariants(ctx)
 }
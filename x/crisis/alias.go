@@ -8,30 +8,48 @@ import (
 )
 
 const (
-	ModuleName           = types.ModuleName
-	DefaultParamspace    = types.DefaultParamspace
-	EventTypeInvariant   = types.EventTypeInvariant
-	AttributeValueCrisis = types.AttributeValueCrisis
-	AttributeKeyRoute    = types.AttributeKeyRoute
+	ModuleName               = types.ModuleName
+	StoreKey                 = types.StoreKey
+	DefaultParamspace        = types.DefaultParamspace
+	EventTypeInvariant       = types.EventTypeInvariant
+	AttributeValueCrisis     = types.AttributeValueCrisis
+	AttributeKeyRoute        = types.AttributeKeyRoute
+	QuerierRoute             = types.QuerierRoute
+	EventTypeInvariantBroken = types.EventTypeInvariantBroken
+	AttributeKeyHeight       = types.AttributeKeyHeight
+	AttributeKeyResult       = types.AttributeKeyResult
+	InvariantActionHalt      = types.InvariantActionHalt
+	InvariantActionLog       = types.InvariantActionLog
+	InvariantActionEvent     = types.InvariantActionEvent
+	QueryInvariantResults    = types.QueryInvariantResults
+	QueryInvariantResult     = types.QueryInvariantResult
 )
 
 var (
-	RegisterCodec            = types.RegisterCodec
-	ErrNoSender              = types.ErrNoSender
-	ErrUnknownInvariant      = types.ErrUnknownInvariant
-	NewGenesisState          = types.NewGenesisState
-	DefaultGenesisState      = types.DefaultGenesisState
-	NewMsgVerifyInvariant    = types.NewMsgVerifyInvariant
-	ParamKeyTable            = types.ParamKeyTable
-	NewInvarRoute            = types.NewInvarRoute
-	NewKeeper                = keeper.NewKeeper
-	ModuleCdc                = types.ModuleCdc
-	ParamStoreKeyConstantFee = types.ParamStoreKeyConstantFee
+	RegisterCodec                 = types.RegisterCodec
+	ErrNoSender                   = types.ErrNoSender
+	ErrUnknownInvariant           = types.ErrUnknownInvariant
+	NewGenesisState               = types.NewGenesisState
+	DefaultGenesisState           = types.DefaultGenesisState
+	NewMsgVerifyInvariant         = types.NewMsgVerifyInvariant
+	ParamKeyTable                 = types.ParamKeyTable
+	NewInvarRoute                 = types.NewInvarRoute
+	NewKeeper                     = keeper.NewKeeper
+	NewQuerier                    = keeper.NewQuerier
+	NewInvariantAction            = types.NewInvariantAction
+	NewInvariantResult            = types.NewInvariantResult
+	NewQueryInvariantParams       = types.NewQueryInvariantParams
+	ModuleCdc                     = types.ModuleCdc
+	ParamStoreKeyConstantFee      = types.ParamStoreKeyConstantFee
+	ParamStoreKeyInvariantActions = types.ParamStoreKeyInvariantActions
 )
 
 type (
-	GenesisState       = types.GenesisState
-	MsgVerifyInvariant = types.MsgVerifyInvariant
-	InvarRoute         = types.InvarRoute
-	Keeper             = keeper.Keeper
+	GenesisState         = types.GenesisState
+	MsgVerifyInvariant   = types.MsgVerifyInvariant
+	InvarRoute           = types.InvarRoute
+	InvariantAction      = types.InvariantAction
+	InvariantResult      = types.InvariantResult
+	QueryInvariantParams = types.QueryInvariantParams
+	Keeper               = keeper.Keeper
 )
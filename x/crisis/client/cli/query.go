@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/x/crisis/internal/types"
+)
+
+// GetQueryCmd returns the cli query commands for this module
+func GetQueryCmd(cdc *codec.Codec) *cobra.Command {
+	crisisQueryCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Querying commands for the crisis module",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	crisisQueryCmd.AddCommand(flags.GetCommands(
+		GetCmdQueryInvariantResults(cdc),
+		GetCmdQueryInvariantResult(cdc),
+	)...)
+
+	return crisisQueryCmd
+}
+
+// GetCmdQueryInvariantResults implements the query command for the results of
+// all invariants run by the node.
+func GetCmdQueryInvariantResults(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "invariants",
+		Short: "Query the last result of every invariant checked by the node",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryInvariantResults)
+			res, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var results []types.InvariantResult
+			if err := cdc.UnmarshalJSON(res, &results); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(results)
+		},
+	}
+}
+
+// GetCmdQueryInvariantResult implements the query command for the result of a
+// single invariant run by the node.
+func GetCmdQueryInvariantResult(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "invariant [module-name] [invariant-route]",
+		Short: "Query the last result of an invariant checked by the node",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, err := cdc.MarshalJSON(types.NewQueryInvariantParams(args[0], args[1]))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryInvariantResult)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var result types.InvariantResult
+			if err := cdc.UnmarshalJSON(res, &result); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(result)
+		},
+	}
+}
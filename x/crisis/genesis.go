@@ -9,10 +9,12 @@ import (
 // new crisis genesis
 func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data types.GenesisState) {
 	keeper.SetConstantFee(ctx, data.ConstantFee)
+	keeper.SetInvariantActions(ctx, data.InvariantActions)
 }
 
 // ExportGenesis returns a GenesisState for a given context and keeper.
 func ExportGenesis(ctx sdk.Context, keeper keeper.Keeper) types.GenesisState {
 	constantFee := keeper.GetConstantFee(ctx)
-	return types.NewGenesisState(constantFee)
+	invariantActions := keeper.GetInvariantActions(ctx)
+	return types.NewGenesisState(constantFee, invariantActions)
 }
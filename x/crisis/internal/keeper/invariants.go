@@ -0,0 +1,158 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/crisis/internal/types"
+)
+
+// getInvariantsPass returns the index of the next route of the pending pass
+// over the registered invariants, if any.
+func (k Keeper) getInvariantsPass(ctx sdk.Context) (next uint64, pending bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.InvariantsPassKey)
+	if bz == nil {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(bz), true
+}
+
+func (k Keeper) setInvariantsPass(ctx sdk.Context, next uint64) {
+	ctx.KVStore(k.storeKey).Set(types.InvariantsPassKey, sdk.Uint64ToBigEndian(next))
+}
+
+func (k Keeper) deleteInvariantsPass(ctx sdk.Context) {
+	ctx.KVStore(k.storeKey).Delete(types.InvariantsPassKey)
+}
+
+// StartInvariantsPass schedules a pass over all registered invariants, which
+// is carried out by CheckInvariants. A pass that is still in progress is
+// continued rather than restarted, so that every invariant keeps being run.
+func (k Keeper) StartInvariantsPass(ctx sdk.Context) {
+	if next, pending := k.getInvariantsPass(ctx); pending {
+		k.Logger(ctx).Info(
+			"previous invariants pass still in progress", "next", next, "total", len(k.routes),
+		)
+		return
+	}
+
+	k.setInvariantsPass(ctx, 0)
+}
+
+// CheckInvariants continues the pending pass over the registered invariants.
+// Without a budget the pass is completed within the block. Otherwise the
+// invariants are run until the time spent in the block passes the budget, and
+// the pass is resumed from the next invariant in the next block. At least one
+// invariant is run per block, so that the pass progresses whatever the budget.
+// Broken invariants are handled with the action configured for their route.
+func (k Keeper) CheckInvariants(ctx sdk.Context) {
+	next, pending := k.getInvariantsPass(ctx)
+	if !pending {
+		return
+	}
+
+	start := time.Now()
+	routes := k.Routes()
+
+	end := next
+	for end < uint64(len(routes)) {
+		k.checkInvariant(ctx, routes[end])
+		end++
+
+		if k.invCheckBudget > 0 && time.Since(start) >= k.invCheckBudget {
+			break
+		}
+	}
+
+	if end < uint64(len(routes)) {
+		k.setInvariantsPass(ctx, end)
+		k.Logger(ctx).Debug(
+			"checked invariants", "from", next, "to", end, "total", len(routes),
+			"duration", time.Since(start), "height", ctx.BlockHeight(),
+		)
+		return
+	}
+
+	k.deleteInvariantsPass(ctx)
+	k.Logger(ctx).Info("checked all invariants", "duration", time.Since(start), "height", ctx.BlockHeight())
+}
+
+// checkInvariant runs a single invariant, records its result and handles it
+// if broken.
+func (k Keeper) checkInvariant(ctx sdk.Context, ir types.InvarRoute) {
+	start := time.Now()
+	res, stop := ir.Invar(ctx)
+	result := types.NewInvariantResult(ir.FullRoute(), ctx.BlockHeight(), stop, res, time.Since(start))
+
+	if stop {
+		result.Action = k.GetInvariantAction(ctx, ir.FullRoute())
+	}
+
+	k.setInvariantResult(ctx, result)
+
+	if !stop {
+		return
+	}
+
+	switch result.Action {
+	case types.InvariantActionLog:
+		k.Logger(ctx).Error("invariant broken", "route", result.Route, "height", result.Height, "result", res)
+
+	case types.InvariantActionEvent:
+		k.Logger(ctx).Error("invariant broken", "route", result.Route, "height", result.Height, "result", res)
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeInvariantBroken,
+				sdk.NewAttribute(types.AttributeKeyRoute, result.Route),
+				sdk.NewAttribute(types.AttributeKeyHeight, fmt.Sprintf("%d", result.Height)),
+				sdk.NewAttribute(types.AttributeKeyResult, res),
+			),
+		)
+
+	default:
+		panic(invariantBrokenError(res, ir))
+	}
+}
+
+func (k Keeper) setInvariantResult(ctx sdk.Context, result types.InvariantResult) {
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(result)
+	ctx.KVStore(k.storeKey).Set(types.GetInvariantResultKey(result.Route), bz)
+}
+
+// GetInvariantResults returns the last result of every invariant run by the
+// node, sorted by route.
+func (k Keeper) GetInvariantResults(ctx sdk.Context) []types.InvariantResult {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.InvariantResultsKeyPrefix)
+	defer iterator.Close()
+
+	results := []types.InvariantResult{}
+	for ; iterator.Valid(); iterator.Next() {
+		var result types.InvariantResult
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &result)
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// GetInvariantResult returns the last result of the invariant registered under
+// the full route, if it was run by the node.
+func (k Keeper) GetInvariantResult(ctx sdk.Context, route string) (result types.InvariantResult, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetInvariantResultKey(route))
+	if bz == nil {
+		return result, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &result)
+	return result, true
+}
+
+func invariantBrokenError(res string, ir types.InvarRoute) error {
+	// TODO: Include app name as part of context to allow for this to be
+	// variable.
+	return fmt.Errorf("invariant broken: %s\n"+
+		"\tCRITICAL please submit the following transaction:\n"+
+		"\t\t tx crisis invariant-broken %s %s", res, ir.ModuleName, ir.Route)
+}
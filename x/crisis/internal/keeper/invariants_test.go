@@ -0,0 +1,137 @@
+package keeper_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/simapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/crisis/internal/keeper"
+	"github.com/cosmos/cosmos-sdk/x/crisis/internal/types"
+)
+
+var testSubspaces int
+
+// newTestKeeper returns a crisis keeper without registered invariants
+func newTestKeeper(app *simapp.SimApp) keeper.Keeper {
+	testSubspaces++
+	subspace := app.ParamsKeeper.Subspace(fmt.Sprintf("%s%d", types.ModuleName, testSubspaces))
+	return keeper.NewKeeper(app.Codec(), app.GetKey(types.StoreKey), subspace, 5, app.SupplyKeeper, "")
+}
+
+func TestCheckInvariants(t *testing.T) {
+	app := createTestApp()
+	ctx := app.NewContext(false, abci.Header{Height: 5})
+	k := newTestKeeper(app)
+
+	var runs []string
+	delays := make(map[string]time.Duration)
+	newInvariant := func(name string, broken bool) sdk.Invariant {
+		return func(sdk.Context) (string, bool) {
+			runs = append(runs, name)
+			time.Sleep(delays[name])
+			return name, broken
+		}
+	}
+	k.RegisterRoute("testModule", "a", newInvariant("a", false))
+	k.RegisterRoute("testModule", "b", newInvariant("b", true))
+	k.RegisterRoute("testModule", "c", newInvariant("c", false))
+
+	// nothing runs without a pending pass
+	k.CheckInvariants(ctx)
+	require.Empty(t, runs)
+
+	// a broken invariant halts by default
+	k.StartInvariantsPass(ctx)
+	require.Panics(t, func() { k.CheckInvariants(ctx) })
+	require.Equal(t, []string{"a", "b"}, runs)
+
+	// a pass is spread across blocks, running invariants until the time spent
+	// in a block passes the budget
+	ctx = app.NewContext(false, abci.Header{Height: 5})
+	ctx.KVStore(app.GetKey(types.StoreKey)).Delete(types.InvariantsPassKey)
+	k = newTestKeeper(app)
+	k.RegisterRoute("testModule", "a", newInvariant("a", false))
+	k.RegisterRoute("testModule", "b", newInvariant("b", true))
+	k.RegisterRoute("testModule", "c", newInvariant("c", false))
+	k.SetInvCheckBudget(100 * time.Millisecond)
+	delays["b"] = 300 * time.Millisecond
+	k.SetInvariantActions(ctx, []types.InvariantAction{
+		types.NewInvariantAction("testModule/b", types.InvariantActionEvent),
+	})
+
+	runs = nil
+	k.StartInvariantsPass(ctx)
+	k.CheckInvariants(ctx)
+	require.Equal(t, []string{"a", "b"}, runs)
+
+	// a pass in progress is continued rather than restarted
+	k.StartInvariantsPass(ctx)
+	k.CheckInvariants(ctx.WithBlockHeight(ctx.BlockHeight() + 1))
+	require.Equal(t, []string{"a", "b", "c"}, runs)
+
+	// the pass is complete
+	k.CheckInvariants(ctx)
+	require.Equal(t, []string{"a", "b", "c"}, runs)
+
+	events := ctx.EventManager().Events()
+	require.Len(t, events, 1)
+	require.Equal(t, types.EventTypeInvariantBroken, events[0].Type)
+
+	results := k.GetInvariantResults(ctx)
+	require.Len(t, results, 3)
+	require.Equal(t, "testModule/b", results[1].Route)
+	require.True(t, results[1].Broken)
+	require.Equal(t, types.InvariantActionEvent, results[1].Action)
+	require.Equal(t, int64(5), results[1].Height)
+	require.Equal(t, int64(6), results[2].Height)
+	require.False(t, results[2].Broken)
+	require.Empty(t, results[2].Action)
+
+	// the results are kept in the crisis store
+	require.Equal(t, results, newTestKeeper(app).GetInvariantResults(ctx))
+
+	// without a budget a pass completes within a block
+	runs = nil
+	delays["b"] = 0
+	k.SetInvCheckBudget(0)
+	k.SetInvariantActions(ctx, []types.InvariantAction{
+		types.NewInvariantAction("testModule/b", types.InvariantActionLog),
+	})
+	k.StartInvariantsPass(ctx)
+	k.CheckInvariants(ctx)
+	require.Equal(t, []string{"a", "b", "c"}, runs)
+
+	result, found := k.GetInvariantResult(ctx, "testModule/b")
+	require.True(t, found)
+	require.Equal(t, types.InvariantActionLog, result.Action)
+}
+
+func TestInvariantActionsParam(t *testing.T) {
+	app := createTestApp()
+	ctx := app.NewContext(false, abci.Header{})
+
+	require.Equal(t, types.InvariantActionHalt, app.CrisisKeeper.GetInvariantAction(ctx, "bank/nonnegative-outstanding"))
+
+	actions := []types.InvariantAction{types.NewInvariantAction("bank/nonnegative-outstanding", types.InvariantActionLog)}
+	app.CrisisKeeper.SetInvariantActions(ctx, actions)
+	require.Equal(t, actions, app.CrisisKeeper.GetInvariantActions(ctx))
+	require.Equal(t, types.InvariantActionLog, app.CrisisKeeper.GetInvariantAction(ctx, "bank/nonnegative-outstanding"))
+
+	invalid := [][]types.InvariantAction{
+		{types.NewInvariantAction("bank", types.InvariantActionLog)},
+		{types.NewInvariantAction("bank/supply", "ignore")},
+		{
+			types.NewInvariantAction("bank/supply", types.InvariantActionLog),
+			types.NewInvariantAction("bank/supply", types.InvariantActionEvent),
+		},
+	}
+	for _, actions := range invalid {
+		gs := types.NewGenesisState(app.CrisisKeeper.GetConstantFee(ctx), actions)
+		require.Error(t, types.ValidateGenesis(gs), actions)
+	}
+}
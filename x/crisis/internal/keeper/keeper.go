@@ -6,6 +6,7 @@ import (
 
 	"github.com/tendermint/tendermint/libs/log"
 
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/crisis/internal/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
@@ -13,9 +14,12 @@ import (
 
 // Keeper - crisis keeper
 type Keeper struct {
+	storeKey       sdk.StoreKey
+	cdc            *codec.Codec
 	routes         []types.InvarRoute
 	paramSpace     params.Subspace
 	invCheckPeriod uint
+	invCheckBudget time.Duration
 
 	supplyKeeper types.SupplyKeeper
 
@@ -23,12 +27,18 @@ type Keeper struct {
 }
 
 // NewKeeper creates a new Keeper object
+//
+// NOTE: The progress of the invariant checks and their results depend on the
+// node's invariant check period and budget. The store must therefore be kept
+// out of the app hash, e.g. by mounting it as a StoreTypeDB store.
 func NewKeeper(
-	paramSpace params.Subspace, invCheckPeriod uint, supplyKeeper types.SupplyKeeper,
-	feeCollectorName string,
+	cdc *codec.Codec, key sdk.StoreKey, paramSpace params.Subspace, invCheckPeriod uint,
+	supplyKeeper types.SupplyKeeper, feeCollectorName string,
 ) Keeper {
 
 	return Keeper{
+		storeKey:         key,
+		cdc:              cdc,
 		routes:           make([]types.InvarRoute, 0),
 		paramSpace:       paramSpace.WithKeyTable(types.ParamKeyTable()),
 		invCheckPeriod:   invCheckPeriod,
@@ -72,11 +82,7 @@ func (k Keeper) AssertInvariants(ctx sdk.Context) {
 
 	for _, ir := range invarRoutes {
 		if res, stop := ir.Invar(ctx); stop {
-			// TODO: Include app name as part of context to allow for this to be
-			// variable.
-			panic(fmt.Errorf("invariant broken: %s\n"+
-				"\tCRITICAL please submit the following transaction:\n"+
-				"\t\t tx crisis invariant-broken %s %s", res, ir.ModuleName, ir.Route))
+			panic(invariantBrokenError(res, ir))
 		}
 	}
 
@@ -89,6 +95,13 @@ func (k Keeper) AssertInvariants(ctx sdk.Context) {
 // InvCheckPeriod returns the invariant checks period.
 func (k Keeper) InvCheckPeriod() uint { return k.invCheckPeriod }
 
+// SetInvCheckBudget sets the time spent checking invariants per block. A zero
+// budget runs all invariants in the block the check period elapses.
+func (k *Keeper) SetInvCheckBudget(budget time.Duration) { k.invCheckBudget = budget }
+
+// InvCheckBudget returns the time spent checking invariants per block.
+func (k Keeper) InvCheckBudget() time.Duration { return k.invCheckBudget }
+
 // SendCoinsFromAccountToFeeCollector transfers amt to the fee collector account.
 func (k Keeper) SendCoinsFromAccountToFeeCollector(ctx sdk.Context, senderAddr sdk.AccAddress, amt sdk.Coins) error {
 	return k.supplyKeeper.SendCoinsFromAccountToModule(ctx, senderAddr, k.feeCollectorName, amt)
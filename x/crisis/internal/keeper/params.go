@@ -15,3 +15,27 @@ func (k Keeper) GetConstantFee(ctx sdk.Context) (constantFee sdk.Coin) {
 func (k Keeper) SetConstantFee(ctx sdk.Context, constantFee sdk.Coin) {
 	k.paramSpace.Set(ctx, types.ParamStoreKeyConstantFee, constantFee)
 }
+
+// GetInvariantActions returns the actions configured for broken invariants
+func (k Keeper) GetInvariantActions(ctx sdk.Context) (actions []types.InvariantAction) {
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyInvariantActions, &actions)
+	return
+}
+
+// SetInvariantActions sets the actions configured for broken invariants
+func (k Keeper) SetInvariantActions(ctx sdk.Context, actions []types.InvariantAction) {
+	k.paramSpace.Set(ctx, types.ParamStoreKeyInvariantActions, actions)
+}
+
+// GetInvariantAction returns the action taken when the invariant registered
+// under the full route is broken. Invariants halt the node unless configured
+// otherwise.
+func (k Keeper) GetInvariantAction(ctx sdk.Context, route string) string {
+	for _, ia := range k.GetInvariantActions(ctx) {
+		if ia.Route == route {
+			return ia.Action
+		}
+	}
+
+	return types.InvariantActionHalt
+}
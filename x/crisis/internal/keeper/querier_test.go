@@ -0,0 +1,50 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/crisis/internal/keeper"
+	"github.com/cosmos/cosmos-sdk/x/crisis/internal/types"
+)
+
+func TestQueryInvariantResults(t *testing.T) {
+	app := createTestApp()
+	ctx := app.NewContext(false, abci.Header{Height: 10})
+	k := newTestKeeper(app)
+	k.RegisterRoute("testModule", "testRoute", func(sdk.Context) (string, bool) { return "all good", false })
+	querier := keeper.NewQuerier(k)
+
+	bz, err := querier(ctx, []string{types.QueryInvariantResults}, abci.RequestQuery{})
+	require.NoError(t, err)
+	var results []types.InvariantResult
+	require.NoError(t, types.ModuleCdc.UnmarshalJSON(bz, &results))
+	require.Empty(t, results)
+
+	params := types.ModuleCdc.MustMarshalJSON(types.NewQueryInvariantParams("testModule", "testRoute"))
+	_, err = querier(ctx, []string{types.QueryInvariantResult}, abci.RequestQuery{Data: params})
+	require.Error(t, err)
+
+	k.StartInvariantsPass(ctx)
+	k.CheckInvariants(ctx)
+
+	bz, err = querier(ctx, []string{types.QueryInvariantResults}, abci.RequestQuery{})
+	require.NoError(t, err)
+	require.NoError(t, types.ModuleCdc.UnmarshalJSON(bz, &results))
+	require.Len(t, results, 1)
+
+	bz, err = querier(ctx, []string{types.QueryInvariantResult}, abci.RequestQuery{Data: params})
+	require.NoError(t, err)
+	var result types.InvariantResult
+	require.NoError(t, types.ModuleCdc.UnmarshalJSON(bz, &result))
+	require.Equal(t, results[0], result)
+	require.Equal(t, "testModule/testRoute", result.Route)
+	require.Equal(t, int64(10), result.Height)
+	require.Equal(t, "all good", result.Message)
+
+	_, err = querier(ctx, []string{"other"}, abci.RequestQuery{})
+	require.Error(t, err)
+}
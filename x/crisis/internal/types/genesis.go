@@ -8,20 +8,23 @@ import (
 
 // GenesisState - crisis genesis state
 type GenesisState struct {
-	ConstantFee sdk.Coin `json:"constant_fee" yaml:"constant_fee"`
+	ConstantFee      sdk.Coin          `json:"constant_fee" yaml:"constant_fee"`
+	InvariantActions []InvariantAction `json:"invariant_actions" yaml:"invariant_actions"`
 }
 
 // NewGenesisState creates a new GenesisState object
-func NewGenesisState(constantFee sdk.Coin) GenesisState {
+func NewGenesisState(constantFee sdk.Coin, invariantActions []InvariantAction) GenesisState {
 	return GenesisState{
-		ConstantFee: constantFee,
+		ConstantFee:      constantFee,
+		InvariantActions: invariantActions,
 	}
 }
 
 // DefaultGenesisState creates a default GenesisState object
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
-		ConstantFee: sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1000)),
+		ConstantFee:      sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1000)),
+		InvariantActions: []InvariantAction{},
 	}
 }
 
@@ -30,5 +33,5 @@ func ValidateGenesis(data GenesisState) error {
 	if !data.ConstantFee.IsPositive() {
 		return fmt.Errorf("constant fee must be positive: %s", data.ConstantFee)
 	}
-	return nil
+	return validateInvariantActions(data.InvariantActions)
 }
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Actions taken when an invariant is found broken by the invariant runner
+const (
+	// InvariantActionHalt halts the node, the default for all invariants
+	InvariantActionHalt = "halt"
+	// InvariantActionLog logs the broken invariant
+	InvariantActionLog = "log"
+	// InvariantActionEvent logs the broken invariant and emits an event
+	InvariantActionEvent = "event"
+)
+
+// InvariantAction overrides the action taken when the invariant registered
+// under the full route (module-name/route) is found broken
+type InvariantAction struct {
+	Route  string `json:"route" yaml:"route"`
+	Action string `json:"action" yaml:"action"`
+}
+
+// NewInvariantAction creates a new InvariantAction object
+func NewInvariantAction(route, action string) InvariantAction {
+	return InvariantAction{
+		Route:  route,
+		Action: action,
+	}
+}
+
+// Validate performs a stateless validation of the invariant action
+func (ia InvariantAction) Validate() error {
+	if strings.Count(ia.Route, "/") != 1 || strings.HasPrefix(ia.Route, "/") || strings.HasSuffix(ia.Route, "/") {
+		return fmt.Errorf("invalid invariant route %q, expected module-name/route", ia.Route)
+	}
+
+	switch ia.Action {
+	case InvariantActionHalt, InvariantActionLog, InvariantActionEvent:
+		return nil
+	default:
+		return fmt.Errorf("invalid action %q for invariant %s", ia.Action, ia.Route)
+	}
+}
+
+// InvariantResult is the outcome of the last run of an invariant
+type InvariantResult struct {
+	Route    string        `json:"route" yaml:"route"`
+	Height   int64         `json:"height" yaml:"height"`
+	Broken   bool          `json:"broken" yaml:"broken"`
+	Message  string        `json:"message" yaml:"message"`
+	Duration time.Duration `json:"duration" yaml:"duration"`
+	Action   string        `json:"action,omitempty" yaml:"action"` // action taken if broken
+}
+
+// NewInvariantResult creates a new InvariantResult object
+func NewInvariantResult(route string, height int64, broken bool, msg string, duration time.Duration) InvariantResult {
+	return InvariantResult{
+		Route:    route,
+		Height:   height,
+		Broken:   broken,
+		Message:  msg,
+		Duration: duration,
+	}
+}
+
+func (ir InvariantResult) String() string {
+	status := "ok"
+	if ir.Broken {
+		status = fmt.Sprintf("BROKEN (%s)", ir.Action)
+	}
+
+	return fmt.Sprintf(`Invariant %s:
+  Height:   %d
+  Status:   %s
+  Duration: %s
+  Message:  %s`, ir.Route, ir.Height, status, ir.Duration, strings.TrimSpace(ir.Message))
+}
@@ -3,4 +3,27 @@ package types
 const (
 	// module name
 	ModuleName = "crisis"
+
+	// StoreKey is the store key string for crisis
+	StoreKey = ModuleName
+
+	// QuerierRoute is the querier route for the crisis module
+	QuerierRoute = ModuleName
 )
+
+// Keys for crisis store
+// Items are stored with the following key: values
+//
+// - 0x01: invariantsPass
+//
+// - 0x02<route_Bytes>: InvariantResult
+var (
+	InvariantsPassKey         = []byte{0x01} // key for the pending invariants pass
+	InvariantResultsKeyPrefix = []byte{0x02} // prefix for each key to an invariant result
+)
+
+// GetInvariantResultKey returns the key of the result of the invariant
+// registered under the full route
+func GetInvariantResultKey(route string) []byte {
+	return append(InvariantResultsKeyPrefix, []byte(route)...)
+}
@@ -15,12 +15,15 @@ const (
 var (
 	// key for constant fee parameter
 	ParamStoreKeyConstantFee = []byte("ConstantFee")
+	// key for the actions taken on broken invariants
+	ParamStoreKeyInvariantActions = []byte("InvariantActions")
 )
 
 // type declaration for parameters
 func ParamKeyTable() params.KeyTable {
 	return params.NewKeyTable(
 		params.NewParamSetPair(ParamStoreKeyConstantFee, sdk.Coin{}, validateConstantFee),
+		params.NewParamSetPair(ParamStoreKeyInvariantActions, []InvariantAction{}, validateInvariantActions),
 	)
 }
 
@@ -36,3 +39,23 @@ func validateConstantFee(i interface{}) error {
 
 	return nil
 }
+
+func validateInvariantActions(i interface{}) error {
+	v, ok := i.([]InvariantAction)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	routes := make(map[string]bool, len(v))
+	for _, ia := range v {
+		if err := ia.Validate(); err != nil {
+			return err
+		}
+		if routes[ia.Route] {
+			return fmt.Errorf("duplicate action for invariant %s", ia.Route)
+		}
+		routes[ia.Route] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package types
+
+// query endpoints supported by the crisis Querier
+const (
+	QueryInvariantResults = "invariants"
+	QueryInvariantResult  = "invariant"
+)
+
+// QueryInvariantParams defines the params for querying the last result of an
+// invariant
+type QueryInvariantParams struct {
+	Route string `json:"route" yaml:"route"` // full route: module-name/route
+}
+
+// NewQueryInvariantParams creates a new QueryInvariantParams object
+func NewQueryInvariantParams(moduleName, route string) QueryInvariantParams {
+	return QueryInvariantParams{
+		Route: moduleName + "/" + route,
+	}
+}
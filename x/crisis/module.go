@@ -6,6 +6,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 
@@ -13,7 +14,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module"
-	//"github.com/cosmos/cosmos-sdk/x/crisis/client/cli"
+	"github.com/cosmos/cosmos-sdk/x/crisis/client/cli"
 	"github.com/cosmos/cosmos-sdk/x/crisis/internal/keeper"
 	"github.com/cosmos/cosmos-sdk/x/crisis/internal/types"
 )
@@ -61,8 +62,22 @@ func (AppModuleBasic) GetTxCmd(cdc *codec.Codec) *cobra.Command {
 	return nil
 }
 
-// GetQueryCmd returns no root query command for the crisis module.
-func (AppModuleBasic) GetQueryCmd(_ *codec.Codec) *cobra.Command { return nil }
+// GetQueryCmd returns the root query command for the crisis module.
+func (AppModuleBasic) GetQueryCmd(cdc *codec.Codec) *cobra.Command {
+	return cli.GetQueryCmd(cdc)
+}
+
+// FlagInvCheckBudget is the start flag for the time spent checking invariants
+// per block
+const FlagInvCheckBudget = "inv-check-budget"
+
+// AddModuleInitFlags adds the crisis module flags to the start command of the
+// daemon.
+func AddModuleInitFlags(startCmd *cobra.Command) {
+	startCmd.Flags().Duration(FlagInvCheckBudget, 0,
+		"Time spent checking invariants per block, spreading them across blocks (0 runs all at once)")
+	viper.BindPFlag(FlagInvCheckBudget, startCmd.Flags().Lookup(FlagInvCheckBudget))
+}
 
 //____________________________________________________________________________
 
@@ -102,11 +117,14 @@ func (am AppModule) NewHandler() sdk.Handler {
 	return NewHandler(*am.keeper)
 }
 
-// QuerierRoute returns no querier route.
-func (AppModule) QuerierRoute() string { return "" }
+// QuerierRoute returns the crisis module's querier route name.
+func (AppModule) QuerierRoute() string { return QuerierRoute }
 
-// NewQuerierHandler returns no sdk.Querier.
-func (AppModule) NewQuerierHandler() sdk.Querier { return nil }
+// NewQuerierHandler returns the crisis module sdk.Querier, serving the
+// results of the invariants run by the node.
+func (am AppModule) NewQuerierHandler() sdk.Querier {
+	return NewQuerier(*am.keeper)
+}
 
 // InitGenesis performs genesis initialization for the crisis module. It returns
 // no validator updates.
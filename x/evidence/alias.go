@@ -21,6 +21,20 @@ const (
 	AttributeValueCategory   = types.AttributeValueCategory
 	AttributeKeyEvidenceHash = types.AttributeKeyEvidenceHash
 	DefaultMaxEvidenceAge    = types.DefaultMaxEvidenceAge
+
+	RouteEquivocation                     = types.RouteEquivocation
+	TypeEquivocation                      = types.TypeEquivocation
+	RouteConflictingHeaders               = types.RouteConflictingHeaders
+	TypeConflictingHeaders                = types.TypeConflictingHeaders
+	EventTypeConflictingHeaders           = types.EventTypeConflictingHeaders
+	AttributeKeyConsAddress               = types.AttributeKeyConsAddress
+	AttributeKeyHeight                    = types.AttributeKeyHeight
+	AttributeKeyPower                     = types.AttributeKeyPower
+	AttributeKeyFraction                  = types.AttributeKeyFraction
+	AttributeKeyJailedUntil               = types.AttributeKeyJailedUntil
+	AttributeKeyTombstoned                = types.AttributeKeyTombstoned
+	DefaultTombstoneConflictingHeaders    = types.DefaultTombstoneConflictingHeaders
+	DefaultConflictingHeadersJailDuration = types.DefaultConflictingHeadersJailDuration
 )
 
 var (
@@ -40,6 +54,25 @@ var (
 	KeyMaxEvidenceAge            = types.KeyMaxEvidenceAge
 	DoubleSignJailEndTime        = types.DoubleSignJailEndTime
 	ParamKeyTable                = types.ParamKeyTable
+	NewParams                    = types.NewParams
+	DefaultParams                = types.DefaultParams
+	NewConflictingHeaders        = types.NewConflictingHeaders
+
+	KeySlashFractionConflictingHeaders     = types.KeySlashFractionConflictingHeaders
+	KeyTombstoneConflictingHeaders         = types.KeyTombstoneConflictingHeaders
+	KeyConflictingHeadersJailDuration      = types.KeyConflictingHeadersJailDuration
+	DefaultSlashFractionConflictingHeaders = types.DefaultSlashFractionConflictingHeaders
+
+	ErrNoEvidenceHandlerExists = types.ErrNoEvidenceHandlerExists
+	ErrInvalidEvidence         = types.ErrInvalidEvidence
+	ErrNoEvidenceExists        = types.ErrNoEvidenceExists
+	ErrEvidenceExists          = types.ErrEvidenceExists
+	ErrNoHistoricalInfo        = types.ErrNoHistoricalInfo
+	ErrUnknownValidator        = types.ErrUnknownValidator
+	ErrEvidenceTooOld          = types.ErrEvidenceTooOld
+	ErrValidatorTombstoned     = types.ErrValidatorTombstoned
+	ErrInvalidChainID          = types.ErrInvalidChainID
+	ErrHistoricalInfoDisabled  = types.ErrHistoricalInfoDisabled
 )
 
 type (
@@ -50,4 +83,7 @@ type (
 	Handler           = types.Handler
 	Router            = types.Router
 	Equivocation      = types.Equivocation
+	Params            = types.Params
+
+	ConflictingHeaders = types.ConflictingHeaders
 )
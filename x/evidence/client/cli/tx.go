@@ -1,12 +1,23 @@
 package cli
 
 import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
 	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
 	"github.com/cosmos/cosmos-sdk/x/evidence/internal/types"
 
 	"github.com/spf13/cobra"
+	tmtypes "github.com/tendermint/tendermint/types"
 )
 
 // GetTxCmd returns a CLI command that has all the native evidence module tx
@@ -24,11 +35,12 @@ func GetTxCmd(storeKey string, cdc *codec.Codec, childCmds []*cobra.Command) *co
 	}
 
 	submitEvidenceCmd := SubmitEvidenceCmd(cdc)
+	submitEvidenceCmd.AddCommand(flags.PostCommands(GetCmdSubmitConflictingHeaders(cdc))...)
 	for _, childCmd := range childCmds {
 		submitEvidenceCmd.AddCommand(flags.PostCommands(childCmd)[0])
 	}
 
-	// TODO: Add tx commands.
+	cmd.AddCommand(submitEvidenceCmd)
 
 	return cmd
 }
@@ -38,9 +50,79 @@ func GetTxCmd(storeKey string, cdc *codec.Codec, childCmds []*cobra.Command) *co
 // under this command.
 func SubmitEvidenceCmd(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "submit",
-		Short: "Submit arbitrary evidence of misbehavior",
+		Use:                        "submit",
+		Short:                      "Submit arbitrary evidence of misbehavior",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
 	}
 
 	return cmd
 }
+
+// GetCmdSubmitConflictingHeaders implements the command to submit evidence of a
+// light client attack, i.e. a validator signing two different headers for the
+// same height.
+func GetCmdSubmitConflictingHeaders(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "conflicting-headers [consensus-address] [signed-header-file] [signed-header-file]",
+		Args:  cobra.ExactArgs(3),
+		Short: "Submit evidence of a validator signing conflicting headers",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit evidence that a validator signed two different headers for the same
+height. Each file must contain a signed header in JSON, as returned in the
+"signed_header" field of the Tendermint RPC commit endpoint. The validator
+signatures are verified against the historical validator set of that height.
+
+Example:
+$ %s tx evidence submit conflicting-headers cosmosvalcons1... header1.json header2.json --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			consAddr, err := sdk.ConsAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			h1, err := parseSignedHeader(cdc, args[1])
+			if err != nil {
+				return err
+			}
+
+			h2, err := parseSignedHeader(cdc, args[2])
+			if err != nil {
+				return err
+			}
+
+			evidence := types.NewConflictingHeaders(consAddr, h1, h2)
+			msg := types.NewMsgSubmitEvidence(evidence, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// parseSignedHeader reads and parses a Tendermint signed header from a file.
+func parseSignedHeader(cdc *codec.Codec, file string) (tmtypes.SignedHeader, error) {
+	var header tmtypes.SignedHeader
+
+	contents, err := ioutil.ReadFile(file)
+	if err != nil {
+		return header, err
+	}
+
+	if err := cdc.UnmarshalJSON(contents, &header); err != nil {
+		return header, fmt.Errorf("failed to parse signed header %s: %w", file, err)
+	}
+
+	return header, nil
+}
@@ -1,11 +1,64 @@
 package rest
 
 import (
+	"fmt"
+	"net/http"
+
 	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/evidence/internal/types"
 
 	"github.com/gorilla/mux"
+	tmtypes "github.com/tendermint/tendermint/types"
 )
 
+// ConflictingHeadersReq defines the request body for submitting light client
+// attack evidence.
+type ConflictingHeadersReq struct {
+	BaseReq          rest.BaseReq         `json:"base_req" yaml:"base_req"`
+	ConsensusAddress sdk.ConsAddress      `json:"consensus_address" yaml:"consensus_address"`
+	H1               tmtypes.SignedHeader `json:"h1" yaml:"h1"`
+	H2               tmtypes.SignedHeader `json:"h2" yaml:"h2"`
+}
+
 func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router, handlers []EvidenceRESTHandler) {
-	// TODO: Register tx handlers.
+	r.HandleFunc(
+		"/evidence/conflicting_headers",
+		submitConflictingHeadersHandlerFn(cliCtx),
+	).Methods(MethodPost)
+
+	for _, h := range handlers {
+		r.HandleFunc(fmt.Sprintf("/evidence/%s", h.SubRoute), h.Handler).Methods(MethodPost)
+	}
+}
+
+func submitConflictingHeadersHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ConflictingHeadersReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		evidence := types.NewConflictingHeaders(req.ConsensusAddress, req.H1, req.H2)
+		msg := types.NewMsgSubmitEvidence(evidence, fromAddr)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
 }
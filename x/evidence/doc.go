@@ -13,6 +13,13 @@ Handler for a given Evidence type can perform any arbitrary state transitions
 such as slashing, jailing, and tombstoning. This provides developers with great
 flexibility in designing evidence handling.
 
+Besides Equivocation evidence received from Tendermint, the module ships the
+ConflictingHeaders evidence type for light client attacks. It is submitted by
+users and its signatures are verified against the validator set stored by
+x/staking as HistoricalInfo. Applications enable it by registering the
+NewConflictingHeadersHandler route and keeping historical info, with a positive
+x/staking HistoricalEntries parameter: otherwise the evidence is rejected.
+
 A full setup of the evidence module may look something as follows:
 
 	ModuleBasics = module.NewBasicManager(
@@ -29,6 +36,7 @@ A full setup of the evidence module may look something as follows:
 
 	// Second, create the evidence Handler and register all desired routes.
 	evidenceRouter := evidence.NewRouter().
+	  AddRoute(evidence.RouteConflictingHeaders, evidence.NewConflictingHeadersHandler(*evidenceKeeper)).
 	  AddRoute(evidenceRoute, evidenceHandler).
 	  AddRoute(..., ...)
 
@@ -36,7 +44,7 @@ A full setup of the evidence module may look something as follows:
 
 	app.mm = module.NewManager(
 	  // ...
-	  evidence.NewAppModule(evidenceKeeper),
+	  evidence.NewAppModule(evidenceKeeper, app.AccountKeeper, app.StakingKeeper),
 	)
 
 	// Remaining application bootstrapping...
@@ -19,6 +19,11 @@ func InitGenesis(ctx sdk.Context, k Keeper, gs GenesisState) {
 		}
 
 		k.SetEvidence(ctx, e)
+
+		// restore the markers of the punished conflicting headers infractions
+		if ch, ok := e.(ConflictingHeaders); ok {
+			k.SetInfractionHandled(ctx, ch.GetConsensusAddress(), ch.GetHeight())
+		}
 	}
 
 	k.SetParams(ctx, gs.Params)
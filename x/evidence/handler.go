@@ -3,6 +3,7 @@ package evidence
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/evidence/exported"
 )
 
 func NewHandler(k Keeper) sdk.Handler {
@@ -37,3 +38,17 @@ func handleMsgSubmitEvidence(ctx sdk.Context, k Keeper, msg MsgSubmitEvidence) (
 		Events: ctx.EventManager().Events(),
 	}, nil
 }
+
+// NewConflictingHeadersHandler returns an evidence Handler for light client
+// attack evidence of type ConflictingHeaders.
+func NewConflictingHeadersHandler(k Keeper) Handler {
+	return func(ctx sdk.Context, evidence exported.Evidence) error {
+		switch e := evidence.(type) {
+		case ConflictingHeaders:
+			return k.HandleConflictingHeaders(ctx, e)
+
+		default:
+			return sdkerrors.Wrapf(ErrInvalidEvidence, "unrecognized %s evidence type: %T", ModuleName, evidence)
+		}
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
 	"github.com/cosmos/cosmos-sdk/x/evidence/internal/types"
+
+	tmtypes "github.com/tendermint/tendermint/types"
 )
 
 // HandleDoubleSign implements an equivocation evidence handler. Assuming the
@@ -107,3 +110,136 @@ func (k Keeper) HandleDoubleSign(ctx sdk.Context, evidence types.Equivocation) {
 	k.slashingKeeper.JailUntil(ctx, consAddr, types.DoubleSignJailEndTime)
 	k.slashingKeeper.Tombstone(ctx, consAddr)
 }
+
+// HandleConflictingHeaders implements a light client attack evidence handler.
+// The accused validator's signatures on both headers are verified against the
+// validator set recorded by x/staking in the HistoricalInfo for the evidence
+// height, which must also have committed both headers with more than 2/3 of
+// its voting power. The evidence is thus only accepted when x/staking keeps
+// historical info, i.e. its HistoricalEntries parameter is positive.
+//
+// Assuming the evidence is valid, the validator is slashed by the
+// SlashFractionConflictingHeaders parameter and jailed. Depending on the
+// TombstoneConflictingHeaders parameter it is either tombstoned or jailed for
+// ConflictingHeadersJailDuration. The infraction is recorded by consensus
+// address and height, so that the validator is punished once for a height even
+// if the headers are swapped or re-encoded, which changes the evidence hash.
+//
+// Unlike HandleDoubleSign, the evidence is submitted by users and an error is
+// returned if:
+// - x/staking keeps no historical info
+// - the headers belong to another chain
+// - the evidence is too old
+// - no historical info exists for the evidence height
+// - the validator is not part of the historical validator set
+// - the validator signatures cannot be verified
+// - the headers were not committed by the historical validator set
+// - the validator is unbonded, does not exist or has no signing info
+// - the validator is already tombstoned
+// - the validator was already punished for an infraction at the same height
+func (k Keeper) HandleConflictingHeaders(ctx sdk.Context, evidence types.ConflictingHeaders) error {
+	logger := k.Logger(ctx)
+	consAddr := evidence.GetConsensusAddress()
+	infractionHeight := evidence.GetHeight()
+
+	// the evidence can't be verified without the historical validator sets
+	if k.stakingKeeper.HistoricalEntries(ctx) == 0 {
+		return sdkerrors.Wrap(types.ErrHistoricalInfoDisabled, "conflicting headers evidence is not accepted")
+	}
+
+	if chainID := evidence.GetChainID(); chainID != ctx.ChainID() {
+		return sdkerrors.Wrapf(types.ErrInvalidChainID, "got %s, expected %s", chainID, ctx.ChainID())
+	}
+
+	// reject evidence if the headers are too old
+	age := ctx.BlockHeader().Time.Sub(evidence.GetTime())
+	if age > k.MaxEvidenceAge(ctx) {
+		return sdkerrors.Wrapf(
+			types.ErrEvidenceTooOld, "age of %s past max age of %s", age, k.MaxEvidenceAge(ctx),
+		)
+	}
+
+	histInfo, ok := k.stakingKeeper.GetHistoricalInfo(ctx, infractionHeight)
+	if !ok {
+		return sdkerrors.Wrapf(types.ErrNoHistoricalInfo, "height %d", infractionHeight)
+	}
+
+	var power int64
+	found := false
+	tmVals := make([]*tmtypes.Validator, 0, len(histInfo.ValSet))
+	for _, val := range histInfo.ValSet {
+		if val.ConsensusPower() > 0 {
+			tmVals = append(tmVals, tmtypes.NewValidator(val.GetConsPubKey(), val.ConsensusPower()))
+		}
+		if found || !val.GetConsAddr().Equals(consAddr) {
+			continue
+		}
+		if err := evidence.VerifySignatures(val.GetConsPubKey()); err != nil {
+			return sdkerrors.Wrap(types.ErrInvalidEvidence, err.Error())
+		}
+
+		power = val.ConsensusPower()
+		found = true
+	}
+	if !found {
+		return sdkerrors.Wrapf(types.ErrUnknownValidator, "%s at height %d", consAddr, infractionHeight)
+	}
+
+	// both headers must have been committed by the validator set of the height
+	if err := evidence.VerifyCommits(tmtypes.NewValidatorSet(tmVals)); err != nil {
+		return sdkerrors.Wrap(types.ErrInvalidEvidence, err.Error())
+	}
+
+	validator := k.stakingKeeper.ValidatorByConsAddr(ctx, consAddr)
+	if validator == nil || validator.IsUnbonded() {
+		return sdkerrors.Wrapf(types.ErrUnknownValidator, "%s is unbonded or does not exist", consAddr)
+	}
+	if !k.slashingKeeper.HasValidatorSigningInfo(ctx, consAddr) {
+		return sdkerrors.Wrapf(types.ErrUnknownValidator, "no signing info for %s", consAddr)
+	}
+	if k.slashingKeeper.IsTombstoned(ctx, consAddr) {
+		return sdkerrors.Wrap(types.ErrValidatorTombstoned, consAddr.String())
+	}
+	if k.IsInfractionHandled(ctx, consAddr, infractionHeight) {
+		return sdkerrors.Wrapf(types.ErrInfractionHandled, "%s at height %d", consAddr, infractionHeight)
+	}
+
+	logger.Info(
+		fmt.Sprintf("confirmed conflicting headers from %s at height %d, age of %d", consAddr, infractionHeight, age),
+	)
+
+	// Slash the stake distribution which signed the headers, see HandleDoubleSign.
+	distributionHeight := infractionHeight - sdk.ValidatorUpdateDelay
+	fraction := k.SlashFractionConflictingHeaders(ctx)
+	k.slashingKeeper.Slash(ctx, consAddr, fraction, power, distributionHeight)
+
+	if !validator.IsJailed() {
+		k.slashingKeeper.Jail(ctx, consAddr)
+	}
+
+	tombstone := k.TombstoneConflictingHeaders(ctx)
+	jailedUntil := ctx.BlockHeader().Time.Add(k.ConflictingHeadersJailDuration(ctx))
+	if tombstone {
+		jailedUntil = types.DoubleSignJailEndTime
+	}
+
+	k.slashingKeeper.JailUntil(ctx, consAddr, jailedUntil)
+	if tombstone {
+		k.slashingKeeper.Tombstone(ctx, consAddr)
+	}
+	k.SetInfractionHandled(ctx, consAddr, infractionHeight)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeConflictingHeaders,
+			sdk.NewAttribute(types.AttributeKeyConsAddress, consAddr.String()),
+			sdk.NewAttribute(types.AttributeKeyHeight, fmt.Sprintf("%d", infractionHeight)),
+			sdk.NewAttribute(types.AttributeKeyPower, fmt.Sprintf("%d", power)),
+			sdk.NewAttribute(types.AttributeKeyFraction, fraction.String()),
+			sdk.NewAttribute(types.AttributeKeyJailedUntil, jailedUntil.String()),
+			sdk.NewAttribute(types.AttributeKeyTombstoned, fmt.Sprintf("%t", tombstone)),
+		),
+	)
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/staking"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 )
 
 func newTestMsgCreateValidator(address sdk.ValAddress, pubKey crypto.PubKey, amt sdk.Int) staking.MsgCreateValidator {
@@ -118,3 +119,181 @@ func (suite *KeeperTestSuite) TestHandleDoubleSign_TooOld() {
 	suite.False(suite.app.StakingKeeper.Validator(ctx, operatorAddr).IsJailed())
 	suite.False(suite.app.SlashingKeeper.IsTombstoned(ctx, sdk.ConsAddress(val.Address())))
 }
+
+func (suite *KeeperTestSuite) setupConflictingHeaders(ctx sdk.Context, privKey crypto.PrivKey) sdk.ValAddress {
+	suite.populateValidators(ctx)
+
+	stakingParams := suite.app.StakingKeeper.GetParams(ctx)
+	stakingParams.HistoricalEntries = 10
+	suite.app.StakingKeeper.SetParams(ctx, stakingParams)
+
+	operatorAddr := valAddresses[0]
+	amt := sdk.TokensFromConsensusPower(100)
+	res, err := staking.NewHandler(suite.app.StakingKeeper)(ctx, newTestMsgCreateValidator(operatorAddr, privKey.PubKey(), amt))
+	suite.NoError(err)
+	suite.NotNil(res)
+
+	staking.EndBlocker(ctx, suite.app.StakingKeeper)
+	suite.app.StakingKeeper.TrackHistoricalInfo(ctx)
+	suite.app.SlashingKeeper.HandleValidatorSignature(ctx, privKey.PubKey().Address(), 100, true)
+
+	return operatorAddr
+}
+
+func (suite *KeeperTestSuite) TestHandleConflictingHeaders() {
+	ctx := suite.ctx.WithIsCheckTx(false).WithBlockHeight(1).WithChainID("test-chain").WithBlockTime(time.Unix(100, 0).UTC())
+	privKey := ed25519.GenPrivKey()
+	operatorAddr := suite.setupConflictingHeaders(ctx, privKey)
+	consAddr := sdk.ConsAddress(privKey.PubKey().Address())
+
+	evidence := types.NewConflictingHeaders(
+		consAddr,
+		types.NewTestSignedHeader(ctx.ChainID(), 1, ctx.BlockTime(), []byte("app_hash_1"), privKey),
+		types.NewTestSignedHeader(ctx.ChainID(), 1, ctx.BlockTime(), []byte("app_hash_2"), privKey),
+	)
+	oldTokens := suite.app.StakingKeeper.Validator(ctx, operatorAddr).GetTokens()
+
+	suite.NoError(suite.keeper.HandleConflictingHeaders(ctx, evidence))
+
+	// should be slashed, jailed and tombstoned
+	suite.True(suite.app.StakingKeeper.Validator(ctx, operatorAddr).IsJailed())
+	suite.True(suite.app.SlashingKeeper.IsTombstoned(ctx, consAddr))
+	suite.True(suite.app.StakingKeeper.Validator(ctx, operatorAddr).GetTokens().LT(oldTokens))
+
+	// the validator cannot be punished twice
+	suite.Error(suite.keeper.HandleConflictingHeaders(ctx, evidence))
+}
+
+func (suite *KeeperTestSuite) TestHandleConflictingHeaders_Jail() {
+	ctx := suite.ctx.WithIsCheckTx(false).WithBlockHeight(1).WithChainID("test-chain").WithBlockTime(time.Unix(100, 0).UTC())
+	privKey := ed25519.GenPrivKey()
+	operatorAddr := suite.setupConflictingHeaders(ctx, privKey)
+	consAddr := sdk.ConsAddress(privKey.PubKey().Address())
+
+	params := suite.keeper.GetParams(ctx)
+	params.TombstoneConflictingHeaders = false
+	suite.keeper.SetParams(ctx, params)
+
+	evidence := types.NewConflictingHeaders(
+		consAddr,
+		types.NewTestSignedHeader(ctx.ChainID(), 1, ctx.BlockTime(), []byte("app_hash_1"), privKey),
+		types.NewTestSignedHeader(ctx.ChainID(), 1, ctx.BlockTime(), []byte("app_hash_2"), privKey),
+	)
+	suite.NoError(suite.keeper.HandleConflictingHeaders(ctx, evidence))
+
+	suite.True(suite.app.StakingKeeper.Validator(ctx, operatorAddr).IsJailed())
+	suite.False(suite.app.SlashingKeeper.IsTombstoned(ctx, consAddr))
+
+	info, found := suite.app.SlashingKeeper.GetValidatorSigningInfo(ctx, consAddr)
+	suite.True(found)
+	suite.Equal(ctx.BlockTime().Add(params.ConflictingHeadersJailDuration), info.JailedUntil)
+}
+
+func (suite *KeeperTestSuite) TestHandleConflictingHeaders_SwappedHeaders() {
+	ctx := suite.ctx.WithIsCheckTx(false).WithBlockHeight(1).WithChainID("test-chain").WithBlockTime(time.Unix(100, 0).UTC())
+	privKey := ed25519.GenPrivKey()
+	operatorAddr := suite.setupConflictingHeaders(ctx, privKey)
+	consAddr := sdk.ConsAddress(privKey.PubKey().Address())
+
+	params := suite.keeper.GetParams(ctx)
+	params.TombstoneConflictingHeaders = false
+	suite.keeper.SetParams(ctx, params)
+
+	h1 := types.NewTestSignedHeader(ctx.ChainID(), 1, ctx.BlockTime(), []byte("app_hash_1"), privKey)
+	h2 := types.NewTestSignedHeader(ctx.ChainID(), 1, ctx.BlockTime(), []byte("app_hash_2"), privKey)
+	evidence := types.NewConflictingHeaders(consAddr, h1, h2)
+	swapped := types.NewConflictingHeaders(consAddr, h2, h1)
+	suite.NotEqual(evidence.Hash(), swapped.Hash())
+
+	suite.NoError(suite.keeper.SubmitEvidence(ctx, evidence))
+	slashedTokens := suite.app.StakingKeeper.Validator(ctx, operatorAddr).GetTokens()
+
+	// the same infraction submitted under another hash is rejected
+	suite.Error(suite.keeper.SubmitEvidence(ctx, swapped))
+	suite.Error(suite.keeper.SubmitEvidence(ctx, swapped))
+	err := suite.keeper.HandleConflictingHeaders(ctx, swapped)
+	suite.True(types.ErrInfractionHandled.Is(err), err)
+
+	suite.True(suite.app.StakingKeeper.Validator(ctx, operatorAddr).GetTokens().Equal(slashedTokens))
+	_, found := suite.keeper.GetEvidence(ctx, swapped.Hash())
+	suite.False(found)
+}
+
+func (suite *KeeperTestSuite) TestHandleConflictingHeaders_Invalid() {
+	ctx := suite.ctx.WithIsCheckTx(false).WithBlockHeight(1).WithChainID("test-chain").WithBlockTime(time.Unix(100, 0).UTC())
+	privKey := ed25519.GenPrivKey()
+	operatorAddr := suite.setupConflictingHeaders(ctx, privKey)
+	consAddr := sdk.ConsAddress(privKey.PubKey().Address())
+	otherKey := ed25519.GenPrivKey()
+
+	newEvidence := func(chainID string, height int64, blockTime time.Time, signer crypto.PrivKey) types.ConflictingHeaders {
+		return types.NewConflictingHeaders(
+			consAddr,
+			types.NewTestSignedHeader(chainID, height, blockTime, []byte("app_hash_1"), privKey),
+			types.NewTestSignedHeader(chainID, height, blockTime, []byte("app_hash_2"), signer),
+		)
+	}
+
+	testCases := []struct {
+		name     string
+		ctx      sdk.Context
+		evidence types.ConflictingHeaders
+	}{
+		{"wrong chain", ctx, newEvidence("other-chain", 1, ctx.BlockTime(), privKey)},
+		{"no historical info", ctx, newEvidence(ctx.ChainID(), 2, ctx.BlockTime(), privKey)},
+		{"not signed by validator", ctx, newEvidence(ctx.ChainID(), 1, ctx.BlockTime(), otherKey)},
+		{
+			"too old", ctx.WithBlockTime(ctx.BlockTime().Add(suite.keeper.MaxEvidenceAge(ctx) + 1)),
+			newEvidence(ctx.ChainID(), 1, ctx.BlockTime(), privKey),
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Error(suite.keeper.HandleConflictingHeaders(tc.ctx, tc.evidence), tc.name)
+		suite.False(suite.app.StakingKeeper.Validator(ctx, operatorAddr).IsJailed(), tc.name)
+	}
+}
+
+func (suite *KeeperTestSuite) TestHandleConflictingHeaders_NoQuorum() {
+	ctx := suite.ctx.WithIsCheckTx(false).WithBlockHeight(1).WithChainID("test-chain").WithBlockTime(time.Unix(100, 0).UTC())
+	privKey := ed25519.GenPrivKey()
+	operatorAddr := suite.setupConflictingHeaders(ctx, privKey)
+	consAddr := sdk.ConsAddress(privKey.PubKey().Address())
+
+	// a second validator with the same power doesn't sign the headers, which
+	// are then committed by half of the voting power only
+	amt := sdk.TokensFromConsensusPower(100)
+	_, err := staking.NewHandler(suite.app.StakingKeeper)(ctx, newTestMsgCreateValidator(valAddresses[1], ed25519.GenPrivKey().PubKey(), amt))
+	suite.NoError(err)
+	staking.EndBlocker(ctx, suite.app.StakingKeeper)
+	suite.app.StakingKeeper.TrackHistoricalInfo(ctx)
+
+	evidence := types.NewConflictingHeaders(
+		consAddr,
+		types.NewTestSignedHeader(ctx.ChainID(), 1, ctx.BlockTime(), []byte("app_hash_1"), privKey),
+		types.NewTestSignedHeader(ctx.ChainID(), 1, ctx.BlockTime(), []byte("app_hash_2"), privKey),
+	)
+	err = suite.keeper.HandleConflictingHeaders(ctx, evidence)
+	suite.True(types.ErrInvalidEvidence.Is(err), err)
+	suite.False(suite.app.StakingKeeper.Validator(ctx, operatorAddr).IsJailed())
+}
+
+func (suite *KeeperTestSuite) TestHandleConflictingHeaders_NoHistoricalEntries() {
+	ctx := suite.ctx.WithIsCheckTx(false).WithBlockHeight(1).WithChainID("test-chain").WithBlockTime(time.Unix(100, 0).UTC())
+	privKey := ed25519.GenPrivKey()
+	operatorAddr := suite.setupConflictingHeaders(ctx, privKey)
+	consAddr := sdk.ConsAddress(privKey.PubKey().Address())
+
+	stakingParams := suite.app.StakingKeeper.GetParams(ctx)
+	stakingParams.HistoricalEntries = 0
+	suite.app.StakingKeeper.SetParams(ctx, stakingParams)
+
+	evidence := types.NewConflictingHeaders(
+		consAddr,
+		types.NewTestSignedHeader(ctx.ChainID(), 1, ctx.BlockTime(), []byte("app_hash_1"), privKey),
+		types.NewTestSignedHeader(ctx.ChainID(), 1, ctx.BlockTime(), []byte("app_hash_2"), privKey),
+	)
+	err := suite.keeper.HandleConflictingHeaders(ctx, evidence)
+	suite.True(types.ErrHistoricalInfoDisabled.Is(err))
+	suite.False(suite.app.StakingKeeper.Validator(ctx, operatorAddr).IsJailed())
+}
@@ -146,6 +146,21 @@ func (k Keeper) IterateEvidence(ctx sdk.Context, cb func(exported.Evidence) bool
 	}
 }
 
+// SetInfractionHandled marks the infraction of the validator with the given
+// consensus address at the given height as punished.
+func (k Keeper) SetInfractionHandled(ctx sdk.Context, consAddr sdk.ConsAddress, height int64) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixHandledInfractions)
+	store.Set(types.HandledInfractionKey(consAddr, height), []byte{0x01})
+}
+
+// IsInfractionHandled returns true if the validator with the given consensus
+// address was already punished for an infraction at the given height, whatever
+// the evidence it was submitted with.
+func (k Keeper) IsInfractionHandled(ctx sdk.Context, consAddr sdk.ConsAddress, height int64) bool {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixHandledInfractions)
+	return store.Has(types.HandledInfractionKey(consAddr, height))
+}
+
 // GetAllEvidence returns all stored Evidence objects.
 func (k Keeper) GetAllEvidence(ctx sdk.Context) (evidence []exported.Evidence) {
 	k.IterateEvidence(ctx, func(e exported.Evidence) bool {
@@ -70,6 +70,7 @@ func (suite *KeeperTestSuite) SetupTest() {
 	)
 	router := evidence.NewRouter()
 	router = router.AddRoute(types.TestEvidenceRouteEquivocation, types.TestEquivocationHandler(*evidenceKeeper))
+	router = router.AddRoute(evidence.RouteConflictingHeaders, evidence.NewConflictingHeadersHandler(*evidenceKeeper))
 	evidenceKeeper.SetRouter(router)
 
 	suite.ctx = app.BaseApp.NewContext(checkTx, abci.Header{Height: 1})
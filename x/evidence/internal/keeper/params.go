@@ -13,9 +13,39 @@ func (k Keeper) MaxEvidenceAge(ctx sdk.Context) (res time.Duration) {
 	return
 }
 
-// GetParams returns the total set of evidence parameters.
-func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
-	k.paramSpace.GetParamSet(ctx, &params)
+// SlashFractionConflictingHeaders returns the fraction of stake slashed for
+// signing conflicting headers, or its default if it was never set.
+func (k Keeper) SlashFractionConflictingHeaders(ctx sdk.Context) sdk.Dec {
+	res := types.DefaultSlashFractionConflictingHeaders
+	k.paramSpace.GetIfExists(ctx, types.KeySlashFractionConflictingHeaders, &res)
+	return res
+}
+
+// TombstoneConflictingHeaders returns whether validators that signed
+// conflicting headers are tombstoned, or its default if it was never set.
+func (k Keeper) TombstoneConflictingHeaders(ctx sdk.Context) bool {
+	res := types.DefaultTombstoneConflictingHeaders
+	k.paramSpace.GetIfExists(ctx, types.KeyTombstoneConflictingHeaders, &res)
+	return res
+}
+
+// ConflictingHeadersJailDuration returns the jail duration for validators that
+// signed conflicting headers and are not tombstoned, or its default if it was
+// never set.
+func (k Keeper) ConflictingHeadersJailDuration(ctx sdk.Context) time.Duration {
+	res := types.DefaultConflictingHeadersJailDuration
+	k.paramSpace.GetIfExists(ctx, types.KeyConflictingHeadersJailDuration, &res)
+	return res
+}
+
+// GetParams returns the total set of evidence parameters. The parameters
+// missing from the store, as on chains started before they were introduced,
+// have their default values.
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	params := types.DefaultParams()
+	for _, pair := range params.ParamSetPairs() {
+		k.paramSpace.GetIfExists(ctx, pair.Key, pair.Value)
+	}
 	return params
 }
 
@@ -2,6 +2,7 @@ package keeper_test
 
 import (
 	"github.com/cosmos/cosmos-sdk/x/evidence/internal/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 func (suite *KeeperTestSuite) TestParams() {
@@ -9,3 +10,22 @@ func (suite *KeeperTestSuite) TestParams() {
 	suite.Equal(types.DefaultParams(), suite.keeper.GetParams(ctx))
 	suite.Equal(types.DefaultMaxEvidenceAge, suite.keeper.MaxEvidenceAge(ctx))
 }
+
+func (suite *KeeperTestSuite) TestParams_Missing() {
+	ctx := suite.ctx.WithIsCheckTx(false)
+
+	// chains started before the conflicting headers params lack them
+	store := ctx.KVStore(suite.app.GetKey(params.StoreKey))
+	for _, key := range [][]byte{
+		types.KeySlashFractionConflictingHeaders,
+		types.KeyTombstoneConflictingHeaders,
+		types.KeyConflictingHeadersJailDuration,
+	} {
+		store.Delete(append([]byte(types.DefaultParamspace+"/"), key...))
+	}
+
+	suite.Equal(types.DefaultParams(), suite.keeper.GetParams(ctx))
+	suite.Equal(types.DefaultSlashFractionConflictingHeaders, suite.keeper.SlashFractionConflictingHeaders(ctx))
+	suite.Equal(types.DefaultTombstoneConflictingHeaders, suite.keeper.TombstoneConflictingHeaders(ctx))
+	suite.Equal(types.DefaultConflictingHeadersJailDuration, suite.keeper.ConflictingHeadersJailDuration(ctx))
+}
@@ -91,5 +91,8 @@ func (suite *KeeperTestSuite) TestQueryParams() {
 	bz, err := suite.querier(ctx, []string{types.QueryParameters}, abci.RequestQuery{})
 	suite.Nil(err)
 	suite.NotNil(bz)
-	suite.Equal("{\n  \"max_evidence_age\": \"120000000000\"\n}", string(bz))
+	suite.Equal(
+		"{\n  \"max_evidence_age\": \"120000000000\",\n  \"slash_fraction_conflicting_headers\": \"0.050000000000000000\",\n  \"tombstone_conflicting_headers\": true,\n  \"conflicting_headers_jail_duration\": \"1209600000000000\"\n}",
+		string(bz),
+	)
 }
@@ -15,6 +15,7 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterInterface((*exported.Evidence)(nil), nil)
 	cdc.RegisterConcrete(MsgSubmitEvidence{}, "cosmos-sdk/MsgSubmitEvidence", nil)
 	cdc.RegisterConcrete(Equivocation{}, "cosmos-sdk/Equivocation", nil)
+	cdc.RegisterConcrete(ConflictingHeaders{}, "cosmos-sdk/ConflictingHeaders", nil)
 }
 
 // RegisterEvidenceTypeCodec registers an external concrete Evidence type defined
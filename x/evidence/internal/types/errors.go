@@ -11,4 +11,11 @@ var (
 	ErrInvalidEvidence         = sdkerrors.Register(ModuleName, 2, "invalid evidence")
 	ErrNoEvidenceExists        = sdkerrors.Register(ModuleName, 3, "evidence does not exist")
 	ErrEvidenceExists          = sdkerrors.Register(ModuleName, 4, "evidence already exists")
+	ErrNoHistoricalInfo        = sdkerrors.Register(ModuleName, 5, "no historical info found for evidence height")
+	ErrUnknownValidator        = sdkerrors.Register(ModuleName, 6, "validator not found in historical validator set")
+	ErrEvidenceTooOld          = sdkerrors.Register(ModuleName, 7, "evidence is older than the max evidence age")
+	ErrValidatorTombstoned     = sdkerrors.Register(ModuleName, 8, "validator is already tombstoned")
+	ErrInvalidChainID          = sdkerrors.Register(ModuleName, 9, "evidence belongs to another chain")
+	ErrHistoricalInfoDisabled  = sdkerrors.Register(ModuleName, 10, "x/staking keeps no historical info")
+	ErrInfractionHandled       = sdkerrors.Register(ModuleName, 11, "validator was already punished for an infraction at this height")
 )
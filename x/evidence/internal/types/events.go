@@ -2,8 +2,15 @@ package types
 
 // evidence module events
 const (
-	EventTypeSubmitEvidence = "submit_evidence"
+	EventTypeSubmitEvidence     = "submit_evidence"
+	EventTypeConflictingHeaders = "conflicting_headers"
 
 	AttributeValueCategory   = "evidence"
 	AttributeKeyEvidenceHash = "evidence_hash"
+	AttributeKeyConsAddress  = "consensus_address"
+	AttributeKeyHeight       = "height"
+	AttributeKeyPower        = "power"
+	AttributeKeyFraction     = "fraction"
+	AttributeKeyJailedUntil  = "jailed_until"
+	AttributeKeyTombstoned   = "tombstoned"
 )
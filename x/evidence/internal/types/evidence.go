@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"fmt"
 	"time"
 
@@ -8,18 +9,25 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/evidence/exported"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmtypes "github.com/tendermint/tendermint/types"
 	"gopkg.in/yaml.v2"
 )
 
 // Evidence type constants
 const (
-	RouteEquivocation = "equivocation"
-	TypeEquivocation  = "equivocation"
+	RouteEquivocation       = "equivocation"
+	TypeEquivocation        = "equivocation"
+	RouteConflictingHeaders = "conflictingheaders"
+	TypeConflictingHeaders  = "conflictingheaders"
 )
 
-var _ exported.Evidence = (*Equivocation)(nil)
+var (
+	_ exported.Evidence = (*Equivocation)(nil)
+	_ exported.Evidence = (*ConflictingHeaders)(nil)
+)
 
 // Equivocation implements the Evidence interface and defines evidence of double
 // signing misbehavior.
@@ -99,3 +107,169 @@ func ConvertDuplicateVoteEvidence(dupVote abci.Evidence) exported.Evidence {
 		Time:             dupVote.Time,
 	}
 }
+
+// ConflictingHeaders implements the Evidence interface and defines evidence of
+// a light client attack. It contains two different signed headers for the same
+// chain and height, both of which carry a commit signature of the accused
+// validator.
+type ConflictingHeaders struct {
+	ConsensusAddress sdk.ConsAddress      `json:"consensus_address" yaml:"consensus_address"`
+	H1               tmtypes.SignedHeader `json:"h1" yaml:"h1"`
+	H2               tmtypes.SignedHeader `json:"h2" yaml:"h2"`
+}
+
+// NewConflictingHeaders returns a new ConflictingHeaders evidence object.
+func NewConflictingHeaders(consAddr sdk.ConsAddress, h1, h2 tmtypes.SignedHeader) ConflictingHeaders {
+	return ConflictingHeaders{
+		ConsensusAddress: consAddr,
+		H1:               h1,
+		H2:               h2,
+	}
+}
+
+// Route returns the Evidence Handler route for a ConflictingHeaders type.
+func (e ConflictingHeaders) Route() string { return RouteConflictingHeaders }
+
+// Type returns the Evidence Handler type for a ConflictingHeaders type.
+func (e ConflictingHeaders) Type() string { return TypeConflictingHeaders }
+
+func (e ConflictingHeaders) String() string {
+	return fmt.Sprintf(`ConflictingHeaders:
+  Consensus Address: %s
+  Chain ID:          %s
+  Height:            %d
+  Header 1 Hash:     %s
+  Header 2 Hash:     %s`,
+		e.ConsensusAddress, e.GetChainID(), e.GetHeight(), signedHeaderHash(e.H1), signedHeaderHash(e.H2),
+	)
+}
+
+// Hash returns the hash of a ConflictingHeaders object.
+func (e ConflictingHeaders) Hash() tmbytes.HexBytes {
+	return tmhash.Sum(ModuleCdc.MustMarshalBinaryBare(e))
+}
+
+// ValidateBasic performs basic stateless validation checks on a
+// ConflictingHeaders object: both headers must be committed at the same height
+// and round. Note, the signatures themselves are verified by the evidence
+// handler against the historical validator set.
+func (e ConflictingHeaders) ValidateBasic() error {
+	if e.ConsensusAddress.Empty() {
+		return fmt.Errorf("invalid conflicting headers validator consensus address: %s", e.ConsensusAddress)
+	}
+	if e.H1.Header == nil || e.H2.Header == nil {
+		return fmt.Errorf("conflicting headers evidence is missing a header")
+	}
+
+	chainID := e.H1.ChainID
+	if err := e.H1.ValidateBasic(chainID); err != nil {
+		return fmt.Errorf("invalid first signed header: %w", err)
+	}
+	if err := e.H2.ValidateBasic(chainID); err != nil {
+		return fmt.Errorf("invalid second signed header: %w", err)
+	}
+	if e.H1.Height != e.H2.Height {
+		return fmt.Errorf("headers are for different heights: %d and %d", e.H1.Height, e.H2.Height)
+	}
+	if e.H1.Commit.Round != e.H2.Commit.Round {
+		return fmt.Errorf("headers are committed in different rounds: %d and %d", e.H1.Commit.Round, e.H2.Commit.Round)
+	}
+	if bytes.Equal(e.H1.Hash(), e.H2.Hash()) {
+		return fmt.Errorf("headers do not conflict: both have hash %s", e.H1.Hash())
+	}
+
+	return nil
+}
+
+// GetConsensusAddress returns the consensus address of the validator accused
+// of signing both headers.
+func (e ConflictingHeaders) GetConsensusAddress() sdk.ConsAddress {
+	return e.ConsensusAddress
+}
+
+// GetHeight returns the height of the conflicting headers.
+func (e ConflictingHeaders) GetHeight() int64 {
+	if e.H1.Header == nil {
+		return 0
+	}
+	return e.H1.Height
+}
+
+// GetTime returns the time of the first header.
+func (e ConflictingHeaders) GetTime() time.Time {
+	if e.H1.Header == nil {
+		return time.Time{}
+	}
+	return e.H1.Time
+}
+
+// GetChainID returns the chain ID of the conflicting headers.
+func (e ConflictingHeaders) GetChainID() string {
+	if e.H1.Header == nil {
+		return ""
+	}
+	return e.H1.ChainID
+}
+
+// GetValidatorPower is a no-op for the ConflictingHeaders type. The power is
+// looked up from the historical validator set by the handler.
+func (e ConflictingHeaders) GetValidatorPower() int64 { return 0 }
+
+// GetTotalPower is a no-op for the ConflictingHeaders type.
+func (e ConflictingHeaders) GetTotalPower() int64 { return 0 }
+
+// VerifySignatures verifies that the given public key, which must belong to
+// the accused validator, produced a valid commit signature for both headers.
+func (e ConflictingHeaders) VerifySignatures(pubKey crypto.PubKey) error {
+	if !bytes.Equal(pubKey.Address(), e.ConsensusAddress) {
+		return fmt.Errorf("public key does not match consensus address %s", e.ConsensusAddress)
+	}
+	if err := verifyCommitSignature(e.H1, pubKey); err != nil {
+		return fmt.Errorf("first signed header: %w", err)
+	}
+	if err := verifyCommitSignature(e.H2, pubKey); err != nil {
+		return fmt.Errorf("second signed header: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyCommits verifies that both headers were committed by more than 2/3 of
+// the voting power of the given validator set, which must be the one of the
+// evidence height.
+func (e ConflictingHeaders) VerifyCommits(valSet *tmtypes.ValidatorSet) error {
+	if err := valSet.VerifyCommit(e.H1.ChainID, e.H1.Commit.BlockID, e.H1.Height, e.H1.Commit); err != nil {
+		return fmt.Errorf("first signed header: %w", err)
+	}
+	if err := valSet.VerifyCommit(e.H2.ChainID, e.H2.Commit.BlockID, e.H2.Height, e.H2.Commit); err != nil {
+		return fmt.Errorf("second signed header: %w", err)
+	}
+
+	return nil
+}
+
+// signedHeaderHash returns the hash of a signed header or nil if the header is
+// missing.
+func signedHeaderHash(sh tmtypes.SignedHeader) tmbytes.HexBytes {
+	if sh.Header == nil {
+		return nil
+	}
+	return sh.Hash()
+}
+
+// verifyCommitSignature checks that the commit of a signed header contains a
+// valid precommit for the header's block from the owner of pubKey.
+func verifyCommitSignature(sh tmtypes.SignedHeader, pubKey crypto.PubKey) error {
+	for idx, sig := range sh.Commit.Signatures {
+		if !sig.ForBlock() || !bytes.Equal(sig.ValidatorAddress, pubKey.Address()) {
+			continue
+		}
+
+		if !pubKey.VerifyBytes(sh.Commit.VoteSignBytes(sh.ChainID, idx), sig.Signature) {
+			return fmt.Errorf("invalid commit signature from %s", sig.ValidatorAddress)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("no commit signature from %X", pubKey.Address())
+}
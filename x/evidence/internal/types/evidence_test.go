@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/evidence/internal/types"
@@ -54,3 +56,69 @@ func TestEquivocationValidateBasic(t *testing.T) {
 		})
 	}
 }
+
+func TestConflictingHeadersValidateBasic(t *testing.T) {
+	n, _ := time.Parse(time.RFC3339, "2006-01-02T15:04:05Z")
+	privKey := ed25519.GenPrivKey()
+	consAddr := sdk.ConsAddress(privKey.PubKey().Address())
+
+	h1 := types.NewTestSignedHeader("test-chain", 10, n, []byte("app_hash_1"), privKey)
+	h2 := types.NewTestSignedHeader("test-chain", 10, n, []byte("app_hash_2"), privKey)
+	h2Round1 := types.NewTestSignedHeader("test-chain", 10, n, []byte("app_hash_2"), privKey)
+	h2Round1.Commit.Round = 1
+
+	testCases := []struct {
+		name      string
+		e         types.ConflictingHeaders
+		expectErr bool
+	}{
+		{"valid", types.NewConflictingHeaders(consAddr, h1, h2), false},
+		{"invalid address", types.NewConflictingHeaders(nil, h1, h2), true},
+		{"missing header", types.NewConflictingHeaders(consAddr, h1, tmtypes.SignedHeader{}), true},
+		{"same header", types.NewConflictingHeaders(consAddr, h1, h1), true},
+		{
+			"different chains", types.NewConflictingHeaders(
+				consAddr, h1, types.NewTestSignedHeader("other-chain", 10, n, []byte("app_hash_2"), privKey),
+			), true,
+		},
+		{
+			"different heights", types.NewConflictingHeaders(
+				consAddr, h1, types.NewTestSignedHeader("test-chain", 11, n, []byte("app_hash_2"), privKey),
+			), true,
+		},
+		{"different rounds", types.NewConflictingHeaders(consAddr, h1, h2Round1), true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectErr, tc.e.ValidateBasic() != nil)
+		})
+	}
+}
+
+func TestConflictingHeadersVerifySignatures(t *testing.T) {
+	n, _ := time.Parse(time.RFC3339, "2006-01-02T15:04:05Z")
+	privKey, otherKey := ed25519.GenPrivKey(), ed25519.GenPrivKey()
+	consAddr := sdk.ConsAddress(privKey.PubKey().Address())
+
+	e := types.NewConflictingHeaders(
+		consAddr,
+		types.NewTestSignedHeader("test-chain", 10, n, []byte("app_hash_1"), privKey),
+		types.NewTestSignedHeader("test-chain", 10, n, []byte("app_hash_2"), privKey),
+	)
+	require.NoError(t, e.VerifySignatures(privKey.PubKey()))
+	require.Error(t, e.VerifySignatures(otherKey.PubKey()))
+	require.Equal(t, int64(10), e.GetHeight())
+	require.Equal(t, "test-chain", e.GetChainID())
+	require.Equal(t, types.RouteConflictingHeaders, e.Route())
+
+	// a signature from another key in one of the commits is rejected
+	e.H2 = types.NewTestSignedHeader("test-chain", 10, n, []byte("app_hash_2"), otherKey)
+	require.Error(t, e.VerifySignatures(privKey.PubKey()))
+
+	// a tampered signature is rejected
+	e.H2 = types.NewTestSignedHeader("test-chain", 10, n, []byte("app_hash_2"), privKey)
+	e.H2.Commit.Signatures[0].Signature[0] ^= 0xFF
+	require.Error(t, e.VerifySignatures(privKey.PubKey()))
+}
@@ -4,7 +4,9 @@ import (
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
 	stakingexported "github.com/cosmos/cosmos-sdk/x/staking/exported"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 
 	"github.com/tendermint/tendermint/crypto"
 )
@@ -14,6 +16,14 @@ type (
 	// evidence module.
 	StakingKeeper interface {
 		ValidatorByConsAddr(sdk.Context, sdk.ConsAddress) stakingexported.ValidatorI
+		GetHistoricalInfo(sdk.Context, int64) (stakingtypes.HistoricalInfo, bool)
+		HistoricalEntries(sdk.Context) uint16
+	}
+
+	// AccountKeeper defines the account module interface contract needed by the
+	// evidence module simulations.
+	AccountKeeper interface {
+		GetAccount(sdk.Context, sdk.AccAddress) authexported.Account
 	}
 
 	// SlashingKeeper defines the slashing module interface contract needed by the
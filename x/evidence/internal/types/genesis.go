@@ -44,5 +44,5 @@ func (gs GenesisState) Validate() error {
 		return fmt.Errorf("max evidence age must be at least 1 minute, is %s", maxEvidence.String())
 	}
 
-	return nil
+	return gs.Params.Validate()
 }
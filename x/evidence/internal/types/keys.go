@@ -1,5 +1,9 @@
 package types
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 const (
 	// ModuleName defines the module name
 	ModuleName = "evidence"
@@ -16,5 +20,13 @@ const (
 
 // KVStore key prefixes
 var (
-	KeyPrefixEvidence = []byte{0x00}
+	KeyPrefixEvidence           = []byte{0x00}
+	KeyPrefixHandledInfractions = []byte{0x01}
 )
+
+// HandledInfractionKey returns the key of the marker of the infraction of a
+// validator at a height, once the validator was punished for it, relative to
+// KeyPrefixHandledInfractions.
+func HandledInfractionKey(consAddr sdk.ConsAddress, height int64) []byte {
+	return append(consAddr.Bytes(), sdk.Uint64ToBigEndian(uint64(height))...)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
 
 	"gopkg.in/yaml.v2"
@@ -13,13 +14,22 @@ import (
 
 // Default parameter values
 const (
-	DefaultParamspace     = ModuleName
-	DefaultMaxEvidenceAge = 60 * 2 * time.Second
+	DefaultParamspace                     = ModuleName
+	DefaultMaxEvidenceAge                 = 60 * 2 * time.Second
+	DefaultTombstoneConflictingHeaders    = true
+	DefaultConflictingHeadersJailDuration = 60 * 60 * 24 * 14 * time.Second
+)
+
+var (
+	DefaultSlashFractionConflictingHeaders = sdk.NewDec(1).Quo(sdk.NewDec(20))
 )
 
 // Parameter store keys
 var (
-	KeyMaxEvidenceAge = []byte("MaxEvidenceAge")
+	KeyMaxEvidenceAge                  = []byte("MaxEvidenceAge")
+	KeySlashFractionConflictingHeaders = []byte("SlashFractionConflictingHeaders")
+	KeyTombstoneConflictingHeaders     = []byte("TombstoneConflictingHeaders")
+	KeyConflictingHeadersJailDuration  = []byte("ConflictingHeadersJailDuration")
 
 	// The Double Sign Jail period ends at Max Time supported by Amino
 	// (Dec 31, 9999 - 23:59:59 GMT).
@@ -28,7 +38,24 @@ var (
 
 // Params defines the total set of parameters for the evidence module
 type Params struct {
-	MaxEvidenceAge time.Duration `json:"max_evidence_age" yaml:"max_evidence_age"`
+	MaxEvidenceAge                  time.Duration `json:"max_evidence_age" yaml:"max_evidence_age"`
+	SlashFractionConflictingHeaders sdk.Dec       `json:"slash_fraction_conflicting_headers" yaml:"slash_fraction_conflicting_headers"`
+	TombstoneConflictingHeaders     bool          `json:"tombstone_conflicting_headers" yaml:"tombstone_conflicting_headers"`
+	ConflictingHeadersJailDuration  time.Duration `json:"conflicting_headers_jail_duration" yaml:"conflicting_headers_jail_duration"`
+}
+
+// NewParams creates a new Params object
+func NewParams(
+	maxEvidenceAge time.Duration, slashFractionConflictingHeaders sdk.Dec,
+	tombstoneConflictingHeaders bool, conflictingHeadersJailDuration time.Duration,
+) Params {
+
+	return Params{
+		MaxEvidenceAge:                  maxEvidenceAge,
+		SlashFractionConflictingHeaders: slashFractionConflictingHeaders,
+		TombstoneConflictingHeaders:     tombstoneConflictingHeaders,
+		ConflictingHeadersJailDuration:  conflictingHeadersJailDuration,
+	}
 }
 
 // ParamKeyTable returns the parameter key table.
@@ -45,14 +72,33 @@ func (p Params) String() string {
 func (p *Params) ParamSetPairs() params.ParamSetPairs {
 	return params.ParamSetPairs{
 		params.NewParamSetPair(KeyMaxEvidenceAge, &p.MaxEvidenceAge, validateMaxEvidenceAge),
+		params.NewParamSetPair(KeySlashFractionConflictingHeaders, &p.SlashFractionConflictingHeaders, validateSlashFractionConflictingHeaders),
+		params.NewParamSetPair(KeyTombstoneConflictingHeaders, &p.TombstoneConflictingHeaders, validateTombstoneConflictingHeaders),
+		params.NewParamSetPair(KeyConflictingHeadersJailDuration, &p.ConflictingHeadersJailDuration, validateConflictingHeadersJailDuration),
 	}
 }
 
 // DefaultParams returns the default parameters for the evidence module.
 func DefaultParams() Params {
-	return Params{
-		MaxEvidenceAge: DefaultMaxEvidenceAge,
+	return NewParams(
+		DefaultMaxEvidenceAge, DefaultSlashFractionConflictingHeaders,
+		DefaultTombstoneConflictingHeaders, DefaultConflictingHeadersJailDuration,
+	)
+}
+
+// Validate checks that the parameters have valid values.
+func (p Params) Validate() error {
+	if err := validateMaxEvidenceAge(p.MaxEvidenceAge); err != nil {
+		return err
 	}
+	if err := validateSlashFractionConflictingHeaders(p.SlashFractionConflictingHeaders); err != nil {
+		return err
+	}
+	if err := validateTombstoneConflictingHeaders(p.TombstoneConflictingHeaders); err != nil {
+		return err
+	}
+
+	return validateConflictingHeadersJailDuration(p.ConflictingHeadersJailDuration)
 }
 
 func validateMaxEvidenceAge(i interface{}) error {
@@ -67,3 +113,44 @@ func validateMaxEvidenceAge(i interface{}) error {
 
 	return nil
 }
+
+func validateSlashFractionConflictingHeaders(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() {
+		return fmt.Errorf("conflicting headers slash fraction cannot be nil")
+	}
+	if v.IsNegative() {
+		return fmt.Errorf("conflicting headers slash fraction cannot be negative: %s", v)
+	}
+	if v.GT(sdk.OneDec()) {
+		return fmt.Errorf("conflicting headers slash fraction too large: %s", v)
+	}
+
+	return nil
+}
+
+func validateTombstoneConflictingHeaders(i interface{}) error {
+	_, ok := i.(bool)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateConflictingHeadersJailDuration(i interface{}) error {
+	v, ok := i.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("conflicting headers jail duration must be positive: %s", v)
+	}
+
+	return nil
+}
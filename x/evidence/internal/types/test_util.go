@@ -20,6 +20,7 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmtypes "github.com/tendermint/tendermint/types"
 )
 
 var (
@@ -133,3 +134,49 @@ func TestEquivocationHandler(k interface{}) Handler {
 		return nil
 	}
 }
+
+// NewTestSignedHeader returns a well-formed signed header for the given chain,
+// height and time whose commit carries a single precommit signed by privKey.
+// Headers created with different appHash values conflict with each other.
+func NewTestSignedHeader(
+	chainID string, height int64, t time.Time, appHash []byte, privKey crypto.PrivKey,
+) tmtypes.SignedHeader {
+
+	hash := func(s string) []byte { return tmhash.Sum([]byte(s)) }
+	valAddr := privKey.PubKey().Address()
+
+	header := &tmtypes.Header{
+		ChainID: chainID,
+		Height:  height,
+		Time:    t,
+		LastBlockID: tmtypes.BlockID{
+			Hash:        hash("last_block"),
+			PartsHeader: tmtypes.PartSetHeader{Total: 1, Hash: hash("last_block_parts")},
+		},
+		LastCommitHash:     hash("last_commit"),
+		DataHash:           hash("data"),
+		ValidatorsHash:     hash("validators"),
+		NextValidatorsHash: hash("next_validators"),
+		ConsensusHash:      hash("consensus"),
+		AppHash:            appHash,
+		LastResultsHash:    hash("last_results"),
+		EvidenceHash:       hash("evidence"),
+		ProposerAddress:    valAddr,
+	}
+
+	blockID := tmtypes.BlockID{
+		Hash:        header.Hash(),
+		PartsHeader: tmtypes.PartSetHeader{Total: 1, Hash: hash("block_parts")},
+	}
+	commit := tmtypes.NewCommit(height, 0, blockID, []tmtypes.CommitSig{
+		tmtypes.NewCommitSigForBlock(nil, valAddr, t),
+	})
+
+	sig, err := privKey.Sign(commit.VoteSignBytes(chainID, 0))
+	if err != nil {
+		panic(err)
+	}
+	commit.Signatures[0].Signature = sig
+
+	return tmtypes.SignedHeader{Header: header, Commit: commit}
+}
@@ -0,0 +1,81 @@
+package simulation
+
+// DONTCOVER
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/cosmos/cosmos-sdk/x/evidence/exported"
+	"github.com/cosmos/cosmos-sdk/x/evidence/internal/types"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+)
+
+// Simulation parameter constants
+const (
+	MaxEvidenceAge                  = "max_evidence_age"
+	SlashFractionConflictingHeaders = "slash_fraction_conflicting_headers"
+	TombstoneConflictingHeaders     = "tombstone_conflicting_headers"
+	ConflictingHeadersJailDuration  = "conflicting_headers_jail_duration"
+)
+
+// GenMaxEvidenceAge randomized MaxEvidenceAge
+func GenMaxEvidenceAge(r *rand.Rand) time.Duration {
+	return time.Duration(simulation.RandIntBetween(r, 60, 60*60*24)) * time.Second
+}
+
+// GenSlashFractionConflictingHeaders randomized SlashFractionConflictingHeaders
+func GenSlashFractionConflictingHeaders(r *rand.Rand) sdk.Dec {
+	return sdk.NewDec(1).Quo(sdk.NewDec(int64(r.Intn(50) + 1)))
+}
+
+// GenTombstoneConflictingHeaders randomized TombstoneConflictingHeaders
+func GenTombstoneConflictingHeaders(r *rand.Rand) bool {
+	return r.Intn(2) == 0
+}
+
+// GenConflictingHeadersJailDuration randomized ConflictingHeadersJailDuration
+func GenConflictingHeadersJailDuration(r *rand.Rand) time.Duration {
+	return time.Duration(simulation.RandIntBetween(r, 60, 60*60*24*14)) * time.Second
+}
+
+// RandomizedGenState generates a random GenesisState for evidence
+func RandomizedGenState(simState *module.SimulationState) {
+	var maxEvidenceAge time.Duration
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, MaxEvidenceAge, &maxEvidenceAge, simState.Rand,
+		func(r *rand.Rand) { maxEvidenceAge = GenMaxEvidenceAge(r) },
+	)
+
+	var slashFractionConflictingHeaders sdk.Dec
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, SlashFractionConflictingHeaders, &slashFractionConflictingHeaders, simState.Rand,
+		func(r *rand.Rand) { slashFractionConflictingHeaders = GenSlashFractionConflictingHeaders(r) },
+	)
+
+	var tombstoneConflictingHeaders bool
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, TombstoneConflictingHeaders, &tombstoneConflictingHeaders, simState.Rand,
+		func(r *rand.Rand) { tombstoneConflictingHeaders = GenTombstoneConflictingHeaders(r) },
+	)
+
+	var conflictingHeadersJailDuration time.Duration
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, ConflictingHeadersJailDuration, &conflictingHeadersJailDuration, simState.Rand,
+		func(r *rand.Rand) { conflictingHeadersJailDuration = GenConflictingHeadersJailDuration(r) },
+	)
+
+	params := types.NewParams(
+		maxEvidenceAge, slashFractionConflictingHeaders,
+		tombstoneConflictingHeaders, conflictingHeadersJailDuration,
+	)
+
+	evidenceGenesis := types.NewGenesisState(params, []exported.Evidence{})
+
+	fmt.Printf("Selected randomly generated evidence parameters:\n%s\n", codec.MustMarshalJSONIndent(simState.Cdc, evidenceGenesis.Params))
+	simState.GenState[types.ModuleName] = simState.Cdc.MustMarshalJSON(evidenceGenesis)
+}
@@ -0,0 +1,107 @@
+package simulation
+
+import (
+	"bytes"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/simapp/helpers"
+	simappparams "github.com/cosmos/cosmos-sdk/simapp/params"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/evidence/internal/types"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
+)
+
+// Simulation operation weights constants
+const (
+	OpWeightMsgSubmitConflictingHeaders = "op_weight_msg_submit_conflicting_headers"
+)
+
+// WeightedOperations returns all the operations from the module with their respective weights
+func WeightedOperations(
+	appParams simulation.AppParams, cdc *codec.Codec, ak types.AccountKeeper, sk stakingkeeper.Keeper,
+) simulation.WeightedOperations {
+
+	var weightMsgSubmitConflictingHeaders int
+	appParams.GetOrGenerate(cdc, OpWeightMsgSubmitConflictingHeaders, &weightMsgSubmitConflictingHeaders, nil,
+		func(_ *rand.Rand) {
+			weightMsgSubmitConflictingHeaders = simappparams.DefaultWeightMsgSubmitConflictingHeaders
+		},
+	)
+
+	return simulation.WeightedOperations{
+		simulation.NewWeightedOperation(
+			weightMsgSubmitConflictingHeaders,
+			SimulateMsgSubmitConflictingHeaders(ak, sk),
+		),
+	}
+}
+
+// SimulateMsgSubmitConflictingHeaders generates a MsgSubmitEvidence with
+// ConflictingHeaders evidence for a random validator of the current height. The
+// headers are signed with the simulation account key that doubles as the
+// validator's consensus key.
+// nolint: funlen
+func SimulateMsgSubmitConflictingHeaders(ak types.AccountKeeper, sk stakingkeeper.Keeper) simulation.Operation { // nolint:interfacer
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		histInfo, found := sk.GetHistoricalInfo(ctx, ctx.BlockHeight())
+		if !found || len(histInfo.ValSet) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil // skip
+		}
+
+		histVal := histInfo.ValSet[r.Intn(len(histInfo.ValSet))]
+		signer, found := simulation.FindAccount(accs, sdk.AccAddress(histVal.GetOperator()))
+		if !found || !bytes.Equal(signer.PubKey.Bytes(), histVal.GetConsPubKey().Bytes()) {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil // skip
+		}
+
+		consAddr := histVal.GetConsAddr()
+		validator := sk.ValidatorByConsAddr(ctx, consAddr)
+		if validator == nil || validator.IsUnbonded() || validator.IsJailed() {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil // skip
+		}
+
+		height, blockTime := ctx.BlockHeight(), ctx.BlockHeader().Time
+		appHash1, appHash2 := make([]byte, 32), make([]byte, 32)
+		r.Read(appHash1)
+		r.Read(appHash2)
+
+		evidence := types.NewConflictingHeaders(
+			consAddr,
+			types.NewTestSignedHeader(chainID, height, blockTime, appHash1, signer.PrivKey),
+			types.NewTestSignedHeader(chainID, height, blockTime, appHash2, signer.PrivKey),
+		)
+
+		submitter, _ := simulation.RandomAcc(r, accs)
+		account := ak.GetAccount(ctx, submitter.Address)
+		fees, err := simulation.RandomFees(r, ctx, account.SpendableCoins(ctx.BlockTime()))
+		if err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, err
+		}
+
+		msg := types.NewMsgSubmitEvidence(evidence, submitter.Address)
+
+		tx := helpers.GenTx(
+			[]sdk.Msg{msg},
+			fees,
+			helpers.DefaultGenTxGas,
+			chainID,
+			[]uint64{account.GetAccountNumber()},
+			[]uint64{account.GetSequence()},
+			submitter.PrivKey,
+		)
+
+		_, _, err = app.Deliver(tx)
+		if err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, err
+		}
+
+		return simulation.NewOperationMsg(msg, true, ""), nil, nil
+	}
+}
@@ -0,0 +1,33 @@
+package simulation
+
+// DONTCOVER
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/x/evidence/internal/types"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+)
+
+const (
+	keySlashFractionConflictingHeaders = "SlashFractionConflictingHeaders"
+	keyTombstoneConflictingHeaders     = "TombstoneConflictingHeaders"
+)
+
+// ParamChanges defines the parameters that can be modified by param change proposals
+// on the simulation
+func ParamChanges(r *rand.Rand) []simulation.ParamChange {
+	return []simulation.ParamChange{
+		simulation.NewSimParamChange(types.ModuleName, keySlashFractionConflictingHeaders,
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%s\"", GenSlashFractionConflictingHeaders(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, keyTombstoneConflictingHeaders,
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("%t", GenTombstoneConflictingHeaders(r))
+			},
+		),
+	}
+}
@@ -39,6 +39,7 @@ type (
 	ParamSetPair            = subspace.ParamSetPair
 	ParamSetPairs           = subspace.ParamSetPairs
 	ParamSet                = subspace.ParamSet
+	ParamSetValidator       = subspace.ParamSetValidator
 	Subspace                = subspace.Subspace
 	ReadOnlySubspace        = subspace.ReadOnlySubspace
 	KeyTable                = subspace.KeyTable
@@ -0,0 +1,19 @@
+package params
+
+import (
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/x/params/subspace"
+)
+
+// BaseAppParamsKeyTable returns the key table of the params of BaseApp, for the
+// subspace named baseapp.Paramspace set as its param store.
+func BaseAppParamsKeyTable() subspace.KeyTable {
+	return subspace.NewKeyTable(
+		subspace.NewParamSetPair(
+			baseapp.ParamStoreKeyTransientStoreLimit, uint64(0), baseapp.ValidateTransientStoreLimit,
+		),
+		subspace.NewParamSetPair(
+			baseapp.ParamStoreKeyTransientStoreGas, false, baseapp.ValidateTransientStoreGas,
+		),
+	)
+}
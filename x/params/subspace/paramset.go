@@ -24,3 +24,11 @@ type ParamSetPairs []ParamSetPair
 type ParamSet interface {
 	ParamSetPairs() ParamSetPairs
 }
+
+// ParamSetValidator is implemented by the ParamSets whose parameters must also
+// be validated together, e.g. a maximum against its base value. Such a set is
+// validated as a whole whenever one of its parameters is updated.
+type ParamSetValidator interface {
+	ParamSet
+	ValidateParamSet() error
+}
@@ -1,6 +1,7 @@
 package subspace
 
 import (
+	"bytes"
 	"fmt"
 	"reflect"
 
@@ -57,6 +58,10 @@ func (s Subspace) WithKeyTable(table KeyTable) Subspace {
 	for k, v := range table.m {
 		s.table.m[k] = v
 	}
+	// a table not built by NewKeyTable may have no param sets
+	if table.sets != nil {
+		*s.table.sets = append(*s.table.sets, *table.sets...)
+	}
 
 	// Allocate additional capacity for Subspace.name
 	// So we don't have to allocate extra space each time appending to the key
@@ -204,11 +209,44 @@ func (s Subspace) Update(ctx sdk.Context, key, value []byte) error {
 	if err := s.Validate(ctx, key, destValue); err != nil {
 		return err
 	}
+	if err := s.validateParamSets(ctx, key, destValue); err != nil {
+		return err
+	}
 
 	s.Set(ctx, key, dest)
 	return nil
 }
 
+// validateParamSets validates as a whole the registered ParamSetValidators
+// containing a parameter, with the parameter set to the given value and the
+// other ones read from the store, or taken from the registered set if missing.
+func (s Subspace) validateParamSets(ctx sdk.Context, key []byte, value interface{}) error {
+	for _, registered := range *s.table.sets {
+		// deep copy the registered set, whose values must not be modified
+		ps := reflect.New(reflect.TypeOf(registered).Elem()).Interface().(ParamSetValidator)
+		s.cdc.MustUnmarshalJSON(s.cdc.MustMarshalJSON(registered), ps)
+
+		found := false
+		for _, pair := range ps.ParamSetPairs() {
+			if bytes.Equal(pair.Key, key) {
+				reflect.ValueOf(pair.Value).Elem().Set(reflect.ValueOf(value))
+				found = true
+				continue
+			}
+			s.GetIfExists(ctx, pair.Key, pair.Value)
+		}
+
+		if !found {
+			continue
+		}
+		if err := ps.ValidateParamSet(); err != nil {
+			return fmt.Errorf("invalid parameter set: %s", err)
+		}
+	}
+
+	return nil
+}
+
 // GetParamSet iterates through each ParamSetPair where for each pair, it will
 // retrieve the value and set it to the corresponding value pointer provided
 // in the ParamSetPair by calling Subspace#Get.
@@ -221,6 +259,12 @@ func (s Subspace) GetParamSet(ctx sdk.Context, ps ParamSet) {
 // SetParamSet iterates through each ParamSetPair and sets the value with the
 // corresponding parameter key in the Subspace's KVStore.
 func (s Subspace) SetParamSet(ctx sdk.Context, ps ParamSet) {
+	if v, ok := ps.(ParamSetValidator); ok {
+		if err := v.ValidateParamSet(); err != nil {
+			panic(fmt.Sprintf("invalid parameter set: %s", err))
+		}
+	}
+
 	for _, pair := range ps.ParamSetPairs() {
 		// pair.Field is a pointer to the field, so indirecting the ptr.
 		// go-amino automatically handles it but just for sure,
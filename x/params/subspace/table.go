@@ -11,14 +11,21 @@ type attribute struct {
 	vfn ValueValidatorFn
 }
 
-// KeyTable subspaces appropriate type for each parameter key
+// KeyTable subspaces appropriate type for each parameter key. The zero value is
+// an empty table, but NewKeyTable should be used to build one.
 type KeyTable struct {
 	m map[string]attribute
+
+	// the registered ParamSets validated as a whole on updates, holding the
+	// values of their parameters missing from the store, shared like m by the
+	// copies of a Subspace
+	sets *[]ParamSetValidator
 }
 
 func NewKeyTable(pairs ...ParamSetPair) KeyTable {
 	keyTable := KeyTable{
-		m: make(map[string]attribute),
+		m:    make(map[string]attribute),
+		sets: new([]ParamSetValidator),
 	}
 
 	for _, psp := range pairs {
@@ -44,6 +51,9 @@ func (t KeyTable) RegisterType(psp ParamSetPair) KeyTable {
 	if _, ok := t.m[keystr]; ok {
 		panic("duplicate parameter key")
 	}
+	if t.m == nil {
+		t.m = make(map[string]attribute)
+	}
 
 	rty := reflect.TypeOf(psp.Value)
 
@@ -61,10 +71,18 @@ func (t KeyTable) RegisterType(psp ParamSetPair) KeyTable {
 }
 
 // RegisterParamSet registers multiple ParamSetPairs from a ParamSet in a KeyTable.
+// If the ParamSet is a ParamSetValidator, its values are used for the parameters
+// missing from the store when validating the set as a whole.
 func (t KeyTable) RegisterParamSet(ps ParamSet) KeyTable {
 	for _, psp := range ps.ParamSetPairs() {
 		t = t.RegisterType(psp)
 	}
+	if v, ok := ps.(ParamSetValidator); ok {
+		if t.sets == nil {
+			t.sets = new([]ParamSetValidator)
+		}
+		*t.sets = append(*t.sets, v)
+	}
 	return t
 }
 
@@ -43,4 +43,14 @@ func TestKeyTable(t *testing.T) {
 			subspace.ParamSetPair{[]byte("test2"), uint16(100), validateMaxValidators},
 		)
 	})
+
+	// a zero table can be built on and set on a subspace
+	require.NotPanics(t, func() {
+		table := subspace.KeyTable{}.RegisterType(subspace.ParamSetPair{keyBondDenom, string("stake"), validateBondDenom})
+		subspace.NewSubspace(nil, key, tkey, "zerotable").WithKeyTable(table)
+	})
+	require.NotPanics(t, func() {
+		table := subspace.KeyTable{}.RegisterParamSet(&params{})
+		subspace.NewSubspace(nil, key, tkey, "zeroparamset").WithKeyTable(table)
+	})
 }
@@ -3,30 +3,40 @@ package slashing
 // nolint
 
 import (
+	"github.com/cosmos/cosmos-sdk/x/slashing/client"
 	"github.com/cosmos/cosmos-sdk/x/slashing/internal/keeper"
 	"github.com/cosmos/cosmos-sdk/x/slashing/internal/types"
 )
 
 const (
-	ModuleName                  = types.ModuleName
-	StoreKey                    = types.StoreKey
-	RouterKey                   = types.RouterKey
-	QuerierRoute                = types.QuerierRoute
-	DefaultParamspace           = types.DefaultParamspace
-	DefaultSignedBlocksWindow   = types.DefaultSignedBlocksWindow
-	DefaultDowntimeJailDuration = types.DefaultDowntimeJailDuration
-	QueryParameters             = types.QueryParameters
-	QuerySigningInfo            = types.QuerySigningInfo
-	QuerySigningInfos           = types.QuerySigningInfos
+	ModuleName                      = types.ModuleName
+	StoreKey                        = types.StoreKey
+	RouterKey                       = types.RouterKey
+	QuerierRoute                    = types.QuerierRoute
+	DefaultParamspace               = types.DefaultParamspace
+	DefaultSignedBlocksWindow       = types.DefaultSignedBlocksWindow
+	DefaultDowntimeJailDuration     = types.DefaultDowntimeJailDuration
+	QueryParameters                 = types.QueryParameters
+	QuerySigningInfo                = types.QuerySigningInfo
+	QuerySigningInfos               = types.QuerySigningInfos
+	QueryTombstoneAppeals           = types.QueryTombstoneAppeals
+	ProposalTypeTombstoneAppeal     = types.ProposalTypeTombstoneAppeal
+	DefaultDowntimeEscalationWindow = types.DefaultDowntimeEscalationWindow
+	DefaultMaxDowntimeJailDuration  = types.DefaultMaxDowntimeJailDuration
 
 	EventTypeSlash                 = types.EventTypeSlash
 	EventTypeLiveness              = types.EventTypeLiveness
+	EventTypeTombstoneLifted       = types.EventTypeTombstoneLifted
 	AttributeKeyAddress            = types.AttributeKeyAddress
 	AttributeKeyHeight             = types.AttributeKeyHeight
 	AttributeKeyPower              = types.AttributeKeyPower
 	AttributeKeyReason             = types.AttributeKeyReason
 	AttributeKeyJailed             = types.AttributeKeyJailed
 	AttributeKeyMissedBlocks       = types.AttributeKeyMissedBlocks
+	AttributeKeyJailDuration       = types.AttributeKeyJailDuration
+	AttributeKeyFraction           = types.AttributeKeyFraction
+	AttributeKeyOffences           = types.AttributeKeyOffences
+	AttributeKeyTitle              = types.AttributeKeyTitle
 	AttributeValueDoubleSign       = types.AttributeValueDoubleSign
 	AttributeValueMissingSignature = types.AttributeValueMissingSignature
 	AttributeValueCategory         = types.AttributeValueCategory
@@ -44,6 +54,8 @@ var (
 	ErrMissingSelfDelegation                 = types.ErrMissingSelfDelegation
 	ErrSelfDelegationTooLowToUnjail          = types.ErrSelfDelegationTooLowToUnjail
 	ErrNoSigningInfoFound                    = types.ErrNoSigningInfoFound
+	ErrValidatorNotTombstoned                = types.ErrValidatorNotTombstoned
+	ErrEmptyConsAddress                      = types.ErrEmptyConsAddress
 	NewGenesisState                          = types.NewGenesisState
 	NewMissedBlock                           = types.NewMissedBlock
 	DefaultGenesisState                      = types.DefaultGenesisState
@@ -53,6 +65,8 @@ var (
 	GetValidatorMissedBlockBitArrayPrefixKey = types.GetValidatorMissedBlockBitArrayPrefixKey
 	GetValidatorMissedBlockBitArrayKey       = types.GetValidatorMissedBlockBitArrayKey
 	GetAddrPubkeyRelationKey                 = types.GetAddrPubkeyRelationKey
+	GetTombstoneAppealPrefixKey              = types.GetTombstoneAppealPrefixKey
+	GetTombstoneAppealKey                    = types.GetTombstoneAppealKey
 	NewMsgUnjail                             = types.NewMsgUnjail
 	ParamKeyTable                            = types.ParamKeyTable
 	NewParams                                = types.NewParams
@@ -60,12 +74,18 @@ var (
 	NewQuerySigningInfoParams                = types.NewQuerySigningInfoParams
 	NewQuerySigningInfosParams               = types.NewQuerySigningInfosParams
 	NewValidatorSigningInfo                  = types.NewValidatorSigningInfo
+	NewTombstoneAppeal                       = types.NewTombstoneAppeal
+	NewTombstoneAppealProposal               = types.NewTombstoneAppealProposal
+	NewQueryTombstoneAppealsParams           = types.NewQueryTombstoneAppealsParams
+	HandleTombstoneAppealProposal            = keeper.HandleTombstoneAppealProposal
 
 	// variable aliases
+	ProposalHandler                 = client.ProposalHandler
 	ModuleCdc                       = types.ModuleCdc
 	ValidatorSigningInfoKey         = types.ValidatorSigningInfoKey
 	ValidatorMissedBlockBitArrayKey = types.ValidatorMissedBlockBitArrayKey
 	AddrPubkeyRelationKey           = types.AddrPubkeyRelationKey
+	TombstoneAppealKey              = types.TombstoneAppealKey
 	DefaultMinSignedPerWindow       = types.DefaultMinSignedPerWindow
 	DefaultSlashFractionDoubleSign  = types.DefaultSlashFractionDoubleSign
 	DefaultSlashFractionDowntime    = types.DefaultSlashFractionDowntime
@@ -74,16 +94,27 @@ var (
 	KeyDowntimeJailDuration         = types.KeyDowntimeJailDuration
 	KeySlashFractionDoubleSign      = types.KeySlashFractionDoubleSign
 	KeySlashFractionDowntime        = types.KeySlashFractionDowntime
+	DefaultDowntimeJailMultiplier   = types.DefaultDowntimeJailMultiplier
+	DefaultDowntimeSlashMultiplier  = types.DefaultDowntimeSlashMultiplier
+	DefaultMaxSlashFractionDowntime = types.DefaultMaxSlashFractionDowntime
+	KeyDowntimeEscalationWindow     = types.KeyDowntimeEscalationWindow
+	KeyDowntimeJailMultiplier       = types.KeyDowntimeJailMultiplier
+	KeyMaxDowntimeJailDuration      = types.KeyMaxDowntimeJailDuration
+	KeyDowntimeSlashMultiplier      = types.KeyDowntimeSlashMultiplier
+	KeyMaxSlashFractionDowntime     = types.KeyMaxSlashFractionDowntime
 )
 
 type (
-	Hooks                   = keeper.Hooks
-	Keeper                  = keeper.Keeper
-	GenesisState            = types.GenesisState
-	MissedBlock             = types.MissedBlock
-	MsgUnjail               = types.MsgUnjail
-	Params                  = types.Params
-	QuerySigningInfoParams  = types.QuerySigningInfoParams
-	QuerySigningInfosParams = types.QuerySigningInfosParams
-	ValidatorSigningInfo    = types.ValidatorSigningInfo
+	Hooks                       = keeper.Hooks
+	Keeper                      = keeper.Keeper
+	GenesisState                = types.GenesisState
+	MissedBlock                 = types.MissedBlock
+	MsgUnjail                   = types.MsgUnjail
+	Params                      = types.Params
+	QuerySigningInfoParams      = types.QuerySigningInfoParams
+	QuerySigningInfosParams     = types.QuerySigningInfosParams
+	ValidatorSigningInfo        = types.ValidatorSigningInfo
+	TombstoneAppeal             = types.TombstoneAppeal
+	TombstoneAppealProposal     = types.TombstoneAppealProposal
+	QueryTombstoneAppealsParams = types.QueryTombstoneAppealsParams
 )
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
@@ -29,6 +30,7 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	slashingQueryCmd.AddCommand(
 		flags.GetCommands(
 			GetCmdQuerySigningInfo(queryRoute, cdc),
+			GetCmdQueryTombstoneAppeals(cdc),
 			GetCmdQueryParams(cdc),
 		)...,
 	)
@@ -99,3 +101,52 @@ $ <appcli> query slashing params
 		},
 	}
 }
+
+// GetCmdQueryTombstoneAppeals implements the command to query the tombstones
+// lifted through governance.
+func GetCmdQueryTombstoneAppeals(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tombstone-appeals",
+		Short: "Query the tombstones lifted through governance",
+		Args:  cobra.NoArgs,
+		Long: strings.TrimSpace(`Query the audit records of the tombstones lifted by passed tombstone appeal
+proposals, optionally restricted to a single validator:
+
+$ <appcli> query slashing tombstone-appeals
+$ <appcli> query slashing tombstone-appeals --validator cosmosvalcons1qnwh2grp7n4dtgxyaqz2ze3hvdqj5ywdwk0smk
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var consAddr sdk.ConsAddress
+			if v := viper.GetString(FlagAddressValidator); v != "" {
+				addr, err := sdk.ConsAddressFromBech32(v)
+				if err != nil {
+					return err
+				}
+				consAddr = addr
+			}
+
+			params := types.NewQueryTombstoneAppealsParams(consAddr, viper.GetInt(flags.FlagPage), viper.GetInt(flags.FlagLimit))
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryTombstoneAppeals)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var appeals []types.TombstoneAppeal
+			cdc.MustUnmarshalJSON(res, &appeals)
+			return cliCtx.PrintOutput(appeals)
+		},
+	}
+
+	cmd.Flags().String(FlagAddressValidator, "", "Bech32 consensus address of the validator")
+	cmd.Flags().Int(flags.FlagPage, 1, "pagination page of appeals to query for")
+	cmd.Flags().Int(flags.FlagLimit, 100, "pagination limit of appeals to query for")
+	return cmd
+}
@@ -2,6 +2,8 @@ package cli
 
 import (
 	"bufio"
+	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -10,8 +12,10 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/slashing/internal/types"
 )
 
@@ -54,3 +58,60 @@ $ <appcli> tx slashing unjail --from mykey
 		},
 	}
 }
+
+// GetCmdSubmitTombstoneAppealProposal implements the command to submit a tombstone-appeal proposal
+func GetCmdSubmitTombstoneAppealProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tombstone-appeal [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to lift the tombstone of a validator",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a tombstone appeal proposal along with an initial deposit.
+If the proposal passes, the tombstone of the validator is lifted and the
+validator may unjail itself again. The proposal details must be supplied via a
+JSON file.
+
+Example:
+$ %s tx gov submit-proposal tombstone-appeal <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "Tombstone Appeal",
+  "description": "The validator double signed because of a misconfigured failover",
+  "cons_address": "cosmosvalcons1qnwh2grp7n4dtgxyaqz2ze3hvdqj5ywdwk0smk",
+  "deposit": [
+    {
+      "denom": "stake",
+      "amount": "10000"
+    }
+  ]
+}
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			proposal, err := ParseTombstoneAppealProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			from := cliCtx.GetFromAddress()
+			content := types.NewTombstoneAppealProposal(proposal.Title, proposal.Description, proposal.ConsAddress)
+
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, from)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
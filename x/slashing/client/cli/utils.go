@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"io/ioutil"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type (
+	// TombstoneAppealProposalJSON defines a TombstoneAppealProposal with a deposit
+	TombstoneAppealProposalJSON struct {
+		Title       string          `json:"title" yaml:"title"`
+		Description string          `json:"description" yaml:"description"`
+		ConsAddress sdk.ConsAddress `json:"cons_address" yaml:"cons_address"`
+		Deposit     sdk.Coins       `json:"deposit" yaml:"deposit"`
+	}
+)
+
+// ParseTombstoneAppealProposalJSON reads and parses a TombstoneAppealProposalJSON from a file.
+func ParseTombstoneAppealProposalJSON(cdc *codec.Codec, proposalFile string) (TombstoneAppealProposalJSON, error) {
+	proposal := TombstoneAppealProposalJSON{}
+
+	contents, err := ioutil.ReadFile(proposalFile)
+	if err != nil {
+		return proposal, err
+	}
+
+	if err := cdc.UnmarshalJSON(contents, &proposal); err != nil {
+		return proposal, err
+	}
+
+	return proposal, nil
+}
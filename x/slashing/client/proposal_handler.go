@@ -0,0 +1,12 @@
+package client
+
+import (
+	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+	"github.com/cosmos/cosmos-sdk/x/slashing/client/cli"
+	"github.com/cosmos/cosmos-sdk/x/slashing/client/rest"
+)
+
+// tombstone appeal proposal handler
+var (
+	ProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitTombstoneAppealProposal, rest.ProposalRESTHandler)
+)
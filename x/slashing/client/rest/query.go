@@ -23,6 +23,11 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 		signingInfoHandlerListFn(cliCtx),
 	).Methods("GET")
 
+	r.HandleFunc(
+		"/slashing/tombstone_appeals",
+		tombstoneAppealsHandlerFn(cliCtx),
+	).Methods("GET")
+
 	r.HandleFunc(
 		"/slashing/parameters",
 		queryParamsHandlerFn(cliCtx),
@@ -97,6 +102,48 @@ func signingInfoHandlerListFn(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
+// http request handler to query the tombstones lifted through governance
+func tombstoneAppealsHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, page, limit, err := rest.ParseHTTPArgsWithLimit(r, 0)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var consAddr sdk.ConsAddress
+		if v := r.URL.Query().Get("validator"); v != "" {
+			consAddr, err = sdk.ConsAddressFromBech32(v)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		params := types.NewQueryTombstoneAppealsParams(consAddr, page, limit)
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryTombstoneAppeals)
+		res, height, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func queryParamsHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
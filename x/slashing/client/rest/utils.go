@@ -0,0 +1,19 @@
+package rest
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+)
+
+type (
+	// TombstoneAppealProposalReq defines a tombstone appeal proposal request body.
+	TombstoneAppealProposalReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+		Title       string          `json:"title" yaml:"title"`
+		Description string          `json:"description" yaml:"description"`
+		ConsAddress sdk.ConsAddress `json:"cons_address" yaml:"cons_address"`
+		Proposer    sdk.AccAddress  `json:"proposer" yaml:"proposer"`
+		Deposit     sdk.Coins       `json:"deposit" yaml:"deposit"`
+	}
+)
@@ -34,6 +34,10 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, stakingKeeper types.StakingKeep
 		}
 	}
 
+	for _, appeal := range data.TombstoneAppeals {
+		keeper.SetTombstoneAppeal(ctx, appeal)
+	}
+
 	keeper.SetParams(ctx, data.Params)
 }
 
@@ -58,5 +62,11 @@ func ExportGenesis(ctx sdk.Context, keeper Keeper) (data types.GenesisState) {
 		return false
 	})
 
-	return types.NewGenesisState(params, signingInfos, missedBlocks)
+	tombstoneAppeals := []types.TombstoneAppeal{}
+	keeper.IterateTombstoneAppeals(ctx, func(appeal types.TombstoneAppeal) (stop bool) {
+		tombstoneAppeals = append(tombstoneAppeals, appeal)
+		return false
+	})
+
+	return types.NewGenesisState(params, signingInfos, missedBlocks, tombstoneAppeals)
 }
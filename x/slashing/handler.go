@@ -3,6 +3,8 @@ package slashing
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/cosmos/cosmos-sdk/x/slashing/internal/keeper"
 	"github.com/cosmos/cosmos-sdk/x/slashing/internal/types"
 )
 
@@ -39,3 +41,17 @@ func handleMsgUnjail(ctx sdk.Context, msg MsgUnjail, k Keeper) (*sdk.Result, err
 
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
+
+// NewTombstoneAppealProposalHandler creates a governance handler that lifts
+// the tombstone of a validator
+func NewTombstoneAppealProposalHandler(k Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		switch c := content.(type) {
+		case types.TombstoneAppealProposal:
+			return keeper.HandleTombstoneAppealProposal(ctx, k, c)
+
+		default:
+			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized slashing proposal content type: %T", c)
+		}
+	}
+}
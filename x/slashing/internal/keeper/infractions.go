@@ -82,6 +82,12 @@ func (k Keeper) HandleValidatorSignature(ctx sdk.Context, addr crypto.Address, p
 			// That's fine since this is just used to filter unbonding delegations & redelegations.
 			distributionHeight := height - sdk.ValidatorUpdateDelay - 1
 
+			// Repeated downtime within the escalation window of the previous
+			// downtime jailing escalates both the jail duration and the slash fraction.
+			blockTime := ctx.BlockHeader().Time
+			priorOffences := signInfo.PriorDowntimeOffences(blockTime, k.DowntimeEscalationWindow(ctx))
+			jailDuration, slashFraction := k.GetParams(ctx).DowntimePenalty(priorOffences)
+
 			ctx.EventManager().EmitEvent(
 				sdk.NewEvent(
 					types.EventTypeSlash,
@@ -89,12 +95,17 @@ func (k Keeper) HandleValidatorSignature(ctx sdk.Context, addr crypto.Address, p
 					sdk.NewAttribute(types.AttributeKeyPower, fmt.Sprintf("%d", power)),
 					sdk.NewAttribute(types.AttributeKeyReason, types.AttributeValueMissingSignature),
 					sdk.NewAttribute(types.AttributeKeyJailed, consAddr.String()),
+					sdk.NewAttribute(types.AttributeKeyOffences, fmt.Sprintf("%d", priorOffences+1)),
+					sdk.NewAttribute(types.AttributeKeyJailDuration, jailDuration.String()),
+					sdk.NewAttribute(types.AttributeKeyFraction, slashFraction.String()),
 				),
 			)
-			k.sk.Slash(ctx, consAddr, distributionHeight, power, k.SlashFractionDowntime(ctx))
+			k.sk.Slash(ctx, consAddr, distributionHeight, power, slashFraction)
 			k.sk.Jail(ctx, consAddr)
 
-			signInfo.JailedUntil = ctx.BlockHeader().Time.Add(k.DowntimeJailDuration(ctx))
+			signInfo.JailedUntil = blockTime.Add(jailDuration)
+			signInfo.DowntimeOffences = priorOffences + 1
+			signInfo.LastDowntimeJail = blockTime
 
 			// We need to reset the counter & array so that the validator won't be immediately slashed for downtime upon rebonding.
 			signInfo.MissedBlocksCounter = 0
@@ -221,3 +221,74 @@ func TestValidatorDippingInAndOut(t *testing.T) {
 	require.Equal(t, sdk.Unbonding, validator.Status)
 
 }
+
+// Test a validator being jailed for downtime repeatedly
+// Ensure that the jail duration and slash fraction escalate within the
+// escalation window and reset once it has elapsed
+func TestHandleRepeatedDowntime(t *testing.T) {
+
+	// initial setup
+	params := types.DefaultParams()
+	ctx, _, sk, _, keeper := CreateTestInput(t, params)
+	power := int64(100)
+	amt := sdk.TokensFromConsensusPower(power)
+	addr, val := Addrs[0], Pks[0]
+	consAddr := sdk.ConsAddress(val.Address())
+	sh := staking.NewHandler(sk)
+	res, err := sh(ctx, NewTestMsgCreateValidator(addr, val, amt))
+	require.NoError(t, err)
+	require.NotNil(t, res)
+
+	staking.EndBlocker(ctx, sk)
+
+	height := int64(0)
+	// miss blocks until the validator gets jailed
+	missBlocks := func() {
+		for validator, _ := sk.GetValidatorByConsAddr(ctx, consAddr); !validator.IsJailed(); height++ {
+			ctx = ctx.WithBlockHeight(height)
+			keeper.HandleValidatorSignature(ctx, val.Address(), power, false)
+			validator, _ = sk.GetValidatorByConsAddr(ctx, consAddr)
+		}
+		staking.EndBlocker(ctx, sk)
+	}
+	rejoin := func() {
+		info, found := keeper.GetValidatorSigningInfo(ctx, consAddr)
+		require.True(t, found)
+		ctx = ctx.WithBlockTime(info.JailedUntil)
+		sk.Unjail(ctx, consAddr)
+		staking.EndBlocker(ctx, sk)
+		validator, _ := sk.GetValidatorByConsAddr(ctx, consAddr)
+		require.Equal(t, sdk.Bonded, validator.GetStatus())
+	}
+
+	// first offence: base penalty
+	missBlocks()
+	info, found := keeper.GetValidatorSigningInfo(ctx, consAddr)
+	require.True(t, found)
+	require.Equal(t, int64(1), info.DowntimeOffences)
+	require.Equal(t, ctx.BlockHeader().Time.Add(params.DowntimeJailDuration), info.JailedUntil)
+	validator, _ := sk.GetValidatorByConsAddr(ctx, consAddr)
+	expTokens := amt.Sub(sdk.TokensFromConsensusPower(1))
+	require.Equal(t, expTokens, validator.GetTokens())
+
+	// second offence within the window: doubled jail duration and slash fraction
+	rejoin()
+	missBlocks()
+	info, _ = keeper.GetValidatorSigningInfo(ctx, consAddr)
+	require.Equal(t, int64(2), info.DowntimeOffences)
+	require.Equal(t, ctx.BlockHeader().Time.Add(2*params.DowntimeJailDuration), info.JailedUntil)
+	validator, _ = sk.GetValidatorByConsAddr(ctx, consAddr)
+	expTokens = expTokens.Sub(sdk.TokensFromConsensusPower(2))
+	require.Equal(t, expTokens, validator.GetTokens())
+
+	// third offence after the window elapsed: back to the base penalty
+	rejoin()
+	ctx = ctx.WithBlockTime(ctx.BlockHeader().Time.Add(params.DowntimeEscalationWindow + time.Second))
+	missBlocks()
+	info, _ = keeper.GetValidatorSigningInfo(ctx, consAddr)
+	require.Equal(t, int64(1), info.DowntimeOffences)
+	require.Equal(t, ctx.BlockHeader().Time.Add(params.DowntimeJailDuration), info.JailedUntil)
+	validator, _ = sk.GetValidatorByConsAddr(ctx, consAddr)
+	expTokens = expTokens.Sub(sdk.TokensFromConsensusPower(1))
+	require.Equal(t, expTokens, validator.GetTokens())
+}
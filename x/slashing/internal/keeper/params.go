@@ -42,9 +42,22 @@ func (k Keeper) SlashFractionDowntime(ctx sdk.Context) (res sdk.Dec) {
 	return
 }
 
-// GetParams returns the total set of slashing parameters.
+// DowntimeEscalationWindow - window after a downtime jailing within which
+// another downtime infraction escalates the penalty
+func (k Keeper) DowntimeEscalationWindow(ctx sdk.Context) time.Duration {
+	res := types.DefaultDowntimeEscalationWindow
+	k.paramspace.GetIfExists(ctx, types.KeyDowntimeEscalationWindow, &res)
+	return res
+}
+
+// GetParams returns the total set of slashing parameters. The parameters
+// missing from the store, as the downtime escalation ones of a chain started
+// before they were added, take their default values.
 func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
-	k.paramspace.GetParamSet(ctx, &params)
+	params = types.DefaultParams()
+	for _, pair := range params.ParamSetPairs() {
+		k.paramspace.GetIfExists(ctx, pair.Key, pair.Value)
+	}
 	return params
 }
 
@@ -0,0 +1,31 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/slashing/internal/types"
+)
+
+func TestParamChangesKeepMaxDowntimePenalties(t *testing.T) {
+	ctx, _, _, paramstore, keeper := CreateTestInput(t, TestParams())
+	cdc := codec.New()
+	params := keeper.GetParams(ctx)
+
+	// the base slash fraction can't exceed the max one
+	above := params.MaxSlashFractionDowntime.Add(sdk.NewDecWithPrec(1, 2))
+	require.Error(t, paramstore.Update(ctx, types.KeySlashFractionDowntime, cdc.MustMarshalJSON(above)))
+
+	// the max jail duration can't go below the base one
+	below := params.DowntimeJailDuration - 1
+	require.Error(t, paramstore.Update(ctx, types.KeyMaxDowntimeJailDuration, cdc.MustMarshalJSON(below)))
+	require.Equal(t, params, keeper.GetParams(ctx))
+
+	// raising the max before the base keeps the params valid
+	require.NoError(t, paramstore.Update(ctx, types.KeyMaxSlashFractionDowntime, cdc.MustMarshalJSON(above)))
+	require.NoError(t, paramstore.Update(ctx, types.KeySlashFractionDowntime, cdc.MustMarshalJSON(above)))
+	require.Equal(t, above, keeper.GetParams(ctx).SlashFractionDowntime)
+}
@@ -0,0 +1,34 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/slashing/internal/types"
+)
+
+// HandleTombstoneAppealProposal is a handler for executing a passed tombstone
+// appeal proposal. The lifted tombstone is recorded as a TombstoneAppeal and
+// announced with a tombstone_lifted event.
+func HandleTombstoneAppealProposal(ctx sdk.Context, k Keeper, p types.TombstoneAppealProposal) error {
+	if err := k.LiftTombstone(ctx, p.ConsAddress); err != nil {
+		return sdkerrors.Wrap(err, p.ConsAddress.String())
+	}
+
+	appeal := types.NewTombstoneAppeal(p.ConsAddress, p.Title, p.Description, ctx.BlockHeight(), ctx.BlockHeader().Time)
+	k.SetTombstoneAppeal(ctx, appeal)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeTombstoneLifted,
+			sdk.NewAttribute(types.AttributeKeyAddress, p.ConsAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyHeight, fmt.Sprintf("%d", appeal.Height)),
+			sdk.NewAttribute(types.AttributeKeyTitle, p.Title),
+		),
+	)
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("lifted tombstone of validator %s at height %d", p.ConsAddress, appeal.Height))
+	return nil
+}
@@ -0,0 +1,49 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/slashing/internal/types"
+)
+
+func TestHandleTombstoneAppealProposal(t *testing.T) {
+	ctx, _, _, _, keeper := CreateTestInput(t, types.DefaultParams())
+	consAddr := sdk.ConsAddress(Addrs[0])
+	ctx = ctx.WithBlockHeight(10).WithBlockTime(time.Unix(100, 0).UTC())
+	proposal := types.NewTombstoneAppealProposal("title", "description", consAddr)
+
+	// no signing info
+	require.Error(t, HandleTombstoneAppealProposal(ctx, keeper, proposal))
+
+	// not tombstoned
+	info := types.NewValidatorSigningInfo(consAddr, 0, 3, time.Unix(0, 0), false, 10)
+	keeper.SetValidatorSigningInfo(ctx, consAddr, info)
+	require.Error(t, HandleTombstoneAppealProposal(ctx, keeper, proposal))
+	require.Empty(t, keeper.GetTombstoneAppeals(ctx, consAddr))
+
+	// tombstoned
+	keeper.JailUntil(ctx, consAddr, time.Unix(253402300799, 0).UTC())
+	keeper.Tombstone(ctx, consAddr)
+	require.NoError(t, HandleTombstoneAppealProposal(ctx, keeper, proposal))
+
+	info, found := keeper.GetValidatorSigningInfo(ctx, consAddr)
+	require.True(t, found)
+	require.False(t, info.Tombstoned)
+	require.Equal(t, ctx.BlockHeader().Time, info.JailedUntil)
+	require.Equal(t, int64(0), info.MissedBlocksCounter)
+
+	appeals := keeper.GetTombstoneAppeals(ctx, consAddr)
+	require.Equal(t, []types.TombstoneAppeal{
+		types.NewTombstoneAppeal(consAddr, "title", "description", 10, ctx.BlockHeader().Time),
+	}, appeals)
+
+	events := ctx.EventManager().Events()
+	require.Equal(t, types.EventTypeTombstoneLifted, events[len(events)-1].Type)
+
+	// the tombstone can only be lifted once
+	require.Error(t, HandleTombstoneAppealProposal(ctx, keeper, proposal))
+}
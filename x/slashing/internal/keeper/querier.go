@@ -7,6 +7,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/rest"
 	"github.com/cosmos/cosmos-sdk/x/slashing/internal/types"
 )
 
@@ -23,6 +24,9 @@ func NewQuerier(k Keeper) sdk.Querier {
 		case types.QuerySigningInfos:
 			return querySigningInfos(ctx, req, k)
 
+		case types.QueryTombstoneAppeals:
+			return queryTombstoneAppeals(ctx, req, k)
+
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown %s query endpoint: %s", types.ModuleName, path[0])
 		}
@@ -90,3 +94,36 @@ func querySigningInfos(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte
 
 	return res, nil
 }
+
+func queryTombstoneAppeals(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryTombstoneAppealsParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	var appeals []types.TombstoneAppeal
+	if params.ConsAddress.Empty() {
+		k.IterateTombstoneAppeals(ctx, func(appeal types.TombstoneAppeal) (stop bool) {
+			appeals = append(appeals, appeal)
+			return false
+		})
+	} else {
+		appeals = k.GetTombstoneAppeals(ctx, params.ConsAddress)
+	}
+
+	start, end := client.Paginate(len(appeals), params.Page, params.Limit, rest.DefaultLimit)
+	if start < 0 || end < 0 {
+		appeals = []types.TombstoneAppeal{}
+	} else {
+		appeals = appeals[start:end]
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, appeals)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
@@ -0,0 +1,69 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/slashing/internal/types"
+)
+
+// SetTombstoneAppeal stores the audit record of a lifted tombstone
+func (k Keeper) SetTombstoneAppeal(ctx sdk.Context, appeal types.TombstoneAppeal) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(appeal)
+	store.Set(types.GetTombstoneAppealKey(appeal.ConsAddress, appeal.Height), bz)
+}
+
+// IterateTombstoneAppeals iterates over the stored TombstoneAppeal records of
+// every validator
+func (k Keeper) IterateTombstoneAppeals(ctx sdk.Context,
+	handler func(appeal types.TombstoneAppeal) (stop bool)) {
+
+	k.iterateTombstoneAppeals(ctx, types.TombstoneAppealKey, handler)
+}
+
+// GetTombstoneAppeals returns all the TombstoneAppeal records of a validator
+// ordered by height
+func (k Keeper) GetTombstoneAppeals(ctx sdk.Context, consAddr sdk.ConsAddress) (appeals []types.TombstoneAppeal) {
+	k.iterateTombstoneAppeals(ctx, types.GetTombstoneAppealPrefixKey(consAddr), func(appeal types.TombstoneAppeal) bool {
+		appeals = append(appeals, appeal)
+		return false
+	})
+	return appeals
+}
+
+func (k Keeper) iterateTombstoneAppeals(ctx sdk.Context, prefix []byte,
+	handler func(appeal types.TombstoneAppeal) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var appeal types.TombstoneAppeal
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &appeal)
+		if handler(appeal) {
+			break
+		}
+	}
+}
+
+// LiftTombstone clears the tombstone of a validator and allows it to unjail
+// right away. The missed block history is reset so that the validator is not
+// immediately jailed for downtime upon rebonding. It returns an error if the
+// validator has no signing info or is not tombstoned.
+func (k Keeper) LiftTombstone(ctx sdk.Context, consAddr sdk.ConsAddress) error {
+	signInfo, found := k.GetValidatorSigningInfo(ctx, consAddr)
+	if !found {
+		return types.ErrNoSigningInfoFound
+	}
+
+	if !signInfo.Tombstoned {
+		return types.ErrValidatorNotTombstoned
+	}
+
+	signInfo.Tombstoned = false
+	signInfo.JailedUntil = ctx.BlockHeader().Time
+	signInfo.MissedBlocksCounter = 0
+	signInfo.IndexOffset = 0
+	k.clearValidatorMissedBlockBitArray(ctx, consAddr)
+	k.SetValidatorSigningInfo(ctx, consAddr, signInfo)
+	return nil
+}
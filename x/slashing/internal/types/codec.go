@@ -7,6 +7,7 @@ import (
 // RegisterCodec registers concrete types on codec
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgUnjail{}, "cosmos-sdk/MsgUnjail", nil)
+	cdc.RegisterConcrete(TombstoneAppealProposal{}, "cosmos-sdk/TombstoneAppealProposal", nil)
 }
 
 // ModuleCdc defines the module codec
@@ -6,12 +6,18 @@ const (
 	EventTypeSlash    = "slash"
 	EventTypeLiveness = "liveness"
 
+	EventTypeTombstoneLifted = "tombstone_lifted"
+
 	AttributeKeyAddress      = "address"
 	AttributeKeyHeight       = "height"
 	AttributeKeyPower        = "power"
 	AttributeKeyReason       = "reason"
 	AttributeKeyJailed       = "jailed"
 	AttributeKeyMissedBlocks = "missed_blocks"
+	AttributeKeyJailDuration = "jail_duration"
+	AttributeKeyFraction     = "slash_fraction"
+	AttributeKeyOffences     = "downtime_offences"
+	AttributeKeyTitle        = "title"
 
 	AttributeValueDoubleSign       = "double_sign"
 	AttributeValueMissingSignature = "missing_signature"
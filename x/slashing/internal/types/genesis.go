@@ -12,17 +12,21 @@ type GenesisState struct {
 	Params       Params                          `json:"params" yaml:"params"`
 	SigningInfos map[string]ValidatorSigningInfo `json:"signing_infos" yaml:"signing_infos"`
 	MissedBlocks map[string][]MissedBlock        `json:"missed_blocks" yaml:"missed_blocks"`
+	// TombstoneAppeals records every tombstone lifted through governance
+	TombstoneAppeals []TombstoneAppeal `json:"tombstone_appeals" yaml:"tombstone_appeals"`
 }
 
 // NewGenesisState creates a new GenesisState object
 func NewGenesisState(
 	params Params, signingInfos map[string]ValidatorSigningInfo, missedBlocks map[string][]MissedBlock,
+	tombstoneAppeals []TombstoneAppeal,
 ) GenesisState {
 
 	return GenesisState{
-		Params:       params,
-		SigningInfos: signingInfos,
-		MissedBlocks: missedBlocks,
+		Params:           params,
+		SigningInfos:     signingInfos,
+		MissedBlocks:     missedBlocks,
+		TombstoneAppeals: tombstoneAppeals,
 	}
 }
 
@@ -43,9 +47,10 @@ func NewMissedBlock(index int64, missed bool) MissedBlock {
 // DefaultGenesisState - default GenesisState used by Cosmos Hub
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
-		Params:       DefaultParams(),
-		SigningInfos: make(map[string]ValidatorSigningInfo),
-		MissedBlocks: make(map[string][]MissedBlock),
+		Params:           DefaultParams(),
+		SigningInfos:     make(map[string]ValidatorSigningInfo),
+		MissedBlocks:     make(map[string][]MissedBlock),
+		TombstoneAppeals: []TombstoneAppeal{},
 	}
 }
 
@@ -76,5 +81,34 @@ func ValidateGenesis(data GenesisState) error {
 		return fmt.Errorf("signed blocks window must be at least 10, is %d", signedWindow)
 	}
 
+	if data.Params.DowntimeEscalationWindow < 0 {
+		return fmt.Errorf("downtime escalation window cannot be negative, is %s", data.Params.DowntimeEscalationWindow.String())
+	}
+
+	jailMultiplier := data.Params.DowntimeJailMultiplier
+	if jailMultiplier.IsNil() || jailMultiplier.LT(sdk.OneDec()) {
+		return fmt.Errorf("downtime jail multiplier should be greater than or equal to one, is %s", jailMultiplier.String())
+	}
+
+	slashMultiplier := data.Params.DowntimeSlashMultiplier
+	if slashMultiplier.IsNil() || slashMultiplier.LT(sdk.OneDec()) {
+		return fmt.Errorf("downtime slash multiplier should be greater than or equal to one, is %s", slashMultiplier.String())
+	}
+
+	maxDowntime := data.Params.MaxSlashFractionDowntime
+	if maxDowntime.IsNil() || maxDowntime.GT(sdk.OneDec()) {
+		return fmt.Errorf("max slashing fraction downtime should be less than or equal to one, is %s", maxDowntime.String())
+	}
+
+	if err := data.Params.ValidateParamSet(); err != nil {
+		return err
+	}
+
+	for _, appeal := range data.TombstoneAppeals {
+		if appeal.ConsAddress.Empty() {
+			return fmt.Errorf("tombstone appeal at height %d has an empty consensus address", appeal.Height)
+		}
+	}
+
 	return nil
 }
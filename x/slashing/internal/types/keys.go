@@ -28,10 +28,13 @@ const (
 // - 0x02<consAddress_Bytes><period_Bytes>: bool
 //
 // - 0x03<accAddr_Bytes>: crypto.PubKey
+//
+// - 0x04<consAddress_Bytes><height_Bytes>: TombstoneAppeal
 var (
 	ValidatorSigningInfoKey         = []byte{0x01} // Prefix for signing info
 	ValidatorMissedBlockBitArrayKey = []byte{0x02} // Prefix for missed block bit array
 	AddrPubkeyRelationKey           = []byte{0x03} // Prefix for address-pubkey relation
+	TombstoneAppealKey              = []byte{0x04} // Prefix for tombstone appeal records
 )
 
 // GetValidatorSigningInfoKey - stored by *Consensus* address (not operator address)
@@ -64,3 +67,16 @@ func GetValidatorMissedBlockBitArrayKey(v sdk.ConsAddress, i int64) []byte {
 func GetAddrPubkeyRelationKey(address []byte) []byte {
 	return append(AddrPubkeyRelationKey, address...)
 }
+
+// GetTombstoneAppealPrefixKey - stored by *Consensus* address (not operator address)
+func GetTombstoneAppealPrefixKey(v sdk.ConsAddress) []byte {
+	return append(TombstoneAppealKey, v.Bytes()...)
+}
+
+// GetTombstoneAppealKey - stored by *Consensus* address and the height at which
+// the tombstone was lifted
+func GetTombstoneAppealKey(v sdk.ConsAddress, height int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(height))
+	return append(GetTombstoneAppealPrefixKey(v), b...)
+}
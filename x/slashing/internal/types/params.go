@@ -13,12 +13,19 @@ const (
 	DefaultParamspace           = ModuleName
 	DefaultSignedBlocksWindow   = int64(100)
 	DefaultDowntimeJailDuration = 60 * 10 * time.Second
+
+	DefaultDowntimeEscalationWindow = 60 * 60 * 24 * 7 * time.Second
+	DefaultMaxDowntimeJailDuration  = 60 * 60 * 24 * 7 * time.Second
 )
 
 var (
 	DefaultMinSignedPerWindow      = sdk.NewDecWithPrec(5, 1)
 	DefaultSlashFractionDoubleSign = sdk.NewDec(1).Quo(sdk.NewDec(20))
 	DefaultSlashFractionDowntime   = sdk.NewDec(1).Quo(sdk.NewDec(100))
+
+	DefaultDowntimeJailMultiplier   = sdk.NewDec(2)
+	DefaultDowntimeSlashMultiplier  = sdk.NewDec(2)
+	DefaultMaxSlashFractionDowntime = sdk.NewDec(1).Quo(sdk.NewDec(20))
 )
 
 // Parameter store keys
@@ -28,11 +35,19 @@ var (
 	KeyDowntimeJailDuration    = []byte("DowntimeJailDuration")
 	KeySlashFractionDoubleSign = []byte("SlashFractionDoubleSign")
 	KeySlashFractionDowntime   = []byte("SlashFractionDowntime")
+
+	KeyDowntimeEscalationWindow = []byte("DowntimeEscalationWindow")
+	KeyDowntimeJailMultiplier   = []byte("DowntimeJailMultiplier")
+	KeyMaxDowntimeJailDuration  = []byte("MaxDowntimeJailDuration")
+	KeyDowntimeSlashMultiplier  = []byte("DowntimeSlashMultiplier")
+	KeyMaxSlashFractionDowntime = []byte("MaxSlashFractionDowntime")
 )
 
-// ParamKeyTable for slashing module
+// ParamKeyTable for slashing module. The default params stand for the ones
+// missing from the store when the params are validated as a whole.
 func ParamKeyTable() params.KeyTable {
-	return params.NewKeyTable().RegisterParamSet(&Params{})
+	defaults := DefaultParams()
+	return params.NewKeyTable().RegisterParamSet(&defaults)
 }
 
 // Params - used for initializing default parameter for slashing at genesis
@@ -42,34 +57,57 @@ type Params struct {
 	DowntimeJailDuration    time.Duration `json:"downtime_jail_duration" yaml:"downtime_jail_duration"`
 	SlashFractionDoubleSign sdk.Dec       `json:"slash_fraction_double_sign" yaml:"slash_fraction_double_sign"`
 	SlashFractionDowntime   sdk.Dec       `json:"slash_fraction_downtime" yaml:"slash_fraction_downtime"`
+
+	// Repeated downtime within DowntimeEscalationWindow of the previous
+	// downtime jailing multiplies the jail duration and the slash fraction
+	// once per prior offence, up to the configured maximums.
+	DowntimeEscalationWindow time.Duration `json:"downtime_escalation_window" yaml:"downtime_escalation_window"`
+	DowntimeJailMultiplier   sdk.Dec       `json:"downtime_jail_multiplier" yaml:"downtime_jail_multiplier"`
+	MaxDowntimeJailDuration  time.Duration `json:"max_downtime_jail_duration" yaml:"max_downtime_jail_duration"`
+	DowntimeSlashMultiplier  sdk.Dec       `json:"downtime_slash_multiplier" yaml:"downtime_slash_multiplier"`
+	MaxSlashFractionDowntime sdk.Dec       `json:"max_slash_fraction_downtime" yaml:"max_slash_fraction_downtime"`
 }
 
 // NewParams creates a new Params object
 func NewParams(
 	signedBlocksWindow int64, minSignedPerWindow sdk.Dec, downtimeJailDuration time.Duration,
 	slashFractionDoubleSign, slashFractionDowntime sdk.Dec,
+	downtimeEscalationWindow time.Duration, downtimeJailMultiplier sdk.Dec,
+	maxDowntimeJailDuration time.Duration, downtimeSlashMultiplier, maxSlashFractionDowntime sdk.Dec,
 ) Params {
 
 	return Params{
-		SignedBlocksWindow:      signedBlocksWindow,
-		MinSignedPerWindow:      minSignedPerWindow,
-		DowntimeJailDuration:    downtimeJailDuration,
-		SlashFractionDoubleSign: slashFractionDoubleSign,
-		SlashFractionDowntime:   slashFractionDowntime,
+		SignedBlocksWindow:       signedBlocksWindow,
+		MinSignedPerWindow:       minSignedPerWindow,
+		DowntimeJailDuration:     downtimeJailDuration,
+		SlashFractionDoubleSign:  slashFractionDoubleSign,
+		SlashFractionDowntime:    slashFractionDowntime,
+		DowntimeEscalationWindow: downtimeEscalationWindow,
+		DowntimeJailMultiplier:   downtimeJailMultiplier,
+		MaxDowntimeJailDuration:  maxDowntimeJailDuration,
+		DowntimeSlashMultiplier:  downtimeSlashMultiplier,
+		MaxSlashFractionDowntime: maxSlashFractionDowntime,
 	}
 }
 
 // String implements the stringer interface for Params
 func (p Params) String() string {
 	return fmt.Sprintf(`Slashing Params:
-  SignedBlocksWindow:      %d
-  MinSignedPerWindow:      %s
-  DowntimeJailDuration:    %s
-  SlashFractionDoubleSign: %s
-  SlashFractionDowntime:   %s`,
+  SignedBlocksWindow:       %d
+  MinSignedPerWindow:       %s
+  DowntimeJailDuration:     %s
+  SlashFractionDoubleSign:  %s
+  SlashFractionDowntime:    %s
+  DowntimeEscalationWindow: %s
+  DowntimeJailMultiplier:   %s
+  MaxDowntimeJailDuration:  %s
+  DowntimeSlashMultiplier:  %s
+  MaxSlashFractionDowntime: %s`,
 		p.SignedBlocksWindow, p.MinSignedPerWindow,
 		p.DowntimeJailDuration, p.SlashFractionDoubleSign,
-		p.SlashFractionDowntime)
+		p.SlashFractionDowntime, p.DowntimeEscalationWindow,
+		p.DowntimeJailMultiplier, p.MaxDowntimeJailDuration,
+		p.DowntimeSlashMultiplier, p.MaxSlashFractionDowntime)
 }
 
 // ParamSetPairs - Implements params.ParamSet
@@ -80,17 +118,86 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 		params.NewParamSetPair(KeyDowntimeJailDuration, &p.DowntimeJailDuration, validateDowntimeJailDuration),
 		params.NewParamSetPair(KeySlashFractionDoubleSign, &p.SlashFractionDoubleSign, validateSlashFractionDoubleSign),
 		params.NewParamSetPair(KeySlashFractionDowntime, &p.SlashFractionDowntime, validateSlashFractionDowntime),
+		params.NewParamSetPair(KeyDowntimeEscalationWindow, &p.DowntimeEscalationWindow, validateDowntimeEscalationWindow),
+		params.NewParamSetPair(KeyDowntimeJailMultiplier, &p.DowntimeJailMultiplier, validateDowntimeMultiplier),
+		params.NewParamSetPair(KeyMaxDowntimeJailDuration, &p.MaxDowntimeJailDuration, validateDowntimeJailDuration),
+		params.NewParamSetPair(KeyDowntimeSlashMultiplier, &p.DowntimeSlashMultiplier, validateDowntimeMultiplier),
+		params.NewParamSetPair(KeyMaxSlashFractionDowntime, &p.MaxSlashFractionDowntime, validateSlashFractionDowntime),
 	}
 }
 
+// ValidateParamSet implements params.ParamSetValidator, checking that the
+// maximum downtime penalties are at least their base values, so that no
+// parameter change can break it.
+func (p Params) ValidateParamSet() error {
+	if p.MaxDowntimeJailDuration < p.DowntimeJailDuration {
+		return fmt.Errorf(
+			"max downtime jail duration %s must be at least the downtime jail duration %s",
+			p.MaxDowntimeJailDuration, p.DowntimeJailDuration,
+		)
+	}
+
+	if p.MaxSlashFractionDowntime.IsNil() || p.SlashFractionDowntime.IsNil() {
+		return fmt.Errorf("downtime slash fractions must be set")
+	}
+	if p.MaxSlashFractionDowntime.LT(p.SlashFractionDowntime) {
+		return fmt.Errorf(
+			"max slash fraction downtime %s must be at least the slash fraction downtime %s",
+			p.MaxSlashFractionDowntime, p.SlashFractionDowntime,
+		)
+	}
+
+	return nil
+}
+
 // DefaultParams defines the parameters for this module
 func DefaultParams() Params {
 	return NewParams(
 		DefaultSignedBlocksWindow, DefaultMinSignedPerWindow, DefaultDowntimeJailDuration,
 		DefaultSlashFractionDoubleSign, DefaultSlashFractionDowntime,
+		DefaultDowntimeEscalationWindow, DefaultDowntimeJailMultiplier,
+		DefaultMaxDowntimeJailDuration, DefaultDowntimeSlashMultiplier, DefaultMaxSlashFractionDowntime,
 	)
 }
 
+// DowntimePenalty returns the jail duration and the slash fraction for a
+// downtime infraction given the number of prior downtime offences within the
+// escalation window. Each prior offence multiplies the base jail duration and
+// slash fraction by their respective multipliers, capped at the configured
+// maximums.
+func (p Params) DowntimePenalty(priorOffences int64) (time.Duration, sdk.Dec) {
+	jailDuration := p.DowntimeJailDuration
+	slashFraction := p.SlashFractionDowntime
+
+	for i := int64(0); i < priorOffences; i++ {
+		jail := sdk.NewDec(int64(jailDuration)).Mul(p.DowntimeJailMultiplier)
+		if jail.GTE(sdk.NewDec(int64(p.MaxDowntimeJailDuration))) {
+			jailDuration = p.MaxDowntimeJailDuration
+		} else {
+			jailDuration = time.Duration(jail.TruncateInt64())
+		}
+
+		slashFraction = slashFraction.Mul(p.DowntimeSlashMultiplier)
+		if slashFraction.GT(p.MaxSlashFractionDowntime) {
+			slashFraction = p.MaxSlashFractionDowntime
+		}
+
+		if jailDuration == p.MaxDowntimeJailDuration && slashFraction.Equal(p.MaxSlashFractionDowntime) {
+			break
+		}
+	}
+
+	// never reduce the penalty below the base values
+	if jailDuration < p.DowntimeJailDuration {
+		jailDuration = p.DowntimeJailDuration
+	}
+	if slashFraction.LT(p.SlashFractionDowntime) {
+		slashFraction = p.SlashFractionDowntime
+	}
+
+	return jailDuration, slashFraction
+}
+
 func validateSignedBlocksWindow(i interface{}) error {
 	v, ok := i.(int64)
 	if !ok {
@@ -164,3 +271,29 @@ func validateSlashFractionDowntime(i interface{}) error {
 
 	return nil
 }
+
+func validateDowntimeEscalationWindow(i interface{}) error {
+	v, ok := i.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v < 0 {
+		return fmt.Errorf("downtime escalation window cannot be negative: %s", v)
+	}
+
+	return nil
+}
+
+func validateDowntimeMultiplier(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.LT(sdk.OneDec()) {
+		return fmt.Errorf("downtime multiplier must be at least one: %s", v)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestDowntimePenalty(t *testing.T) {
+	params := DefaultParams()
+	params.DowntimeJailDuration = time.Hour
+	params.MaxDowntimeJailDuration = 5 * time.Hour
+	params.SlashFractionDowntime = sdk.NewDecWithPrec(1, 2)
+	params.MaxSlashFractionDowntime = sdk.NewDecWithPrec(3, 2)
+
+	tests := []struct {
+		priorOffences int64
+		expJail       time.Duration
+		expFraction   sdk.Dec
+	}{
+		{0, time.Hour, sdk.NewDecWithPrec(1, 2)},
+		{1, 2 * time.Hour, sdk.NewDecWithPrec(2, 2)},
+		{2, 4 * time.Hour, sdk.NewDecWithPrec(3, 2)},
+		{3, 5 * time.Hour, sdk.NewDecWithPrec(3, 2)},
+		{1000, 5 * time.Hour, sdk.NewDecWithPrec(3, 2)},
+	}
+
+	for _, tc := range tests {
+		jail, fraction := params.DowntimePenalty(tc.priorOffences)
+		require.Equal(t, tc.expJail, jail, "prior offences: %d", tc.priorOffences)
+		require.True(t, tc.expFraction.Equal(fraction), "prior offences: %d, fraction: %s", tc.priorOffences, fraction)
+	}
+
+	// multipliers of one keep the base penalty
+	params.DowntimeJailMultiplier = sdk.OneDec()
+	params.DowntimeSlashMultiplier = sdk.OneDec()
+	jail, fraction := params.DowntimePenalty(10)
+	require.Equal(t, time.Hour, jail)
+	require.True(t, sdk.NewDecWithPrec(1, 2).Equal(fraction))
+}
+
+func TestPriorDowntimeOffences(t *testing.T) {
+	info := NewValidatorSigningInfo(sdk.ConsAddress("addr"), 0, 0, time.Unix(0, 0), false, 0)
+	require.Equal(t, int64(0), info.PriorDowntimeOffences(time.Unix(10, 0), time.Hour))
+
+	info.DowntimeOffences = 2
+	info.LastDowntimeJail = time.Unix(0, 0)
+	require.Equal(t, int64(2), info.PriorDowntimeOffences(time.Unix(3600, 0), time.Hour))
+	require.Equal(t, int64(0), info.PriorDowntimeOffences(time.Unix(3601, 0), time.Hour))
+}
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+const (
+	// ProposalTypeTombstoneAppeal defines the type for a TombstoneAppealProposal
+	ProposalTypeTombstoneAppeal = "TombstoneAppeal"
+)
+
+// Assert TombstoneAppealProposal implements govtypes.Content at compile-time
+var _ govtypes.Content = TombstoneAppealProposal{}
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeTombstoneAppeal)
+	govtypes.RegisterProposalTypeCodec(TombstoneAppealProposal{}, "cosmos-sdk/TombstoneAppealProposal")
+}
+
+// TombstoneAppealProposal lifts the tombstone of a validator that was
+// provably misconfigured, allowing it to unjail again
+type TombstoneAppealProposal struct {
+	Title       string          `json:"title" yaml:"title"`
+	Description string          `json:"description" yaml:"description"`
+	ConsAddress sdk.ConsAddress `json:"cons_address" yaml:"cons_address"`
+}
+
+// NewTombstoneAppealProposal creates a new tombstone appeal proposal.
+func NewTombstoneAppealProposal(title, description string, consAddr sdk.ConsAddress) TombstoneAppealProposal {
+	return TombstoneAppealProposal{title, description, consAddr}
+}
+
+// GetTitle returns the title of a tombstone appeal proposal.
+func (tap TombstoneAppealProposal) GetTitle() string { return tap.Title }
+
+// GetDescription returns the description of a tombstone appeal proposal.
+func (tap TombstoneAppealProposal) GetDescription() string { return tap.Description }
+
+// ProposalRoute returns the routing key of a tombstone appeal proposal.
+func (tap TombstoneAppealProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of a tombstone appeal proposal.
+func (tap TombstoneAppealProposal) ProposalType() string { return ProposalTypeTombstoneAppeal }
+
+// ValidateBasic runs basic stateless validity checks
+func (tap TombstoneAppealProposal) ValidateBasic() error {
+	err := govtypes.ValidateAbstract(tap)
+	if err != nil {
+		return err
+	}
+	if tap.ConsAddress.Empty() {
+		return ErrEmptyConsAddress
+	}
+
+	return nil
+}
+
+// String implements the Stringer interface.
+func (tap TombstoneAppealProposal) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`Tombstone Appeal Proposal:
+  Title:             %s
+  Description:       %s
+  Consensus Address: %s
+`, tap.Title, tap.Description, tap.ConsAddress))
+	return b.String()
+}
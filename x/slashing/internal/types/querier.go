@@ -11,6 +11,8 @@ const (
 	QueryParameters   = "parameters"
 	QuerySigningInfo  = "signingInfo"
 	QuerySigningInfos = "signingInfos"
+
+	QueryTombstoneAppeals = "tombstoneAppeals"
 )
 
 // QuerySigningInfoParams defines the params for the following queries:
@@ -34,3 +36,17 @@ type QuerySigningInfosParams struct {
 func NewQuerySigningInfosParams(page, limit int) QuerySigningInfosParams {
 	return QuerySigningInfosParams{page, limit}
 }
+
+// QueryTombstoneAppealsParams defines the params for the following queries:
+// - 'custom/slashing/tombstoneAppeals'
+//
+// An empty ConsAddress returns the appeals of every validator.
+type QueryTombstoneAppealsParams struct {
+	ConsAddress sdk.ConsAddress
+	Page, Limit int
+}
+
+// NewQueryTombstoneAppealsParams creates a new QueryTombstoneAppealsParams instance
+func NewQueryTombstoneAppealsParams(consAddr sdk.ConsAddress, page, limit int) QueryTombstoneAppealsParams {
+	return QueryTombstoneAppealsParams{consAddr, page, limit}
+}
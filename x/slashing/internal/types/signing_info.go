@@ -15,6 +15,8 @@ type ValidatorSigningInfo struct {
 	JailedUntil         time.Time       `json:"jailed_until" yaml:"jailed_until"`                   // timestamp validator cannot be unjailed until
 	Tombstoned          bool            `json:"tombstoned" yaml:"tombstoned"`                       // whether or not a validator has been tombstoned (killed out of validator set)
 	MissedBlocksCounter int64           `json:"missed_blocks_counter" yaml:"missed_blocks_counter"` // missed blocks counter (to avoid scanning the array every time)
+	DowntimeOffences    int64           `json:"downtime_offences" yaml:"downtime_offences"`         // number of downtime jailings within the escalation window
+	LastDowntimeJail    time.Time       `json:"last_downtime_jail" yaml:"last_downtime_jail"`       // timestamp of the most recent downtime jailing
 }
 
 // NewValidatorSigningInfo creates a new ValidatorSigningInfo instance
@@ -41,7 +43,22 @@ func (i ValidatorSigningInfo) String() string {
   Index Offset:          %d
   Jailed Until:          %v
   Tombstoned:            %t
-  Missed Blocks Counter: %d`,
+  Missed Blocks Counter: %d
+  Downtime Offences:     %d
+  Last Downtime Jail:    %v`,
 		i.Address, i.StartHeight, i.IndexOffset, i.JailedUntil,
-		i.Tombstoned, i.MissedBlocksCounter)
+		i.Tombstoned, i.MissedBlocksCounter, i.DowntimeOffences,
+		i.LastDowntimeJail)
+}
+
+// PriorDowntimeOffences returns the number of downtime offences that count
+// towards escalating the penalty of a new downtime infraction at blockTime. The
+// history is forgotten once more than window has elapsed since the last
+// downtime jailing.
+func (i ValidatorSigningInfo) PriorDowntimeOffences(blockTime time.Time, window time.Duration) int64 {
+	if i.DowntimeOffences == 0 || blockTime.Sub(i.LastDowntimeJail) > window {
+		return 0
+	}
+
+	return i.DowntimeOffences
 }
@@ -0,0 +1,43 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// TombstoneAppeal is the audit record of a tombstone lifted through a passed
+// TombstoneAppealProposal
+type TombstoneAppeal struct {
+	ConsAddress sdk.ConsAddress `json:"cons_address" yaml:"cons_address"` // validator consensus address
+	Title       string          `json:"title" yaml:"title"`               // title of the proposal that lifted the tombstone
+	Description string          `json:"description" yaml:"description"`   // description of the proposal that lifted the tombstone
+	Height      int64           `json:"height" yaml:"height"`             // height at which the tombstone was lifted
+	Time        time.Time       `json:"time" yaml:"time"`                 // block time at which the tombstone was lifted
+}
+
+// NewTombstoneAppeal creates a new TombstoneAppeal instance
+func NewTombstoneAppeal(
+	consAddr sdk.ConsAddress, title, description string, height int64, time time.Time,
+) TombstoneAppeal {
+
+	return TombstoneAppeal{
+		ConsAddress: consAddr,
+		Title:       title,
+		Description: description,
+		Height:      height,
+		Time:        time,
+	}
+}
+
+// String implements the stringer interface for TombstoneAppeal
+func (a TombstoneAppeal) String() string {
+	return fmt.Sprintf(`Tombstone Appeal:
+  Consensus Address: %s
+  Title:             %s
+  Description:       %s
+  Height:            %d
+  Time:              %v`,
+		a.ConsAddress, a.Title, a.Description, a.Height, a.Time)
+}
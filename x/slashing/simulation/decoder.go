@@ -35,6 +35,12 @@ func DecodeStore(cdc *codec.Codec, kvA, kvB tmkv.Pair) string {
 		bechPKB := sdk.MustBech32ifyPubKey(sdk.Bech32PubKeyTypeAccPub, pubKeyB)
 		return fmt.Sprintf("PubKeyA: %s\nPubKeyB: %s", bechPKA, bechPKB)
 
+	case bytes.Equal(kvA.Key[:1], types.TombstoneAppealKey):
+		var appealA, appealB types.TombstoneAppeal
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvA.Value, &appealA)
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvB.Value, &appealB)
+		return fmt.Sprintf("%v\n%v", appealA, appealB)
+
 	default:
 		panic(fmt.Sprintf("invalid slashing key prefix %X", kvA.Key[:1]))
 	}
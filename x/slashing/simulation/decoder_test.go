@@ -37,11 +37,13 @@ func TestDecodeStore(t *testing.T) {
 	info := types.NewValidatorSigningInfo(consAddr1, 0, 1, time.Now().UTC(), false, 0)
 	bechPK := sdk.MustBech32ifyPubKey(sdk.Bech32PubKeyTypeAccPub, delPk1)
 	missed := true
+	appeal := types.NewTombstoneAppeal(consAddr1, "title", "description", 10, time.Now().UTC())
 
 	kvPairs := tmkv.Pairs{
 		tmkv.Pair{Key: types.GetValidatorSigningInfoKey(consAddr1), Value: cdc.MustMarshalBinaryLengthPrefixed(info)},
 		tmkv.Pair{Key: types.GetValidatorMissedBlockBitArrayKey(consAddr1, 6), Value: cdc.MustMarshalBinaryLengthPrefixed(missed)},
 		tmkv.Pair{Key: types.GetAddrPubkeyRelationKey(delAddr1), Value: cdc.MustMarshalBinaryLengthPrefixed(delPk1)},
+		tmkv.Pair{Key: types.GetTombstoneAppealKey(consAddr1, 10), Value: cdc.MustMarshalBinaryLengthPrefixed(appeal)},
 		tmkv.Pair{Key: []byte{0x99}, Value: []byte{0x99}},
 	}
 
@@ -52,6 +54,7 @@ func TestDecodeStore(t *testing.T) {
 		{"ValidatorSigningInfo", fmt.Sprintf("%v\n%v", info, info)},
 		{"ValidatorMissedBlockBitArray", fmt.Sprintf("missedA: %v\nmissedB: %v", missed, missed)},
 		{"AddrPubkeyRelation", fmt.Sprintf("PubKeyA: %s\nPubKeyB: %s", bechPK, bechPK)},
+		{"TombstoneAppeal", fmt.Sprintf("%v\n%v", appeal, appeal)},
 		{"other", ""},
 	}
 	for i, tt := range tests {
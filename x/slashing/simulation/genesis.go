@@ -22,6 +22,12 @@ const (
 	DowntimeJailDuration    = "downtime_jail_duration"
 	SlashFractionDoubleSign = "slash_fraction_double_sign"
 	SlashFractionDowntime   = "slash_fraction_downtime"
+
+	DowntimeEscalationWindow = "downtime_escalation_window"
+	DowntimeJailMultiplier   = "downtime_jail_multiplier"
+	MaxDowntimeJailDuration  = "max_downtime_jail_duration"
+	DowntimeSlashMultiplier  = "downtime_slash_multiplier"
+	MaxSlashFractionDowntime = "max_slash_fraction_downtime"
 )
 
 // GenSignedBlocksWindow randomized SignedBlocksWindow
@@ -49,6 +55,30 @@ func GenSlashFractionDowntime(r *rand.Rand) sdk.Dec {
 	return sdk.NewDec(1).Quo(sdk.NewDec(int64(r.Intn(200) + 1)))
 }
 
+// GenDowntimeEscalationWindow randomized DowntimeEscalationWindow
+func GenDowntimeEscalationWindow(r *rand.Rand) time.Duration {
+	return time.Duration(simulation.RandIntBetween(r, 0, 60*60*24*14)) * time.Second
+}
+
+// GenDowntimeMultiplier randomized DowntimeJailMultiplier and DowntimeSlashMultiplier
+func GenDowntimeMultiplier(r *rand.Rand) sdk.Dec {
+	return sdk.OneDec().Add(sdk.NewDecWithPrec(int64(r.Intn(31)), 1))
+}
+
+// GenMaxDowntimeJailDuration randomized MaxDowntimeJailDuration, at least downtimeJailDuration
+func GenMaxDowntimeJailDuration(r *rand.Rand, downtimeJailDuration time.Duration) time.Duration {
+	return downtimeJailDuration + time.Duration(simulation.RandIntBetween(r, 0, 60*60*24*7))*time.Second
+}
+
+// GenMaxSlashFractionDowntime randomized MaxSlashFractionDowntime, between slashFractionDowntime and one
+func GenMaxSlashFractionDowntime(r *rand.Rand, slashFractionDowntime sdk.Dec) sdk.Dec {
+	maxFraction := slashFractionDowntime.Add(sdk.NewDecWithPrec(int64(r.Intn(10)), 2))
+	if maxFraction.GT(sdk.OneDec()) {
+		return sdk.OneDec()
+	}
+	return maxFraction
+}
+
 // RandomizedGenState generates a random GenesisState for slashing
 func RandomizedGenState(simState *module.SimulationState) {
 	var signedBlocksWindow int64
@@ -81,12 +111,44 @@ func RandomizedGenState(simState *module.SimulationState) {
 		func(r *rand.Rand) { slashFractionDowntime = GenSlashFractionDowntime(r) },
 	)
 
+	var downtimeEscalationWindow time.Duration
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, DowntimeEscalationWindow, &downtimeEscalationWindow, simState.Rand,
+		func(r *rand.Rand) { downtimeEscalationWindow = GenDowntimeEscalationWindow(r) },
+	)
+
+	var downtimeJailMultiplier sdk.Dec
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, DowntimeJailMultiplier, &downtimeJailMultiplier, simState.Rand,
+		func(r *rand.Rand) { downtimeJailMultiplier = GenDowntimeMultiplier(r) },
+	)
+
+	var maxDowntimeJailDuration time.Duration
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, MaxDowntimeJailDuration, &maxDowntimeJailDuration, simState.Rand,
+		func(r *rand.Rand) { maxDowntimeJailDuration = GenMaxDowntimeJailDuration(r, downtimeJailDuration) },
+	)
+
+	var downtimeSlashMultiplier sdk.Dec
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, DowntimeSlashMultiplier, &downtimeSlashMultiplier, simState.Rand,
+		func(r *rand.Rand) { downtimeSlashMultiplier = GenDowntimeMultiplier(r) },
+	)
+
+	var maxSlashFractionDowntime sdk.Dec
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, MaxSlashFractionDowntime, &maxSlashFractionDowntime, simState.Rand,
+		func(r *rand.Rand) { maxSlashFractionDowntime = GenMaxSlashFractionDowntime(r, slashFractionDowntime) },
+	)
+
 	params := types.NewParams(
 		signedBlocksWindow, minSignedPerWindow, downtimeJailDuration,
 		slashFractionDoubleSign, slashFractionDowntime,
+		downtimeEscalationWindow, downtimeJailMultiplier,
+		maxDowntimeJailDuration, downtimeSlashMultiplier, maxSlashFractionDowntime,
 	)
 
-	slashingGenesis := types.NewGenesisState(params, nil, nil)
+	slashingGenesis := types.NewGenesisState(params, nil, nil, nil)
 
 	fmt.Printf("Selected randomly generated slashing parameters:\n%s\n", codec.MustMarshalJSONIndent(simState.Cdc, slashingGenesis.Params))
 	simState.GenState[types.ModuleName] = simState.Cdc.MustMarshalJSON(slashingGenesis)
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+func init() {
+	utils.RegisterMsgRenderer(types.RouterKey, types.MsgDelegate{}.Type(), renderMsgDelegate)
+	utils.RegisterMsgRenderer(types.RouterKey, types.MsgUndelegate{}.Type(), renderMsgUndelegate)
+	utils.RegisterMsgRenderer(types.RouterKey, types.MsgBeginRedelegate{}.Type(), renderMsgBeginRedelegate)
+}
+
+func renderMsgDelegate(msg sdk.Msg) (utils.MsgPreview, error) {
+	delegate, ok := msg.(types.MsgDelegate)
+	if !ok {
+		return utils.MsgPreview{}, fmt.Errorf("unexpected msg type %T", msg)
+	}
+
+	return utils.MsgPreview{Summary: fmt.Sprintf("Delegate %s from %s to validator %s",
+		utils.FormatCoin(delegate.Amount), delegate.DelegatorAddress, delegate.ValidatorAddress)}, nil
+}
+
+func renderMsgUndelegate(msg sdk.Msg) (utils.MsgPreview, error) {
+	undelegate, ok := msg.(types.MsgUndelegate)
+	if !ok {
+		return utils.MsgPreview{}, fmt.Errorf("unexpected msg type %T", msg)
+	}
+
+	return utils.MsgPreview{Summary: fmt.Sprintf("Undelegate %s of %s from validator %s",
+		utils.FormatCoin(undelegate.Amount), undelegate.DelegatorAddress, undelegate.ValidatorAddress)}, nil
+}
+
+func renderMsgBeginRedelegate(msg sdk.Msg) (utils.MsgPreview, error) {
+	redelegate, ok := msg.(types.MsgBeginRedelegate)
+	if !ok {
+		return utils.MsgPreview{}, fmt.Errorf("unexpected msg type %T", msg)
+	}
+
+	return utils.MsgPreview{Summary: fmt.Sprintf("Redelegate %s of %s from validator %s to validator %s",
+		utils.FormatCoin(redelegate.Amount), redelegate.DelegatorAddress,
+		redelegate.ValidatorSrcAddress, redelegate.ValidatorDstAddress)}, nil
+}
@@ -36,7 +36,11 @@ func BeginBlocker(k Keeper, ctx sdk.Context, _ abci.RequestBeginBlock) {
 
 		if !k.HasHandler(plan.Name) {
 			upgradeMsg := fmt.Sprintf("UPGRADE \"%s\" NEEDED at %s: %s", plan.Name, plan.DueAt(), plan.Info)
-			// We don't have an upgrade handler for this upgrade name, meaning this software is out of date so shutdown
+			// We don't have an upgrade handler for this upgrade name, meaning this software is out of date so shutdown.
+			// Write the plan to disk first, so a supervisor can switch to the new binary.
+			if err := k.DumpUpgradeInfoToDisk(ctx, plan); err != nil {
+				ctx.Logger().Error(fmt.Sprintf("failed to write upgrade info to disk: %s", err))
+			}
 			ctx.Logger().Error(upgradeMsg)
 			panic(upgradeMsg)
 		}
@@ -2,6 +2,8 @@ package upgrade_test
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
 	"testing"
 	"time"
 
@@ -30,8 +32,13 @@ type TestSuite struct {
 var s TestSuite
 
 func setupTest(height int64, skip map[int64]bool) TestSuite {
+	return setupTestWithHome(height, skip, "")
+}
+
+// setupTestWithHome sets up a test suite whose node writes the upgrade info to home.
+func setupTestWithHome(height int64, skip map[int64]bool, home string) TestSuite {
 	db := dbm.NewMemDB()
-	app := simapp.NewSimApp(log.NewNopLogger(), db, nil, true, skip, 0)
+	app := simapp.NewSimApp(log.NewNopLogger(), db, nil, true, skip, home, 0)
 	genesisState := simapp.NewDefaultGenesisState()
 	stateBytes, err := codec.MarshalJSONIndent(app.Codec(), genesisState)
 	if err != nil {
@@ -121,7 +128,9 @@ func VerifyDoUpgrade(t *testing.T) {
 	})
 
 	t.Log("Verify that the upgrade can be successfully applied with a handler")
-	s.keeper.SetUpgradeHandler("test", func(ctx sdk.Context, plan upgrade.Plan) {})
+	s.keeper.SetUpgradeHandler("test", func(ctx sdk.Context, plan upgrade.Plan, vm module.VersionMap) (module.VersionMap, error) {
+		return vm, nil
+	})
 	require.NotPanics(t, func() {
 		s.module.BeginBlock(newCtx, req)
 	})
@@ -137,7 +146,9 @@ func VerifyDoUpgradeWithCtx(t *testing.T, newCtx sdk.Context, proposalName strin
 	})
 
 	t.Log("Verify that the upgrade can be successfully applied with a handler")
-	s.keeper.SetUpgradeHandler(proposalName, func(ctx sdk.Context, plan upgrade.Plan) {})
+	s.keeper.SetUpgradeHandler(proposalName, func(ctx sdk.Context, plan upgrade.Plan, vm module.VersionMap) (module.VersionMap, error) {
+		return vm, nil
+	})
 	require.NotPanics(t, func() {
 		s.module.BeginBlock(newCtx, req)
 	})
@@ -149,7 +160,10 @@ func TestHaltIfTooNew(t *testing.T) {
 	s := setupTest(10, map[int64]bool{})
 	t.Log("Verify that we don't panic with registered plan not in database at all")
 	var called int
-	s.keeper.SetUpgradeHandler("future", func(ctx sdk.Context, plan upgrade.Plan) { called++ })
+	s.keeper.SetUpgradeHandler("future", func(ctx sdk.Context, plan upgrade.Plan, vm module.VersionMap) (module.VersionMap, error) {
+		called++
+		return vm, nil
+	})
 
 	newCtx := s.ctx.WithBlockHeight(s.ctx.BlockHeight() + 1).WithBlockTime(time.Now())
 	req := abci.RequestBeginBlock{Header: newCtx.BlockHeader()}
@@ -393,3 +407,95 @@ func TestUpgradeWithoutSkip(t *testing.T) {
 	VerifyDoUpgrade(t)
 	VerifyDone(t, s.ctx, "test")
 }
+
+func TestDumpUpgradeInfoToDisk(t *testing.T) {
+	home, err := ioutil.TempDir("", "upgrade_home")
+	require.NoError(t, err)
+	defer os.RemoveAll(home)
+
+	s := setupTestWithHome(10, map[int64]bool{}, home)
+	info := `{"binaries":{"any":{"url":"https://example.com/simd","checksum":"sha256:` +
+		`e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"}}}`
+	err = s.handler(s.ctx, upgrade.SoftwareUpgradeProposal{Title: "prop", Plan: upgrade.Plan{Name: "test", Height: s.ctx.BlockHeight() + 1, Info: info}})
+	require.NoError(t, err)
+
+	_, found, err := s.keeper.ReadUpgradeInfoFromDisk()
+	require.NoError(t, err)
+	require.False(t, found)
+
+	t.Log("Verify the plan is written to disk before halting")
+	newCtx := s.ctx.WithBlockHeight(s.ctx.BlockHeight() + 1).WithBlockTime(time.Now())
+	req := abci.RequestBeginBlock{Header: newCtx.BlockHeader()}
+	require.Panics(t, func() {
+		s.module.BeginBlock(newCtx, req)
+	})
+
+	upgradeInfo, found, err := s.keeper.ReadUpgradeInfoFromDisk()
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, upgrade.UpgradeInfo{Name: "test", Height: newCtx.BlockHeight(), Info: info}, upgradeInfo)
+
+	planInfo, structured, err := upgradeInfo.PlanInfo()
+	require.NoError(t, err)
+	require.True(t, structured)
+	binary, ok := planInfo.Binary(upgrade.CurrentPlatform())
+	require.True(t, ok)
+	require.Equal(t, "https://example.com/simd", binary.URL)
+}
+
+func TestRejectInvalidPlanInfo(t *testing.T) {
+	s := setupTest(10, map[int64]bool{})
+	err := s.handler(s.ctx, upgrade.SoftwareUpgradeProposal{Title: "prop", Plan: upgrade.Plan{Name: "test", Height: s.ctx.BlockHeight() + 1, Info: `{"binaries":{}}`}})
+	require.Error(t, err)
+	require.True(t, errors.Is(sdkerrors.ErrInvalidRequest, err), err)
+}
+
+func TestModuleVersionsOnUpgrade(t *testing.T) {
+	s := setupTest(10, map[int64]bool{})
+
+	t.Log("Verify the module versions are set on InitChain")
+	vm := s.keeper.GetModuleVersionMap(s.ctx)
+	require.Equal(t, uint64(1), vm[upgrade.ModuleName])
+	require.Equal(t, uint64(1), vm["bank"])
+
+	err := s.handler(s.ctx, upgrade.SoftwareUpgradeProposal{Title: "prop", Plan: upgrade.Plan{Name: "v2", Height: s.ctx.BlockHeight() + 1}})
+	require.NoError(t, err)
+
+	t.Log("Verify the versions returned by the handler are stored")
+	s.keeper.SetUpgradeHandler("v2", func(ctx sdk.Context, plan upgrade.Plan, fromVM module.VersionMap) (module.VersionMap, error) {
+		require.Equal(t, vm, fromVM)
+		return module.VersionMap{"bank": 2}, nil
+	})
+
+	newCtx := s.ctx.WithBlockHeight(s.ctx.BlockHeight() + 1).WithBlockTime(time.Now())
+	req := abci.RequestBeginBlock{Header: newCtx.BlockHeader()}
+	require.NotPanics(t, func() {
+		s.module.BeginBlock(newCtx, req)
+	})
+
+	vm["bank"] = 2
+	require.Equal(t, vm, s.keeper.GetModuleVersionMap(newCtx))
+	VerifyDone(t, newCtx, "v2")
+
+	t.Log("Verify the versions are exported in genesis")
+	var gs upgrade.GenesisState
+	require.NoError(t, codec.New().UnmarshalJSON(s.module.ExportGenesis(newCtx), &gs))
+	require.Equal(t, vm, gs.VersionMap())
+}
+
+func TestFailedMigrationsHalt(t *testing.T) {
+	s := setupTest(10, map[int64]bool{})
+	err := s.handler(s.ctx, upgrade.SoftwareUpgradeProposal{Title: "prop", Plan: upgrade.Plan{Name: "v2", Height: s.ctx.BlockHeight() + 1}})
+	require.NoError(t, err)
+
+	s.keeper.SetUpgradeHandler("v2", func(ctx sdk.Context, plan upgrade.Plan, fromVM module.VersionMap) (module.VersionMap, error) {
+		return nil, errors.New("migration failed")
+	})
+
+	newCtx := s.ctx.WithBlockHeight(s.ctx.BlockHeight() + 1).WithBlockTime(time.Now())
+	req := abci.RequestBeginBlock{Header: newCtx.BlockHeader()}
+	require.Panics(t, func() {
+		s.module.BeginBlock(newCtx, req)
+	})
+	VerifyNotDone(t, newCtx, "v2")
+}
@@ -14,10 +14,14 @@ const (
 	QuerierKey                        = types.QuerierKey
 	PlanByte                          = types.PlanByte
 	DoneByte                          = types.DoneByte
+	VersionMapByte                    = types.VersionMapByte
 	ProposalTypeSoftwareUpgrade       = types.ProposalTypeSoftwareUpgrade
 	ProposalTypeCancelSoftwareUpgrade = types.ProposalTypeCancelSoftwareUpgrade
 	QueryCurrent                      = types.QueryCurrent
 	QueryApplied                      = types.QueryApplied
+	PlatformAny                       = types.PlatformAny
+	ChecksumSHA256                    = types.ChecksumSHA256
+	UpgradeInfoFileName               = types.UpgradeInfoFileName
 )
 
 var (
@@ -27,6 +31,17 @@ var (
 	NewSoftwareUpgradeProposal       = types.NewSoftwareUpgradeProposal
 	NewCancelSoftwareUpgradeProposal = types.NewCancelSoftwareUpgradeProposal
 	NewQueryAppliedParams            = types.NewQueryAppliedParams
+	IsStructuredInfo                 = types.IsStructuredInfo
+	ParsePlanInfo                    = types.ParsePlanInfo
+	CurrentPlatform                  = types.CurrentPlatform
+	NewUpgradeInfo                   = types.NewUpgradeInfo
+	UpgradeInfoFilePath              = types.UpgradeInfoFilePath
+	WriteUpgradeInfo                 = types.WriteUpgradeInfo
+	ReadUpgradeInfo                  = types.ReadUpgradeInfo
+	NewMigrationsUpgradeHandler      = types.NewMigrationsUpgradeHandler
+	NewGenesisState                  = types.NewGenesisState
+	DefaultGenesisState              = types.DefaultGenesisState
+	ModuleVersionsFromMap            = types.ModuleVersionsFromMap
 	NewKeeper                        = keeper.NewKeeper
 	NewQuerier                       = keeper.NewQuerier
 )
@@ -37,5 +52,10 @@ type (
 	SoftwareUpgradeProposal       = types.SoftwareUpgradeProposal
 	CancelSoftwareUpgradeProposal = types.CancelSoftwareUpgradeProposal
 	QueryAppliedParams            = types.QueryAppliedParams
+	PlanInfo                      = types.PlanInfo
+	BinaryInfo                    = types.BinaryInfo
+	UpgradeInfo                   = types.UpgradeInfo
+	ModuleVersion                 = types.ModuleVersion
+	GenesisState                  = types.GenesisState
 	Keeper                        = keeper.Keeper
 )
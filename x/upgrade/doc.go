@@ -59,8 +59,9 @@ existing software will cease to process ABCI messages and a new version with cod
 All upgrades are coordinated by a unique upgrade name that cannot be reused on the same blockchain. In order for the upgrade
 module to know that the upgrade has been safely applied, a handler with the name of the upgrade must be installed.
 Here is an example handler for an upgrade named "my-fancy-upgrade":
-	app.upgradeKeeper.SetUpgradeHandler("my-fancy-upgrade", func(ctx sdk.Context, plan upgrade.Plan) {
+	app.upgradeKeeper.SetUpgradeHandler("my-fancy-upgrade", func(ctx sdk.Context, plan upgrade.Plan, fromVM module.VersionMap) (module.VersionMap, error) {
 		// Perform any migrations of the state store needed for this upgrade
+		return app.mm.RunMigrations(ctx, app.configurator, fromVM, nil)
 	})
 
 This upgrade handler performs the dual function of alerting the upgrade module that the named upgrade has been applied,
@@ -79,6 +80,30 @@ To perform the actual halt of the blockchain, the upgrade keeper simply panics w
 from proceeding but doesn't actually exit the process. Exiting the process can cause issues for other nodes that start
 to lose connectivity with the exiting nodes, thus this module prefers to just halt but not exit.
 
+Module Migrations
+
+Each module is at a consensus version, starting at 1, which it increments on every state-breaking change
+of its store by implementing module.HasConsensusVersion; modules still at version 1 don't implement it.
+Along with a new version, a module registers the migration from the previous version with the
+Configurator passed to its RegisterMigrations method:
+	func (am AppModule) RegisterMigrations(cfg module.Configurator) {
+		cfg.RegisterMigration(types.ModuleName, 1, func(ctx sdk.Context) error {
+			return am.keeper.MigrateV1ToV2(ctx)
+		})
+	}
+
+The upgrade keeper stores the consensus versions of all modules and passes them to the upgrade handler, which
+returns the versions after the upgrade. Manager.RunMigrations runs the registered migrations of all modules in
+order, modules missing from the previous version map being at version 1, and initializes the default genesis
+of the modules whose stores are added by the store upgrades of the upgrade only. Most handlers can thus simply
+be created with NewMigrationsUpgradeHandler, given the store upgrades also passed to UpgradeableStoreLoader:
+	app.upgradeKeeper.SetUpgradeHandler("v2", upgrade.NewMigrationsUpgradeHandler(app.mm, app.configurator, storeUpgrades["v2"]))
+
+The module versions are exported as part of the upgrade genesis. A genesis can only be imported by a binary
+whose modules are at the exported versions, and state whose migrations have not run cannot be exported.
+Chains started before module versions were stored have an empty version map: all their modules are migrated
+from version 1.
+
 Automation and Plan.Info
 
 We have deprecated calling out to scripts, instead with propose https://github.com/regen-network/cosmosd
@@ -88,6 +113,20 @@ specified here https://github.com/regen-network/cosmosd/blob/master/README.md#au
 This will allow a properly configured cosmsod daemon to auto-download new binaries and auto-upgrade.
 As noted there, this is intended more for full nodes than validators.
 
+When Plan.Info is a JSON object it is parsed as a PlanInfo, which maps platforms (e.g. "linux/amd64",
+or "any") to the URL and "sha256:<hex>" checksum of the binary to run after the upgrade:
+	{"binaries":{"linux/amd64":{"url":"https://...","checksum":"sha256:..."}}}
+Structured info is validated when the plan is proposed, so a malformed plan never reaches the halt height.
+
+Right before halting, the keeper writes the plan name, height and info to <home>/data/upgrade-info.json.
+The supervise command, which server.AddCommands adds to the daemon, reads this file to restart the node
+with the binary staged under <home>/upgrades/<name>/bin, verifying its checksum against the PlanInfo when
+one is given. The new binary reads the same file to apply the store upgrades of the upgrade when its stores
+are loaded:
+	app.SetStoreLoader(baseapp.UpgradeableStoreLoader(
+		upgrade.UpgradeInfoFilePath(home), map[string]*storetypes.StoreUpgrades{"v2": {Added: []string{"foo"}}},
+	))
+
 Cancelling Upgrades
 
 There are two ways to cancel a planned upgrade - with on-chain governance or off-chain social consensus.
@@ -0,0 +1,16 @@
+package upgrade
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// InitGenesis stores the module versions the genesis was exported at, no sense
+// in serializing future upgrades
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	k.SetModuleVersionMap(ctx, data.VersionMap())
+}
+
+// ExportGenesis returns the stored module versions
+func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	return NewGenesisState(k.GetModuleVersionMap(ctx))
+}
@@ -10,19 +10,24 @@ import (
 	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/module"
 	"github.com/cosmos/cosmos-sdk/x/upgrade/internal/types"
 )
 
 type Keeper struct {
+	homePath           string
 	skipUpgradeHeights map[int64]bool
 	storeKey           sdk.StoreKey
 	cdc                *codec.Codec
 	upgradeHandlers    map[string]types.UpgradeHandler
 }
 
-// NewKeeper constructs an upgrade Keeper
-func NewKeeper(skipUpgradeHeights map[int64]bool, storeKey sdk.StoreKey, cdc *codec.Codec) Keeper {
+// NewKeeper constructs an upgrade Keeper. The homePath is the node home to
+// which the upgrade info is written before halting; it may be left empty to
+// disable writing the upgrade info.
+func NewKeeper(skipUpgradeHeights map[int64]bool, storeKey sdk.StoreKey, cdc *codec.Codec, homePath string) Keeper {
 	return Keeper{
+		homePath:           homePath,
 		skipUpgradeHeights: skipUpgradeHeights,
 		storeKey:           storeKey,
 		cdc:                cdc,
@@ -113,14 +118,46 @@ func (k Keeper) HasHandler(name string) bool {
 	return ok
 }
 
-// ApplyUpgrade will execute the handler associated with the Plan and mark the plan as done.
+// SetModuleVersionMap stores the consensus versions of the given modules.
+// Versions of modules that are not part of the map are left untouched.
+func (k Keeper) SetModuleVersionMap(ctx sdk.Context, vm module.VersionMap) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), []byte{types.VersionMapByte})
+	for name, version := range vm {
+		bz := make([]byte, 8)
+		binary.BigEndian.PutUint64(bz, version)
+		store.Set([]byte(name), bz)
+	}
+}
+
+// GetModuleVersionMap returns the stored consensus versions of all modules
+func (k Keeper) GetModuleVersionMap(ctx sdk.Context) module.VersionMap {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), []byte{types.VersionMapByte})
+	iterator := store.Iterator(nil, nil)
+	defer iterator.Close()
+
+	vm := make(module.VersionMap)
+	for ; iterator.Valid(); iterator.Next() {
+		vm[string(iterator.Key())] = binary.BigEndian.Uint64(iterator.Value())
+	}
+
+	return vm
+}
+
+// ApplyUpgrade will execute the handler associated with the Plan, store the module
+// versions it returns and mark the plan as done. It panics if the handler fails,
+// halting the chain the same way a missing handler does.
 func (k Keeper) ApplyUpgrade(ctx sdk.Context, plan types.Plan) {
 	handler := k.upgradeHandlers[plan.Name]
 	if handler == nil {
 		panic("ApplyUpgrade should never be called without first checking HasHandler")
 	}
 
-	handler(ctx, plan)
+	updatedVM, err := handler(ctx, plan, k.GetModuleVersionMap(ctx))
+	if err != nil {
+		panic(fmt.Sprintf("upgrade %q failed: %s", plan.Name, err))
+	}
+
+	k.SetModuleVersionMap(ctx, updatedVM)
 
 	k.ClearUpgradePlan(ctx)
 	k.setDone(ctx, plan.Name)
@@ -130,3 +167,24 @@ func (k Keeper) ApplyUpgrade(ctx sdk.Context, plan types.Plan) {
 func (k Keeper) IsSkipHeight(height int64) bool {
 	return k.skipUpgradeHeights[height]
 }
+
+// DumpUpgradeInfoToDisk is the pre-upgrade hook run right before the node halts
+// for an upgrade it has no handler for. It writes the plan to the upgrade info
+// file in the node home, where a supervisor can pick it up to swap binaries.
+func (k Keeper) DumpUpgradeInfoToDisk(ctx sdk.Context, plan types.Plan) error {
+	if k.homePath == "" {
+		return nil
+	}
+
+	return types.WriteUpgradeInfo(k.homePath, types.NewUpgradeInfo(plan, ctx.BlockHeight()))
+}
+
+// ReadUpgradeInfoFromDisk returns the upgrade info last written to the node
+// home, if any.
+func (k Keeper) ReadUpgradeInfoFromDisk() (types.UpgradeInfo, bool, error) {
+	if k.homePath == "" {
+		return types.UpgradeInfo{}, false, nil
+	}
+
+	return types.ReadUpgradeInfo(k.homePath)
+}
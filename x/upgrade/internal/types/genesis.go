@@ -0,0 +1,72 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/types/module"
+)
+
+// ModuleVersion is the consensus version of a single module
+type ModuleVersion struct {
+	Name    string `json:"name" yaml:"name"`
+	Version uint64 `json:"version" yaml:"version"`
+}
+
+// GenesisState contains the consensus versions of the modules at the time the
+// genesis was exported, so that it can't be imported by an incompatible binary.
+type GenesisState struct {
+	ModuleVersions []ModuleVersion `json:"module_versions" yaml:"module_versions"`
+}
+
+// NewGenesisState creates a new GenesisState object
+func NewGenesisState(vm module.VersionMap) GenesisState {
+	return GenesisState{
+		ModuleVersions: ModuleVersionsFromMap(vm),
+	}
+}
+
+// DefaultGenesisState returns an empty genesis state, the module versions of a
+// new chain are set by the app on InitChain.
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// Validate performs basic genesis state validation
+func (gs GenesisState) Validate() error {
+	seen := make(map[string]bool, len(gs.ModuleVersions))
+	for _, mv := range gs.ModuleVersions {
+		if mv.Name == "" {
+			return fmt.Errorf("module name cannot be empty")
+		}
+		if mv.Version == 0 {
+			return fmt.Errorf("module %s: consensus version must be positive", mv.Name)
+		}
+		if seen[mv.Name] {
+			return fmt.Errorf("duplicate consensus version for module %s", mv.Name)
+		}
+		seen[mv.Name] = true
+	}
+
+	return nil
+}
+
+// VersionMap returns the module versions as a module.VersionMap
+func (gs GenesisState) VersionMap() module.VersionMap {
+	vm := make(module.VersionMap, len(gs.ModuleVersions))
+	for _, mv := range gs.ModuleVersions {
+		vm[mv.Name] = mv.Version
+	}
+	return vm
+}
+
+// ModuleVersionsFromMap returns the module versions of the map sorted by name
+func ModuleVersionsFromMap(vm module.VersionMap) []ModuleVersion {
+	mvs := make([]ModuleVersion, 0, len(vm))
+	for name, version := range vm {
+		mvs = append(mvs, ModuleVersion{Name: name, Version: version})
+	}
+
+	sort.Slice(mvs, func(i, j int) bool { return mvs[i].Name < mvs[j].Name })
+	return mvs
+}
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/types/module"
+)
+
+func TestGenesisStateValidate(t *testing.T) {
+	require.NoError(t, DefaultGenesisState().Validate())
+
+	gs := NewGenesisState(module.VersionMap{"bank": 2, "auth": 1})
+	require.NoError(t, gs.Validate())
+	require.Equal(t, []ModuleVersion{{"auth", 1}, {"bank", 2}}, gs.ModuleVersions)
+	require.Equal(t, module.VersionMap{"bank": 2, "auth": 1}, gs.VersionMap())
+
+	cases := map[string][]ModuleVersion{
+		"empty name":   {{"", 1}},
+		"zero version": {{"bank", 0}},
+		"duplicate":    {{"bank", 1}, {"bank", 2}},
+	}
+	for name, mvs := range cases {
+		require.Error(t, GenesisState{ModuleVersions: mvs}.Validate(), name)
+	}
+}
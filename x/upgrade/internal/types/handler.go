@@ -1,8 +1,32 @@
 package types
 
 import (
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
 )
 
-// UpgradeHandler specifies the type of function that is called when an upgrade is applied
-type UpgradeHandler func(ctx sdk.Context, plan Plan)
+// UpgradeHandler specifies the type of function that is called when an upgrade is applied.
+// It receives the consensus versions of the modules before the upgrade and returns the
+// versions after the upgrade, which are then persisted by the upgrade keeper.
+type UpgradeHandler func(ctx sdk.Context, plan Plan, fromVM module.VersionMap) (module.VersionMap, error)
+
+// NewMigrationsUpgradeHandler returns an UpgradeHandler running the store migrations
+// of all modules of the given Manager, in the Manager's order, from their stored
+// consensus versions to their current ones. The module migrations must have been
+// registered with the given Configurator. The modules whose stores are added by
+// the store upgrades of the upgrade, which may be nil, get their default genesis
+// initialized instead.
+func NewMigrationsUpgradeHandler(
+	mm *module.Manager, cfg module.Configurator, storeUpgrades *storetypes.StoreUpgrades,
+) UpgradeHandler {
+
+	var added []string
+	if storeUpgrades != nil {
+		added = storeUpgrades.Added
+	}
+
+	return func(ctx sdk.Context, _ Plan, fromVM module.VersionMap) (module.VersionMap, error) {
+		return mm.RunMigrations(ctx, cfg, fromVM, added)
+	}
+}
@@ -27,7 +27,8 @@ type Plan struct {
 	Height int64 `json:"height,omitempty"`
 
 	// Any application specific upgrade info to be included on-chain
-	// such as a git commit that validators could automatically upgrade to
+	// such as a git commit that validators could automatically upgrade to.
+	// A JSON object is parsed as a PlanInfo listing the binaries per platform.
 	Info string `json:"info,omitempty"`
 }
 
@@ -54,6 +55,14 @@ func (p Plan) ValidateBasic() error {
 	if !p.Time.IsZero() && p.Height != 0 {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "cannot set both time and height")
 	}
+	if strings.ContainsAny(p.Name, `/\`) || p.Name == "." || p.Name == ".." {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "name cannot be used as a directory name")
+	}
+	if IsStructuredInfo(p.Info) {
+		if _, err := ParsePlanInfo(p.Info); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -76,3 +85,9 @@ func (p Plan) DueAt() string {
 	}
 	return fmt.Sprintf("height: %d", p.Height)
 }
+
+// PlanInfo returns the structured form of the plan's Info. It returns false if
+// the Info is free text.
+func (p Plan) PlanInfo() (PlanInfo, bool, error) {
+	return StructuredPlanInfo(p.Info)
+}
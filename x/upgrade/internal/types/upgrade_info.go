@@ -0,0 +1,43 @@
+package types
+
+import (
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+)
+
+// The upgrade info written by a halting node and the structured plan info are
+// defined in x/upgrade/types, which the upgrade supervisor imports without
+// importing the module.
+
+// nolint
+const (
+	UpgradeInfoFileName = upgradetypes.UpgradeInfoFileName
+	PlatformAny         = upgradetypes.PlatformAny
+	ChecksumSHA256      = upgradetypes.ChecksumSHA256
+)
+
+// nolint
+var (
+	UpgradeInfoFilePath = upgradetypes.UpgradeInfoFilePath
+	WriteUpgradeInfo    = upgradetypes.WriteUpgradeInfo
+	ReadUpgradeInfo     = upgradetypes.ReadUpgradeInfo
+	IsStructuredInfo    = upgradetypes.IsStructuredInfo
+	StructuredPlanInfo  = upgradetypes.StructuredPlanInfo
+	ParsePlanInfo       = upgradetypes.ParsePlanInfo
+	CurrentPlatform     = upgradetypes.CurrentPlatform
+)
+
+// nolint
+type (
+	UpgradeInfo = upgradetypes.UpgradeInfo
+	PlanInfo    = upgradetypes.PlanInfo
+	BinaryInfo  = upgradetypes.BinaryInfo
+)
+
+// NewUpgradeInfo creates a new UpgradeInfo for a plan halting at height.
+func NewUpgradeInfo(plan Plan, height int64) UpgradeInfo {
+	return UpgradeInfo{
+		Name:   plan.Name,
+		Height: height,
+		Info:   plan.Info,
+	}
+}
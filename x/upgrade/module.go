@@ -2,6 +2,7 @@ package upgrade
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/gorilla/mux"
 	"github.com/spf13/cobra"
@@ -102,24 +103,33 @@ func (am AppModule) NewQuerierHandler() sdk.Querier {
 	return NewQuerier(am.keeper)
 }
 
-// InitGenesis is ignored, no sense in serializing future upgrades
+// InitGenesis stores the module versions of the exported genesis
 func (am AppModule) InitGenesis(ctx sdk.Context, data json.RawMessage) []abci.ValidatorUpdate {
+	var genesisState GenesisState
+	moduleCdc.MustUnmarshalJSON(data, &genesisState)
+	InitGenesis(ctx, am.keeper, genesisState)
 	return []abci.ValidatorUpdate{}
 }
 
-// DefaultGenesis is an empty object
+// DefaultGenesis returns a genesis state without module versions
 func (AppModuleBasic) DefaultGenesis() json.RawMessage {
-	return []byte("{}")
+	return moduleCdc.MustMarshalJSON(DefaultGenesisState())
 }
 
-// ValidateGenesis is always successful, as we ignore the value
+// ValidateGenesis performs genesis state validation for the upgrade module
 func (AppModuleBasic) ValidateGenesis(bz json.RawMessage) error {
-	return nil
+	var data GenesisState
+	if err := moduleCdc.UnmarshalJSON(bz, &data); err != nil {
+		return fmt.Errorf("failed to unmarshal %s genesis state: %w", ModuleName, err)
+	}
+
+	return data.Validate()
 }
 
-// ExportGenesis is always empty, as InitGenesis does nothing either
+// ExportGenesis exports the module versions, pending upgrades are not serialized
 func (am AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
-	return am.DefaultGenesis()
+	gs := ExportGenesis(ctx, am.keeper)
+	return moduleCdc.MustMarshalJSON(gs)
 }
 
 // BeginBlock calls the upgrade module hooks
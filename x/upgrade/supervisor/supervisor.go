@@ -0,0 +1,211 @@
+// Package supervisor implements a process supervisor that restarts a node with
+// a locally staged binary once it halts for an upgrade planned by x/upgrade.
+package supervisor
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+)
+
+// Supervisor directory layout inside the node home
+const (
+	UpgradesDir    = "upgrades"
+	GenesisUpgrade = "genesis"
+	CurrentLink    = "current"
+)
+
+// Supervisor runs a node binary as a child process and swaps it for a locally
+// staged binary once the node halts for an upgrade. Binaries are staged as
+//
+//	<home>/upgrades/genesis/bin/<name>
+//	<home>/upgrades/<upgrade name>/bin/<name>
+//
+// and <home>/upgrades/current links to the directory of the running binary.
+// The upgrade is detected through the upgrade info file that x/upgrade writes
+// to <home>/data before halting.
+type Supervisor struct {
+	Home         string
+	Name         string
+	Args         []string
+	PollInterval time.Duration
+	Stdout       io.Writer
+	Stderr       io.Writer
+	Logger       log.Logger
+}
+
+// NewSupervisor creates a new Supervisor for the named binary in home.
+func NewSupervisor(home, name string, args []string, logger log.Logger) *Supervisor {
+	return &Supervisor{
+		Home:         home,
+		Name:         name,
+		Args:         args,
+		PollInterval: time.Second,
+		Stdout:       os.Stdout,
+		Stderr:       os.Stderr,
+		Logger:       logger,
+	}
+}
+
+// UpgradeDir returns the staging directory of the named upgrade.
+func (s *Supervisor) UpgradeDir(upgradeName string) string {
+	return filepath.Join(s.Home, UpgradesDir, upgradeName)
+}
+
+// UpgradeBin returns the path of the staged binary of the named upgrade.
+func (s *Supervisor) UpgradeBin(upgradeName string) string {
+	return filepath.Join(s.UpgradeDir(upgradeName), "bin", s.Name)
+}
+
+// CurrentUpgrade returns the name of the upgrade the current link points to,
+// or GenesisUpgrade if no upgrade has been applied yet.
+func (s *Supervisor) CurrentUpgrade() (string, error) {
+	target, err := os.Readlink(filepath.Join(s.Home, UpgradesDir, CurrentLink))
+	if os.IsNotExist(err) {
+		return GenesisUpgrade, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return filepath.Base(target), nil
+}
+
+// Run starts the current binary and restarts it with the staged binary each
+// time the node halts for an upgrade. It returns once the binary exits without
+// an upgrade pending.
+func (s *Supervisor) Run() error {
+	for {
+		upgraded, err := s.runOnce()
+		if err != nil || !upgraded {
+			return err
+		}
+	}
+}
+
+// runOnce runs the current binary until it exits or halts for an upgrade, in
+// which case the staged binary is switched to and true is returned.
+func (s *Supervisor) runOnce() (bool, error) {
+	current, err := s.CurrentUpgrade()
+	if err != nil {
+		return false, err
+	}
+
+	bin := s.UpgradeBin(current)
+	s.Logger.Info("starting node", "upgrade", current, "binary", bin)
+
+	cmd := exec.Command(bin, s.Args...)
+	cmd.Stdout, cmd.Stderr = s.Stdout, s.Stderr
+	if err := cmd.Start(); err != nil {
+		return false, fmt.Errorf("failed to start %s: %w", bin, err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	ticker := time.NewTicker(s.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case exitErr := <-done:
+			// the binary may have written the upgrade info right before exiting
+			info, pending, err := s.pendingUpgrade(current)
+			if err != nil {
+				return false, err
+			}
+			if !pending {
+				return false, exitErr
+			}
+			return true, s.switchUpgrade(info)
+
+		case <-ticker.C:
+			info, pending, err := s.pendingUpgrade(current)
+			if err != nil || !pending {
+				continue
+			}
+
+			// the node halts but does not exit, so stop it before switching
+			s.Logger.Info("upgrade needed, stopping node", "upgrade", info.Name, "height", info.Height)
+			stopProcess(cmd.Process, done)
+			return true, s.switchUpgrade(info)
+		}
+	}
+}
+
+// pendingUpgrade returns the upgrade info written by the node if it names an
+// upgrade other than the current one.
+func (s *Supervisor) pendingUpgrade(current string) (upgradetypes.UpgradeInfo, bool, error) {
+	info, found, err := upgradetypes.ReadUpgradeInfo(s.Home)
+	if err != nil || !found || info.Name == current {
+		return info, false, err
+	}
+
+	return info, true, nil
+}
+
+// switchUpgrade checks the staged binary of an upgrade and points the current
+// link to it.
+func (s *Supervisor) switchUpgrade(info upgradetypes.UpgradeInfo) error {
+	if info.Name == "" || strings.ContainsAny(info.Name, `/\`) {
+		return fmt.Errorf("invalid upgrade name %q", info.Name)
+	}
+
+	bin := s.UpgradeBin(info.Name)
+	stat, err := os.Stat(bin)
+	if err != nil {
+		return fmt.Errorf("binary for upgrade %s is not staged: %w", info.Name, err)
+	}
+	if stat.IsDir() || stat.Mode().Perm()&0111 == 0 {
+		return fmt.Errorf("staged binary %s is not executable", bin)
+	}
+
+	planInfo, structured, err := info.PlanInfo()
+	if err != nil {
+		return err
+	}
+	if structured {
+		binary, ok := planInfo.Binary(upgradetypes.CurrentPlatform())
+		if !ok {
+			return fmt.Errorf("upgrade %s lists no binary for %s", info.Name, upgradetypes.CurrentPlatform())
+		}
+		if err := binary.VerifyFile(bin); err != nil {
+			return err
+		}
+	}
+
+	// replace the link atomically, so a crash never leaves it missing
+	link := filepath.Join(s.Home, UpgradesDir, CurrentLink)
+	tmpLink := link + ".tmp"
+	_ = os.Remove(tmpLink)
+	if err := os.Symlink(s.UpgradeDir(info.Name), tmpLink); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpLink, link); err != nil {
+		return err
+	}
+
+	s.Logger.Info("switched binary", "upgrade", info.Name, "binary", bin)
+	return nil
+}
+
+// stopProcess interrupts the process and kills it if it does not exit in time.
+// Signal errors are ignored as the process may have exited in the meantime.
+func stopProcess(process *os.Process, done <-chan error) {
+	_ = process.Signal(syscall.SIGTERM)
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		_ = process.Kill()
+		<-done
+	}
+}
@@ -0,0 +1,104 @@
+package supervisor
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// stageFakeBinary writes a shell script as the staged binary of an upgrade.
+func stageFakeBinary(t *testing.T, s *Supervisor, upgradeName, script string) {
+	bin := s.UpgradeBin(upgradeName)
+	require.NoError(t, os.MkdirAll(filepath.Dir(bin), 0755))
+	require.NoError(t, ioutil.WriteFile(bin, []byte("#!/bin/sh\n"+script), 0755))
+}
+
+func newTestSupervisor(t *testing.T) (*Supervisor, func()) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake binaries are shell scripts")
+	}
+
+	home, err := ioutil.TempDir("", "supervisor")
+	require.NoError(t, err)
+
+	s := NewSupervisor(home, "simd", []string{"start"}, log.NewNopLogger())
+	s.PollInterval = 10 * time.Millisecond
+	s.Stdout, s.Stderr = ioutil.Discard, ioutil.Discard
+
+	return s, func() { os.RemoveAll(home) }
+}
+
+func TestSupervisorSwitchesBinary(t *testing.T) {
+	s, cleanup := newTestSupervisor(t)
+	defer cleanup()
+
+	infoFile := filepath.Join(s.Home, "data", "upgrade-info.json")
+	marker := filepath.Join(s.Home, "upgraded")
+
+	// the genesis binary halts for the upgrade without exiting
+	stageFakeBinary(t, s, GenesisUpgrade, fmt.Sprintf(
+		"mkdir -p %s\necho '{\"name\":\"v2\",\"height\":10}' > %s\nexec sleep 60\n",
+		filepath.Dir(infoFile), infoFile,
+	))
+	// the upgraded binary records its arguments and exits
+	stageFakeBinary(t, s, "v2", fmt.Sprintf("echo \"$@\" > %s\n", marker))
+
+	require.NoError(t, s.Run())
+
+	current, err := s.CurrentUpgrade()
+	require.NoError(t, err)
+	require.Equal(t, "v2", current)
+
+	bz, err := ioutil.ReadFile(marker)
+	require.NoError(t, err)
+	require.Equal(t, "start\n", string(bz))
+}
+
+func TestSupervisorVerifiesChecksum(t *testing.T) {
+	s, cleanup := newTestSupervisor(t)
+	defer cleanup()
+
+	infoFile := filepath.Join(s.Home, "data", "upgrade-info.json")
+	info := `{\"name\":\"v2\",\"height\":10,\"info\":\"{\\\"binaries\\\":{\\\"any\\\":{\\\"url\\\":\\\"https://example.com/simd\\\",` +
+		`\\\"checksum\\\":\\\"sha256:0000000000000000000000000000000000000000000000000000000000000000\\\"}}}\"}`
+
+	// the genesis binary exits right after writing the upgrade info
+	stageFakeBinary(t, s, GenesisUpgrade, fmt.Sprintf(
+		"mkdir -p %s\necho \"%s\" > %s\nexit 1\n", filepath.Dir(infoFile), info, infoFile,
+	))
+	stageFakeBinary(t, s, "v2", "exit 0\n")
+
+	err := s.Run()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "checksum mismatch")
+
+	current, err := s.CurrentUpgrade()
+	require.NoError(t, err)
+	require.Equal(t, GenesisUpgrade, current)
+}
+
+func TestSupervisorMissingBinary(t *testing.T) {
+	s, cleanup := newTestSupervisor(t)
+	defer cleanup()
+
+	infoFile := filepath.Join(s.Home, "data", "upgrade-info.json")
+	stageFakeBinary(t, s, GenesisUpgrade, fmt.Sprintf(
+		"mkdir -p %s\necho '{\"name\":\"v2\",\"height\":10}' > %s\nexit 1\n", filepath.Dir(infoFile), infoFile,
+	))
+
+	err := s.Run()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not staged")
+
+	// without an upgrade pending the exit error is returned
+	require.NoError(t, os.Remove(infoFile))
+	stageFakeBinary(t, s, GenesisUpgrade, "exit 3\n")
+	require.Error(t, s.Run())
+}
@@ -0,0 +1,145 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	// PlatformAny is the platform key of a binary that runs on every platform
+	PlatformAny = "any"
+
+	// ChecksumSHA256 is the prefix of a SHA-256 binary checksum
+	ChecksumSHA256 = "sha256:"
+)
+
+// BinaryInfo describes where the binary for one platform can be downloaded and
+// how it can be verified once it is staged locally.
+type BinaryInfo struct {
+	URL      string `json:"url"`
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// PlanInfo is the structured form of Plan.Info. It maps platforms, in the
+// form "os/arch" (e.g. "linux/amd64") or PlatformAny, to binaries:
+//
+//	{"binaries": {"linux/amd64": {"url": "https://...", "checksum": "sha256:..."}}}
+type PlanInfo struct {
+	Binaries map[string]BinaryInfo `json:"binaries"`
+}
+
+// IsStructuredInfo returns true if a Plan.Info string holds a JSON object and
+// is therefore expected to be a PlanInfo. Any other Info is treated as free
+// text for backwards compatibility.
+func IsStructuredInfo(info string) bool {
+	return strings.HasPrefix(strings.TrimSpace(info), "{")
+}
+
+// StructuredPlanInfo returns the structured form of a Plan.Info. It returns
+// false if the Info is free text.
+func StructuredPlanInfo(info string) (PlanInfo, bool, error) {
+	if !IsStructuredInfo(info) {
+		return PlanInfo{}, false, nil
+	}
+
+	planInfo, err := ParsePlanInfo(info)
+	return planInfo, true, err
+}
+
+// ParsePlanInfo parses and validates the structured form of Plan.Info.
+func ParsePlanInfo(info string) (PlanInfo, error) {
+	var planInfo PlanInfo
+	if err := json.Unmarshal([]byte(info), &planInfo); err != nil {
+		return planInfo, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid plan info: %s", err)
+	}
+
+	return planInfo, planInfo.ValidateBasic()
+}
+
+// ValidateBasic does basic validation of a PlanInfo
+func (pi PlanInfo) ValidateBasic() error {
+	if len(pi.Binaries) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "plan info must list at least one binary")
+	}
+
+	for platform, binary := range pi.Binaries {
+		if platform != PlatformAny && len(strings.Split(platform, "/")) != 2 {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid platform %q, expected os/arch or %s", platform, PlatformAny)
+		}
+		if err := binary.ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "binary for %s", platform)
+		}
+	}
+
+	return nil
+}
+
+// Binary returns the binary for the given platform, falling back to the
+// PlatformAny binary if the platform is not listed.
+func (pi PlanInfo) Binary(platform string) (BinaryInfo, bool) {
+	if binary, ok := pi.Binaries[platform]; ok {
+		return binary, true
+	}
+
+	binary, ok := pi.Binaries[PlatformAny]
+	return binary, ok
+}
+
+// ValidateBasic does basic validation of a BinaryInfo
+func (b BinaryInfo) ValidateBasic() error {
+	u, err := url.Parse(b.URL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid binary url %q", b.URL)
+	}
+
+	if b.Checksum == "" {
+		return nil
+	}
+	if !strings.HasPrefix(b.Checksum, ChecksumSHA256) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "unsupported checksum %q, expected %s<hex>", b.Checksum, ChecksumSHA256)
+	}
+	if bz, err := hex.DecodeString(strings.TrimPrefix(b.Checksum, ChecksumSHA256)); err != nil || len(bz) != sha256.Size {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid sha256 checksum %q", b.Checksum)
+	}
+
+	return nil
+}
+
+// VerifyFile checks the binary at the given path against the checksum. Binaries
+// without a checksum are always accepted.
+func (b BinaryInfo) VerifyFile(path string) error {
+	if b.Checksum == "" {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return err
+	}
+
+	if sum := ChecksumSHA256 + hex.EncodeToString(hasher.Sum(nil)); !strings.EqualFold(sum, b.Checksum) {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", path, b.Checksum, sum)
+	}
+
+	return nil
+}
+
+// CurrentPlatform returns the os/arch platform key of the running binary.
+func CurrentPlatform() string {
+	return runtime.GOOS + "/" + runtime.GOARCH
+}
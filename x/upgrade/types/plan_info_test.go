@@ -0,0 +1,85 @@
+package types
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePlanInfo(t *testing.T) {
+	checksum := "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	cases := map[string]struct {
+		info  string
+		valid bool
+	}{
+		"platform binary": {
+			info:  `{"binaries":{"linux/amd64":{"url":"https://example.com/simd","checksum":"` + checksum + `"}}}`,
+			valid: true,
+		},
+		"any binary without checksum": {
+			info:  `{"binaries":{"any":{"url":"https://example.com/simd"}}}`,
+			valid: true,
+		},
+		"no binaries":      {info: `{"binaries":{}}`},
+		"invalid json":     {info: `{"binaries":`},
+		"invalid platform": {info: `{"binaries":{"linux":{"url":"https://example.com/simd"}}}`},
+		"invalid url":      {info: `{"binaries":{"any":{"url":"simd"}}}`},
+		"unknown checksum": {info: `{"binaries":{"any":{"url":"https://example.com/simd","checksum":"md5:abcd"}}}`},
+		"short checksum":   {info: `{"binaries":{"any":{"url":"https://example.com/simd","checksum":"sha256:abcd"}}}`},
+	}
+
+	for name, tc := range cases {
+		tc := tc // copy to local variable for scopelint
+		t.Run(name, func(t *testing.T) {
+			require.True(t, IsStructuredInfo(tc.info))
+			_, err := ParsePlanInfo(tc.info)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+
+	require.False(t, IsStructuredInfo("https://foo.bar"))
+}
+
+func TestPlanInfoBinary(t *testing.T) {
+	planInfo := PlanInfo{Binaries: map[string]BinaryInfo{
+		"linux/amd64": {URL: "https://example.com/linux"},
+		PlatformAny:   {URL: "https://example.com/any"},
+	}}
+
+	binary, ok := planInfo.Binary("linux/amd64")
+	require.True(t, ok)
+	require.Equal(t, "https://example.com/linux", binary.URL)
+
+	binary, ok = planInfo.Binary("darwin/amd64")
+	require.True(t, ok)
+	require.Equal(t, "https://example.com/any", binary.URL)
+
+	delete(planInfo.Binaries, PlatformAny)
+	_, ok = planInfo.Binary("darwin/amd64")
+	require.False(t, ok)
+}
+
+func TestBinaryInfoVerifyFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plan_info")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "simd")
+	require.NoError(t, ioutil.WriteFile(path, []byte("hello"), 0755))
+
+	binary := BinaryInfo{URL: "https://example.com/simd"}
+	require.NoError(t, binary.VerifyFile(path))
+
+	binary.Checksum = "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	require.NoError(t, binary.VerifyFile(path))
+
+	binary.Checksum = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	require.Error(t, binary.VerifyFile(path))
+}
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// UpgradeInfoFileName is the name of the file, inside the data directory of the
+// node home, to which a halting node writes the upgrade it needs.
+const UpgradeInfoFileName = "upgrade-info.json"
+
+// UpgradeInfo describes the upgrade a node halted for. It is written to disk
+// right before the node halts so that a supervisor can swap the binary.
+type UpgradeInfo struct {
+	Name   string `json:"name"`
+	Height int64  `json:"height"`
+	Info   string `json:"info,omitempty"`
+}
+
+// PlanInfo returns the structured form of the upgrade's Info, see
+// StructuredPlanInfo.
+func (ui UpgradeInfo) PlanInfo() (PlanInfo, bool, error) {
+	return StructuredPlanInfo(ui.Info)
+}
+
+// UpgradeInfoFilePath returns the path of the upgrade info file for a node home.
+func UpgradeInfoFilePath(home string) string {
+	return filepath.Join(home, "data", UpgradeInfoFileName)
+}
+
+// WriteUpgradeInfo writes the UpgradeInfo to the node home.
+func WriteUpgradeInfo(home string, info UpgradeInfo) error {
+	path := UpgradeInfoFilePath(home)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	bz, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, bz, 0600)
+}
+
+// ReadUpgradeInfo reads the UpgradeInfo from the node home. It returns false if
+// no upgrade info has been written.
+func ReadUpgradeInfo(home string) (UpgradeInfo, bool, error) {
+	var info UpgradeInfo
+
+	bz, err := ioutil.ReadFile(UpgradeInfoFilePath(home))
+	if os.IsNotExist(err) {
+		return info, false, nil
+	} else if err != nil {
+		return info, false, err
+	}
+
+	if err := json.Unmarshal(bz, &info); err != nil {
+		return info, false, fmt.Errorf("cannot parse upgrade info: %w", err)
+	}
+
+	return info, true, nil
+}